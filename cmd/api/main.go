@@ -18,7 +18,9 @@ import (
 	"github.com/spec-kit/ticket-service/internal/observability"
 	"github.com/spec-kit/ticket-service/internal/persistence"
 	"github.com/spec-kit/ticket-service/internal/repository"
+	"github.com/spec-kit/ticket-service/internal/scan"
 	"github.com/spec-kit/ticket-service/internal/service"
+	"github.com/spec-kit/ticket-service/internal/storage"
 	"github.com/spec-kit/ticket-service/internal/worker"
 )
 
@@ -37,8 +39,6 @@ func main() {
 	metrics := observability.NewMetrics()
 
 	dispatcher := events.NewInMemoryDispatcher()
-	notificationSvc := service.NewNotificationService(dispatcher, logger, cfg.Notification)
-	worker.StartNotificationWorker(notificationSvc)
 
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
@@ -58,7 +58,18 @@ func main() {
 	redis := persistence.NewRedis(cfg.Redis, logger)
 	defer redis.Close()
 
+	attachmentStore, err := storage.New(cfg.Storage)
+	if err != nil {
+		logger.Fatal("failed to init attachment storage", zap.Error(err))
+	}
+
+	attachmentScanner, err := scan.New(cfg.Scanning)
+	if err != nil {
+		logger.Fatal("failed to init attachment scanner", zap.Error(err))
+	}
+
 	pool := pg.PoolHandle()
+	transactor := repository.NewTransactor(pool)
 	userRepo := repository.NewUserRepository(pool)
 	staffRepo := repository.NewStaffRepository(pool)
 	resetRepo := repository.NewPasswordResetRepository(pool)
@@ -68,6 +79,30 @@ func main() {
 	ticketRepo := repository.NewTicketRepository(pool)
 	messageRepo := repository.NewTicketMessageRepository(pool)
 	attachmentRepo := repository.NewAttachmentRepository(pool)
+	escalationRepo := repository.NewEscalationRepository(pool)
+	workflowRepo := repository.NewWorkflowRepository(pool)
+	customFieldRepo := repository.NewCustomFieldRepository(pool)
+	ticketFormRepo := repository.NewTicketFormRepository(pool)
+	savedViewRepo := repository.NewSavedViewRepository(pool)
+	tagRepo := repository.NewTagRepository(pool)
+	ticketLinkRepo := repository.NewTicketLinkRepository(pool)
+	participantRepo := repository.NewTicketParticipantRepository(pool)
+	mentionRepo := repository.NewStaffMentionRepository(pool)
+	cannedResponseRepo := repository.NewCannedResponseRepository(pool)
+	macroRepo := repository.NewMacroRepository(pool)
+	revisionRepo := repository.NewMessageRevisionRepository(pool)
+	uploadRepo := repository.NewAttachmentUploadRepository(pool)
+
+	notificationSvc := service.NewNotificationService(service.NotificationDependencies{
+		Dispatcher:      dispatcher,
+		Logger:          logger,
+		Config:          cfg.Notification,
+		TicketRepo:      ticketRepo,
+		ParticipantRepo: participantRepo,
+		UserRepo:        userRepo,
+		StaffRepo:       staffRepo,
+	})
+	worker.StartNotificationWorker(notificationSvc)
 
 	authService := service.NewAuthService(*cfg, service.AuthDependencies{
 		UserRepo:          userRepo,
@@ -82,15 +117,33 @@ func main() {
 		StaffRepo:      staffRepo,
 	})
 
+	attachmentLinks := service.NewAttachmentLinkSigner(cfg.Links, cfg.Auth.JWTSecret)
+
 	ticketService := service.NewTicketService(service.TicketDependencies{
-		TicketRepo:     ticketRepo,
-		MessageRepo:    messageRepo,
-		AttachmentRepo: attachmentRepo,
-		DepartmentRepo: departmentRepo,
-		TeamRepo:       teamRepo,
-		StaffRepo:      staffRepo,
-		HistoryRepo:    ticketHistoryRepo,
-		Dispatcher:     dispatcher,
+		TicketRepo:       ticketRepo,
+		MessageRepo:      messageRepo,
+		AttachmentRepo:   attachmentRepo,
+		DepartmentRepo:   departmentRepo,
+		TeamRepo:         teamRepo,
+		StaffRepo:        staffRepo,
+		HistoryRepo:      ticketHistoryRepo,
+		WorkflowRepo:     workflowRepo,
+		CustomFieldRepo:  customFieldRepo,
+		TagRepo:          tagRepo,
+		LinkRepo:         ticketLinkRepo,
+		ParticipantRepo:  participantRepo,
+		UserRepo:         userRepo,
+		MentionRepo:      mentionRepo,
+		RevisionRepo:     revisionRepo,
+		UploadRepo:       uploadRepo,
+		Storage:          attachmentStore,
+		Transactor:       transactor,
+		Dispatcher:       dispatcher,
+		SLA:              cfg.SLA,
+		MessageEffects:   cfg.Messages,
+		AttachmentPolicy: cfg.Attachments,
+		LinkSigner:       attachmentLinks,
+		AuthorDisplay:    cfg.Authors,
 	})
 
 	assignmentService := service.NewAssignmentService(service.AssignmentDependencies{
@@ -101,22 +154,145 @@ func main() {
 		Dispatcher:  dispatcher,
 	})
 
-	app := fiber.New()
-	httptransport.RegisterMiddlewares(app, logger, metrics, cfg.App.RequestTimeout())
+	escalationService := service.NewEscalationService(service.EscalationDependencies{
+		EscalationRepo: escalationRepo,
+		TicketRepo:     ticketRepo,
+		StaffRepo:      staffRepo,
+		DepartmentRepo: departmentRepo,
+		HistoryRepo:    ticketHistoryRepo,
+		Transactor:     transactor,
+		Dispatcher:     dispatcher,
+		SLA:            cfg.SLA,
+		BatchSize:      cfg.Escalation.BatchSize,
+	})
+	if cfg.Escalation.Enabled && pool != nil {
+		worker.StartEscalationWorker(ctx, escalationService, cfg.Escalation.Interval(), logger)
+	}
+
+	workflowService := service.NewWorkflowService(service.WorkflowDependencies{
+		WorkflowRepo:   workflowRepo,
+		DepartmentRepo: departmentRepo,
+	})
+
+	customFieldService := service.NewCustomFieldService(service.CustomFieldDependencies{
+		CustomFieldRepo: customFieldRepo,
+		DepartmentRepo:  departmentRepo,
+	})
+
+	ticketFormService := service.NewTicketFormService(service.TicketFormDependencies{
+		TicketFormRepo:  ticketFormRepo,
+		CustomFieldRepo: customFieldRepo,
+		DepartmentRepo:  departmentRepo,
+		TeamRepo:        teamRepo,
+	})
+
+	savedViewService := service.NewSavedViewService(service.SavedViewDependencies{
+		SavedViewRepo: savedViewRepo,
+		TeamRepo:      teamRepo,
+		Tickets:       ticketService,
+	})
+
+	tagService := service.NewTagService(service.TagDependencies{
+		TagRepo: tagRepo,
+	})
+
+	mentionService := service.NewMentionService(service.MentionDependencies{
+		MentionRepo: mentionRepo,
+	})
+
+	attachmentScanService := service.NewAttachmentScanService(service.AttachmentScanDependencies{
+		Scanner:        attachmentScanner,
+		Storage:        attachmentStore,
+		UploadRepo:     uploadRepo,
+		AttachmentRepo: attachmentRepo,
+		Tickets:        ticketService,
+		Config:         cfg.Scanning,
+	})
+	if pool != nil {
+		worker.StartAttachmentScanWorker(ctx, attachmentScanService, cfg.Scanning.Interval(), logger)
+	}
+
+	attachmentService := service.NewAttachmentService(service.AttachmentDependencies{
+		Storage:    attachmentStore,
+		UploadRepo: uploadRepo,
+		Tickets:    ticketService,
+		Scans:      attachmentScanService,
+		Links:      attachmentLinks,
+	})
+
+	cannedResponseService := service.NewCannedResponseService(service.CannedResponseDependencies{
+		CannedResponseRepo: cannedResponseRepo,
+		TeamRepo:           teamRepo,
+		Tickets:            ticketService,
+	})
+
+	macroService := service.NewMacroService(service.MacroDependencies{
+		MacroRepo:    macroRepo,
+		TeamRepo:     teamRepo,
+		StaffRepo:    staffRepo,
+		TagRepo:      tagRepo,
+		WorkflowRepo: workflowRepo,
+		Tickets:      ticketService,
+		Assignments:  assignmentService,
+		Transactor:   transactor,
+	})
+
+	autoCloseService := service.NewAutoCloseService(service.AutoCloseDependencies{
+		TicketRepo:   ticketRepo,
+		MessageRepo:  messageRepo,
+		HistoryRepo:  ticketHistoryRepo,
+		WorkflowRepo: workflowRepo,
+		Transactor:   transactor,
+		Dispatcher:   dispatcher,
+		Config:       cfg.AutoClose,
+	})
+	if cfg.AutoClose.Enabled && pool != nil {
+		worker.StartAutoCloseWorker(ctx, autoCloseService, cfg.AutoClose.Interval(), logger)
+	}
+
+	// bodies are streamed so uploads can exceed the default limit without being buffered; the
+	// body limit middleware enforces the default everywhere else. An upload request carries at
+	// most one message's worth of attachments plus form overhead.
+	app := fiber.New(fiber.Config{StreamRequestBody: true, DisablePreParseMultipartForm: true})
+	uploadBodyLimit := 0
+	if cfg.Attachments.MaxMessageBytes > 0 {
+		uploadBodyLimit = int(cfg.Attachments.MaxMessageBytes) + 1<<20
+	}
+	httptransport.RegisterMiddlewares(app, logger, metrics, cfg.App.RequestTimeout(), uploadBodyLimit)
 
 	healthHandler := handlers.NewHealthHandler(cfg.App.Name, cfg.App.Version, pg, redis)
 	usersHandler := handlers.NewUsersHandler(authService)
 	staffHandler := handlers.NewStaffHandler(authService, staffService)
-	ticketsHandler := handlers.NewTicketsHandler(ticketService)
+	ticketsHandler := handlers.NewTicketsHandler(ticketService, ticketFormService)
 	staffTicketsHandler := handlers.NewStaffTicketsHandler(ticketService, assignmentService)
+	escalationHandler := handlers.NewEscalationHandler(escalationService)
+	workflowHandler := handlers.NewWorkflowHandler(workflowService)
+	customFieldHandler := handlers.NewCustomFieldHandler(customFieldService)
+	ticketFormHandler := handlers.NewTicketFormHandler(ticketFormService)
+	savedViewHandler := handlers.NewSavedViewHandler(savedViewService, ticketService)
+	tagHandler := handlers.NewTagHandler(tagService)
+	mentionHandler := handlers.NewMentionHandler(mentionService)
+	cannedResponseHandler := handlers.NewCannedResponseHandler(cannedResponseService)
+	macroHandler := handlers.NewMacroHandler(macroService)
+	attachmentHandler := handlers.NewAttachmentHandler(attachmentService)
 
 	httptransport.RegisterRoutes(app, httptransport.RouteConfig{
-		Health:         healthHandler,
-		Users:          usersHandler,
-		Staff:          staffHandler,
-		Tickets:        ticketsHandler,
-		StaffTickets:   staffTicketsHandler,
-		AuthMiddleware: authMiddleware,
+		Health:          healthHandler,
+		Users:           usersHandler,
+		Staff:           staffHandler,
+		Tickets:         ticketsHandler,
+		StaffTickets:    staffTicketsHandler,
+		Escalations:     escalationHandler,
+		Workflows:       workflowHandler,
+		CustomFields:    customFieldHandler,
+		TicketForms:     ticketFormHandler,
+		SavedViews:      savedViewHandler,
+		Tags:            tagHandler,
+		Mentions:        mentionHandler,
+		CannedResponses: cannedResponseHandler,
+		Macros:          macroHandler,
+		Attachments:     attachmentHandler,
+		AuthMiddleware:  authMiddleware,
 	})
 
 	go func() {
@@ -0,0 +1,32 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/spec-kit/ticket-service/internal/domain"
+)
+
+// CustomFieldRequest for create/update.
+type CustomFieldRequest struct {
+	Key          string                 `json:"key"`
+	Label        string                 `json:"label"`
+	Type         domain.CustomFieldType `json:"type"`
+	Options      []string               `json:"options"`
+	Required     bool                   `json:"required"`
+	DepartmentID *string                `json:"department_id"`
+	IsActive     *bool                  `json:"is_active,omitempty"`
+}
+
+// CustomFieldResponse representation.
+type CustomFieldResponse struct {
+	ID           string                 `json:"id"`
+	Key          string                 `json:"key"`
+	Label        string                 `json:"label"`
+	Type         domain.CustomFieldType `json:"type"`
+	Options      []string               `json:"options"`
+	Required     bool                   `json:"required"`
+	DepartmentID *string                `json:"department_id"`
+	IsActive     bool                   `json:"is_active"`
+	CreatedAt    time.Time              `json:"created_at"`
+	UpdatedAt    time.Time              `json:"updated_at"`
+}
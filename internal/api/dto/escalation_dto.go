@@ -0,0 +1,32 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/spec-kit/ticket-service/internal/domain"
+)
+
+// EscalationRuleRequest for create/update.
+type EscalationRuleRequest struct {
+	Name             string                     `json:"name"`
+	DepartmentID     *string                    `json:"department_id"`
+	Priorities       []domain.TicketPriority    `json:"priorities"`
+	Condition        domain.EscalationCondition `json:"condition"`
+	ThresholdMinutes int                        `json:"threshold_minutes"`
+	Action           domain.EscalationAction    `json:"action"`
+	IsActive         *bool                      `json:"is_active,omitempty"`
+}
+
+// EscalationRuleResponse representation.
+type EscalationRuleResponse struct {
+	ID               string                     `json:"id"`
+	Name             string                     `json:"name"`
+	DepartmentID     *string                    `json:"department_id"`
+	Priorities       []domain.TicketPriority    `json:"priorities"`
+	Condition        domain.EscalationCondition `json:"condition"`
+	ThresholdMinutes int                        `json:"threshold_minutes"`
+	Action           domain.EscalationAction    `json:"action"`
+	IsActive         bool                       `json:"is_active"`
+	CreatedAt        time.Time                  `json:"created_at"`
+	UpdatedAt        time.Time                  `json:"updated_at"`
+}
@@ -0,0 +1,8 @@
+package dto
+
+// ListResponse is the envelope returned by paginated list endpoints.
+type ListResponse[T any] struct {
+	Data       []T     `json:"data"`
+	NextCursor *string `json:"next_cursor"`
+	Total      int     `json:"total"`
+}
@@ -0,0 +1,63 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/spec-kit/ticket-service/internal/domain"
+)
+
+// CannedResponseRequest creates or replaces a canned response.
+type CannedResponseRequest struct {
+	Name   string  `json:"name"`
+	TeamID *string `json:"team_id,omitempty"`
+	Body   string  `json:"body"`
+}
+
+// CannedResponseResponse representation.
+type CannedResponseResponse struct {
+	ID           string    `json:"id"`
+	Name         string    `json:"name"`
+	OwnerStaffID string    `json:"owner_staff_id"`
+	TeamID       *string   `json:"team_id,omitempty"`
+	Shared       bool      `json:"shared"`
+	Body         string    `json:"body"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+// RenderedReplyResponse is a canned response filled in for a ticket.
+type RenderedReplyResponse struct {
+	ID       string `json:"id"`
+	Name     string `json:"name"`
+	TicketID string `json:"ticket_id"`
+	Body     string `json:"body"`
+}
+
+// MacroRequest creates or replaces a macro.
+type MacroRequest struct {
+	Name      string                   `json:"name"`
+	TeamID    *string                  `json:"team_id,omitempty"`
+	ReplyBody string                   `json:"reply_body"`
+	ReplyType domain.TicketMessageType `json:"reply_type"`
+	Actions   domain.MacroActions      `json:"actions"`
+}
+
+// MacroResponse representation.
+type MacroResponse struct {
+	ID           string                   `json:"id"`
+	Name         string                   `json:"name"`
+	OwnerStaffID string                   `json:"owner_staff_id"`
+	TeamID       *string                  `json:"team_id,omitempty"`
+	Shared       bool                     `json:"shared"`
+	ReplyBody    string                   `json:"reply_body,omitempty"`
+	ReplyType    domain.TicketMessageType `json:"reply_type"`
+	Actions      domain.MacroActions      `json:"actions"`
+	CreatedAt    time.Time                `json:"created_at"`
+	UpdatedAt    time.Time                `json:"updated_at"`
+}
+
+// ApplyMacroResponse returns the updated ticket and the reply the macro posted.
+type ApplyMacroResponse struct {
+	Ticket  TicketSummary          `json:"ticket"`
+	Message *TicketMessageResponse `json:"message,omitempty"`
+}
@@ -0,0 +1,24 @@
+package dto
+
+import "time"
+
+// StaffMentionResponse is one entry in a staff member's mention inbox.
+type StaffMentionResponse struct {
+	ID                 string     `json:"id"`
+	TicketID           string     `json:"ticket_id"`
+	TicketExternalKey  string     `json:"ticket_external_key"`
+	TicketTitle        string     `json:"ticket_title"`
+	MessageID          string     `json:"message_id"`
+	BodyPreview        string     `json:"body_preview"`
+	MentionedByStaffID *string    `json:"mentioned_by_staff_id"`
+	Read               bool       `json:"read"`
+	ReadAt             *time.Time `json:"read_at"`
+	CreatedAt          time.Time  `json:"created_at"`
+}
+
+// MarkMentionsRequest sets the read state of mentions; All applies to the whole inbox.
+type MarkMentionsRequest struct {
+	MentionIDs []string `json:"mention_ids"`
+	All        bool     `json:"all"`
+	Read       *bool    `json:"read"`
+}
@@ -0,0 +1,36 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/spec-kit/ticket-service/internal/domain"
+)
+
+// SavedViewRequest creates or replaces a saved view.
+type SavedViewRequest struct {
+	Name   string                 `json:"name"`
+	TeamID *string                `json:"team_id,omitempty"`
+	Filter domain.SavedViewFilter `json:"filter"`
+	Sort   string                 `json:"sort"`
+}
+
+// SavedViewResponse representation.
+type SavedViewResponse struct {
+	ID           string                 `json:"id"`
+	Name         string                 `json:"name"`
+	OwnerStaffID string                 `json:"owner_staff_id"`
+	TeamID       *string                `json:"team_id,omitempty"`
+	Shared       bool                   `json:"shared"`
+	Filter       domain.SavedViewFilter `json:"filter"`
+	Sort         string                 `json:"sort"`
+	CreatedAt    time.Time              `json:"created_at"`
+	UpdatedAt    time.Time              `json:"updated_at"`
+}
+
+// SavedViewCountResponse pairs a view with its current ticket count.
+type SavedViewCountResponse struct {
+	ID     string `json:"id"`
+	Name   string `json:"name"`
+	Shared bool   `json:"shared"`
+	Count  int    `json:"count"`
+}
@@ -27,17 +27,19 @@ type PasswordChangeRequest struct {
 
 // DepartmentRequest for create/update.
 type DepartmentRequest struct {
-	Name        string `json:"name"`
-	Description string `json:"description"`
-	IsActive    *bool  `json:"is_active,omitempty"`
+	Name                   string    `json:"name"`
+	Description            string    `json:"description"`
+	IsActive               *bool     `json:"is_active,omitempty"`
+	AllowedAttachmentTypes *[]string `json:"allowed_attachment_types,omitempty"`
 }
 
 // DepartmentResponse representation.
 type DepartmentResponse struct {
-	ID          string `json:"id"`
-	Name        string `json:"name"`
-	Description string `json:"description"`
-	IsActive    bool   `json:"is_active"`
+	ID                     string   `json:"id"`
+	Name                   string   `json:"name"`
+	Description            string   `json:"description"`
+	IsActive               bool     `json:"is_active"`
+	AllowedAttachmentTypes []string `json:"allowed_attachment_types"`
 }
 
 // TeamRequest for create/update operations.
@@ -0,0 +1,41 @@
+package dto
+
+import "time"
+
+// TagRequest for create/update.
+type TagRequest struct {
+	Name        string  `json:"name"`
+	Color       *string `json:"color"`
+	Description string  `json:"description"`
+	Deprecated  *bool   `json:"deprecated,omitempty"`
+}
+
+// MergeTagRequest folds the tag in the path into TargetID.
+type MergeTagRequest struct {
+	TargetID string `json:"target_id"`
+}
+
+// TagResponse representation.
+type TagResponse struct {
+	ID          string    `json:"id"`
+	Name        string    `json:"name"`
+	Color       *string   `json:"color"`
+	Description string    `json:"description"`
+	Deprecated  bool      `json:"deprecated"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// MergeTagResponse reports the surviving tag and how many tickets were retagged.
+type MergeTagResponse struct {
+	Tag             TagResponse `json:"tag"`
+	TicketsRetagged int         `json:"tickets_retagged"`
+}
+
+// TagUsageResponse is one row of tag usage statistics.
+type TagUsageResponse struct {
+	Tag         TagResponse `json:"tag"`
+	TicketCount int         `json:"ticket_count"`
+	OpenCount   int         `json:"open_count"`
+	LastUsedAt  *time.Time  `json:"last_used_at"`
+}
@@ -14,6 +14,7 @@ type CreateTicketRequest struct {
 	Description  string                `json:"description"`
 	Priority     domain.TicketPriority `json:"priority"`
 	Tags         []string              `json:"tags"`
+	CustomFields map[string]any        `json:"custom_fields"`
 }
 
 // TicketListQuery captures query filters for user endpoints.
@@ -28,34 +29,51 @@ type TicketListQuery struct {
 
 // TicketSummary response.
 type TicketSummary struct {
-	ID           string                `json:"id"`
-	ExternalKey  string                `json:"external_key"`
-	DepartmentID string                `json:"department_id"`
-	TeamID       *string               `json:"team_id"`
-	Title        string                `json:"title"`
-	Status       domain.TicketStatus   `json:"status"`
-	Priority     domain.TicketPriority `json:"priority"`
-	Tags         []string              `json:"tags"`
-	CreatedAt    time.Time             `json:"created_at"`
-	UpdatedAt    time.Time             `json:"updated_at"`
+	ID             string                `json:"id"`
+	ExternalKey    string                `json:"external_key"`
+	DepartmentID   string                `json:"department_id"`
+	TeamID         *string               `json:"team_id"`
+	Title          string                `json:"title"`
+	Status         domain.TicketStatus   `json:"status"`
+	WorkflowStatus *string               `json:"workflow_status"`
+	Priority       domain.TicketPriority `json:"priority"`
+	Tags           []string              `json:"tags"`
+	CustomFields   map[string]any        `json:"custom_fields"`
+	SLADueAt       *time.Time            `json:"sla_due_at"`
+	MergedIntoID   *string               `json:"merged_into_ticket_id,omitempty"`
+	CreatedAt      time.Time             `json:"created_at"`
+	UpdatedAt      time.Time             `json:"updated_at"`
+}
+
+// TicketSearchResult is a ticket summary returned from full-text search.
+type TicketSearchResult struct {
+	TicketSummary
+	Rank    float32 `json:"rank"`
+	Snippet string  `json:"snippet"`
 }
 
 // TicketDetailResponse provides full ticket info.
 type TicketDetailResponse struct {
-	ID           string                  `json:"id"`
-	ExternalKey  string                  `json:"external_key"`
-	DepartmentID string                  `json:"department_id"`
-	TeamID       *string                 `json:"team_id"`
-	Title        string                  `json:"title"`
-	Description  string                  `json:"description"`
-	Status       domain.TicketStatus     `json:"status"`
-	Priority     domain.TicketPriority   `json:"priority"`
-	Tags         []string                `json:"tags"`
-	CreatedAt    time.Time               `json:"created_at"`
-	UpdatedAt    time.Time               `json:"updated_at"`
-	ClosedAt     *time.Time              `json:"closed_at"`
-	Messages     []TicketMessageResponse `json:"messages"`
-	History      []TicketHistoryResponse `json:"history"`
+	ID             string                      `json:"id"`
+	ExternalKey    string                      `json:"external_key"`
+	DepartmentID   string                      `json:"department_id"`
+	TeamID         *string                     `json:"team_id"`
+	Title          string                      `json:"title"`
+	Description    string                      `json:"description"`
+	Status         domain.TicketStatus         `json:"status"`
+	WorkflowStatus *string                     `json:"workflow_status"`
+	Priority       domain.TicketPriority       `json:"priority"`
+	Tags           []string                    `json:"tags"`
+	CustomFields   map[string]any              `json:"custom_fields"`
+	SLADueAt       *time.Time                  `json:"sla_due_at"`
+	MergedIntoID   *string                     `json:"merged_into_ticket_id,omitempty"`
+	CreatedAt      time.Time                   `json:"created_at"`
+	UpdatedAt      time.Time                   `json:"updated_at"`
+	ClosedAt       *time.Time                  `json:"closed_at"`
+	Messages       []TicketMessageResponse     `json:"messages"`
+	History        []TicketHistoryResponse     `json:"history"`
+	Links          []TicketLinkResponse        `json:"links,omitempty"`
+	Participants   []TicketParticipantResponse `json:"participants,omitempty"`
 }
 
 // TicketMessageResponse represents thread message.
@@ -64,28 +82,72 @@ type TicketMessageResponse struct {
 	MessageType domain.TicketMessageType `json:"message_type"`
 	AuthorType  domain.MessageAuthorType `json:"author_type"`
 	AuthorID    *string                  `json:"author_id"`
+	Author      AuthorResponse           `json:"author"`
 	Body        string                   `json:"body"`
-	Attachments []AttachmentResponse     `json:"attachments"`
-	CreatedAt   time.Time                `json:"created_at"`
+	BodyFormat  domain.MessageBodyFormat `json:"body_format"`
+	// BodyHTML is the body rendered to sanitized HTML for display.
+	BodyHTML    string               `json:"body_html"`
+	Attachments []AttachmentResponse `json:"attachments"`
+	CreatedAt   time.Time            `json:"created_at"`
+	EditedAt    *time.Time           `json:"edited_at,omitempty"`
+	Redacted    bool                 `json:"redacted"`
+}
+
+// AuthorResponse is the display identity of a message author or history actor. End-users see
+// staff under the public agent alias.
+type AuthorResponse struct {
+	Name      string            `json:"name"`
+	Role      domain.AuthorRole `json:"role"`
+	AvatarURL *string           `json:"avatar_url,omitempty"`
+}
+
+// EditMessageRequest replaces the body of a staff member's own message.
+type EditMessageRequest struct {
+	Body string `json:"body"`
+}
+
+// RedactMessageRequest removes body segments and attachments from a message.
+type RedactMessageRequest struct {
+	Segments      []string `json:"segments"`
+	AttachmentIDs []string `json:"attachment_ids"`
+	Reason        string   `json:"reason"`
+}
+
+// MessageRevisionResponse is one entry in a message's admin-only revision trail.
+type MessageRevisionResponse struct {
+	ID                 string                     `json:"id"`
+	Kind               domain.MessageRevisionKind `json:"kind"`
+	EditorStaffID      *string                    `json:"editor_staff_id,omitempty"`
+	PreviousBody       *string                    `json:"previous_body,omitempty"`
+	RedactedSegments   int                        `json:"redacted_segments,omitempty"`
+	RemovedAttachments []AttachmentResponse       `json:"removed_attachments,omitempty"`
+	Reason             string                     `json:"reason,omitempty"`
+	CreatedAt          time.Time                  `json:"created_at"`
 }
 
 // AttachmentResponse metadata.
 type AttachmentResponse struct {
-	ID        string `json:"id"`
-	FileName  string `json:"file_name"`
-	MimeType  string `json:"mime_type"`
-	SizeBytes int64  `json:"size_bytes"`
-	URL       string `json:"url,omitempty"`
+	ID         string                      `json:"id"`
+	FileName   string                      `json:"file_name"`
+	MimeType   string                      `json:"mime_type"`
+	SizeBytes  int64                       `json:"size_bytes"`
+	ScanStatus domain.AttachmentScanStatus `json:"scan_status"`
+	// URL and ThumbnailURL are short-lived signed links usable without the bearer token.
+	URL          string `json:"url,omitempty"`
+	ThumbnailURL string `json:"thumbnail_url,omitempty"`
 }
 
 // CreateMessageRequest payload.
 type CreateMessageRequest struct {
-	Body        string                    `json:"body"`
+	Body string `json:"body"`
+	// BodyFormat is text (default), markdown or html.
+	BodyFormat  domain.MessageBodyFormat  `json:"body_format,omitempty"`
 	MessageType *domain.TicketMessageType `json:"message_type,omitempty"`
 	Attachments []AttachmentRequest       `json:"attachments"`
 }
 
-// AttachmentRequest describes attachment input.
+// AttachmentRequest references an upload by the storage key the upload endpoint returned.
+// File name, type and size are taken from the upload; the client-supplied values are ignored.
 type AttachmentRequest struct {
 	StorageKey string `json:"storage_key"`
 	FileName   string `json:"file_name"`
@@ -104,6 +166,77 @@ type UpdatePriorityRequest struct {
 	NewPriority domain.TicketPriority `json:"new_priority"`
 }
 
+// UpdateCustomFieldsRequest payload; null values clear a field.
+type UpdateCustomFieldsRequest struct {
+	CustomFields map[string]any `json:"custom_fields"`
+}
+
+// MergeTicketsRequest folds the listed tickets into the ticket in the path.
+type MergeTicketsRequest struct {
+	SourceTicketIDs []string `json:"source_ticket_ids"`
+}
+
+// SplitTicketRequest moves the listed messages into a new ticket.
+type SplitTicketRequest struct {
+	MessageIDs []string              `json:"message_ids"`
+	Title      string                `json:"title"`
+	Priority   domain.TicketPriority `json:"priority"`
+}
+
+// CreateTicketLinkRequest links the ticket in the path to ticket_id.
+type CreateTicketLinkRequest struct {
+	TicketID  string                `json:"ticket_id"`
+	Type      domain.TicketLinkType `json:"type"`
+	Propagate bool                  `json:"propagate"`
+}
+
+// UpdateTicketLinkRequest payload.
+type UpdateTicketLinkRequest struct {
+	Propagate bool `json:"propagate"`
+}
+
+// TicketLinkResponse describes a link from the requested ticket's side.
+type TicketLinkResponse struct {
+	ID          string                `json:"id"`
+	Type        domain.TicketLinkType `json:"type"`
+	TicketID    string                `json:"ticket_id"`
+	ExternalKey string                `json:"external_key"`
+	Title       string                `json:"title"`
+	Status      domain.TicketStatus   `json:"status"`
+	Priority    domain.TicketPriority `json:"priority"`
+	Propagate   bool                  `json:"propagate"`
+	CreatedAt   time.Time             `json:"created_at"`
+}
+
+// AddParticipantRequest adds a watcher (staff_id) or a CC (user_id or email).
+type AddParticipantRequest struct {
+	Role    domain.ParticipantRole `json:"role"`
+	StaffID string                 `json:"staff_id"`
+	UserID  string                 `json:"user_id"`
+	Email   string                 `json:"email"`
+}
+
+// AddCCRequest lets a requester copy someone on their ticket.
+type AddCCRequest struct {
+	Email string `json:"email"`
+}
+
+// TicketParticipantResponse describes a watcher or CC.
+type TicketParticipantResponse struct {
+	ID        string                 `json:"id"`
+	Role      domain.ParticipantRole `json:"role"`
+	StaffID   *string                `json:"staff_id,omitempty"`
+	UserID    *string                `json:"user_id,omitempty"`
+	Email     *string                `json:"email,omitempty"`
+	CreatedAt time.Time              `json:"created_at"`
+}
+
+// UpdateTicketTagsRequest payload.
+type UpdateTicketTagsRequest struct {
+	Add    []string `json:"add"`
+	Remove []string `json:"remove"`
+}
+
 // AssignStaffRequest payload.
 type AssignStaffRequest struct {
 	AssigneeStaffID string `json:"assignee_staff_id"`
@@ -120,7 +253,19 @@ type TicketHistoryResponse struct {
 	ChangeType    domain.TicketChangeType  `json:"change_type"`
 	ChangedByType domain.MessageAuthorType `json:"changed_by_type"`
 	ChangedByID   *string                  `json:"changed_by_id"`
+	ChangedBy     AuthorResponse           `json:"changed_by"`
 	OldValue      map[string]any           `json:"old_value"`
 	NewValue      map[string]any           `json:"new_value"`
 	CreatedAt     time.Time                `json:"created_at"`
 }
+
+// AttachmentUploadResponse describes a stored upload; pass StorageKey in a message's attachments.
+type AttachmentUploadResponse struct {
+	ID         string                      `json:"id"`
+	StorageKey string                      `json:"storage_key"`
+	FileName   string                      `json:"file_name"`
+	MimeType   string                      `json:"mime_type"`
+	SizeBytes  int64                       `json:"size_bytes"`
+	ScanStatus domain.AttachmentScanStatus `json:"scan_status"`
+	CreatedAt  time.Time                   `json:"created_at"`
+}
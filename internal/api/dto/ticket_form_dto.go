@@ -0,0 +1,51 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/spec-kit/ticket-service/internal/domain"
+)
+
+// TicketFormRequest replaces a department ticket form.
+type TicketFormRequest struct {
+	Name     string                   `json:"name"`
+	Fields   []domain.TicketFormField `json:"fields"`
+	IsActive *bool                    `json:"is_active,omitempty"`
+}
+
+// TicketFormResponse representation for admins.
+type TicketFormResponse struct {
+	ID           string                   `json:"id"`
+	DepartmentID string                   `json:"department_id"`
+	Name         string                   `json:"name"`
+	Fields       []domain.TicketFormField `json:"fields"`
+	IsActive     bool                     `json:"is_active"`
+	CreatedAt    time.Time                `json:"created_at"`
+	UpdatedAt    time.Time                `json:"updated_at"`
+}
+
+// FormOptionResponse is a selectable value.
+type FormOptionResponse struct {
+	Value string `json:"value"`
+	Label string `json:"label"`
+}
+
+// FormFieldResponse describes a field for portal rendering.
+type FormFieldResponse struct {
+	Key         string                 `json:"key"`
+	Label       string                 `json:"label"`
+	HelpText    string                 `json:"help_text,omitempty"`
+	Type        domain.CustomFieldType `json:"type"`
+	Required    bool                   `json:"required"`
+	Builtin     bool                   `json:"builtin"`
+	Options     []FormOptionResponse   `json:"options,omitempty"`
+	VisibleWhen *domain.FormCondition  `json:"visible_when,omitempty"`
+}
+
+// DepartmentFormResponse is the public form for a department.
+type DepartmentFormResponse struct {
+	ID           string              `json:"id,omitempty"`
+	DepartmentID string              `json:"department_id"`
+	Name         string              `json:"name"`
+	Fields       []FormFieldResponse `json:"fields"`
+}
@@ -0,0 +1,29 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/spec-kit/ticket-service/internal/domain"
+)
+
+// WorkflowRequest replaces a department workflow.
+type WorkflowRequest struct {
+	Name          string                      `json:"name"`
+	InitialStatus string                      `json:"initial_status"`
+	Statuses      []domain.WorkflowStatus     `json:"statuses"`
+	Transitions   []domain.WorkflowTransition `json:"transitions"`
+	IsActive      *bool                       `json:"is_active,omitempty"`
+}
+
+// WorkflowResponse representation.
+type WorkflowResponse struct {
+	ID            string                      `json:"id"`
+	DepartmentID  string                      `json:"department_id"`
+	Name          string                      `json:"name"`
+	InitialStatus string                      `json:"initial_status"`
+	Statuses      []domain.WorkflowStatus     `json:"statuses"`
+	Transitions   []domain.WorkflowTransition `json:"transitions"`
+	IsActive      bool                        `json:"is_active"`
+	CreatedAt     time.Time                   `json:"created_at"`
+	UpdatedAt     time.Time                   `json:"updated_at"`
+}
@@ -0,0 +1,171 @@
+package handlers
+
+import (
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/spec-kit/ticket-service/internal/api/dto"
+	"github.com/spec-kit/ticket-service/internal/auth"
+	"github.com/spec-kit/ticket-service/internal/domain"
+	"github.com/spec-kit/ticket-service/internal/service"
+	apperrors "github.com/spec-kit/ticket-service/pkg/util/errorutil"
+)
+
+// maxFilesPerUpload bounds the files accepted in one multipart request.
+const maxFilesPerUpload = 10
+
+// AttachmentHandler exposes attachment uploads and downloads for users and staff.
+type AttachmentHandler struct {
+	attachments *service.AttachmentService
+}
+
+// NewAttachmentHandler constructs handler.
+func NewAttachmentHandler(attachmentService *service.AttachmentService) *AttachmentHandler {
+	return &AttachmentHandler{attachments: attachmentService}
+}
+
+// UploadUserAttachments handles POST /tickets/:id/attachments (multipart field "file").
+func (h *AttachmentHandler) UploadUserAttachments(c *fiber.Ctx) error {
+	principal, ok := auth.PrincipalFromContext(c)
+	if !ok || principal.User == nil {
+		return apperrors.NewUnauthorized("user required")
+	}
+	return h.upload(c, func(file service.UploadFile) (*domain.AttachmentUpload, error) {
+		return h.attachments.UploadForUser(c.Context(), principal.User.ID, c.Params("id"), file)
+	})
+}
+
+// UploadStaffAttachments handles POST /staff/tickets/:id/attachments (multipart field "file").
+func (h *AttachmentHandler) UploadStaffAttachments(c *fiber.Ctx) error {
+	staff, err := staffPrincipal(c)
+	if err != nil {
+		return err
+	}
+	return h.upload(c, func(file service.UploadFile) (*domain.AttachmentUpload, error) {
+		return h.attachments.UploadForStaff(c.Context(), staff, c.Params("id"), file)
+	})
+}
+
+// DownloadUserAttachment handles GET /tickets/:id/attachments/:attachmentId.
+func (h *AttachmentHandler) DownloadUserAttachment(c *fiber.Ctx) error {
+	principal, ok := auth.PrincipalFromContext(c)
+	if !ok || principal.User == nil {
+		return apperrors.NewUnauthorized("user required")
+	}
+	download, err := h.attachments.DownloadForUser(c.Context(), principal.User.ID, c.Params("id"), c.Params("attachmentId"))
+	if err != nil {
+		return err
+	}
+	return sendAttachment(c, download)
+}
+
+// DownloadStaffAttachment handles GET /staff/tickets/:id/attachments/:attachmentId.
+func (h *AttachmentHandler) DownloadStaffAttachment(c *fiber.Ctx) error {
+	staff, err := staffPrincipal(c)
+	if err != nil {
+		return err
+	}
+	download, err := h.attachments.DownloadForStaff(c.Context(), staff, c.Params("id"), c.Params("attachmentId"))
+	if err != nil {
+		return err
+	}
+	return sendAttachment(c, download)
+}
+
+// DownloadLinkedAttachment handles GET /attachments/:attachmentId?expires=&signature=. The
+// signed link stands in for the bearer token.
+func (h *AttachmentHandler) DownloadLinkedAttachment(c *fiber.Ctx) error {
+	download, err := h.attachments.DownloadLinked(c.Context(), c.Params("attachmentId"), c.Query("expires"), c.Query("signature"))
+	if err != nil {
+		return err
+	}
+	c.Set(fiber.HeaderCacheControl, "private, no-store")
+	return sendAttachment(c, download)
+}
+
+// AttachmentThumbnail handles GET /attachments/:attachmentId/thumbnail?expires=&signature=.
+func (h *AttachmentHandler) AttachmentThumbnail(c *fiber.Ctx) error {
+	download, err := h.attachments.ThumbnailLinked(c.Context(), c.Params("attachmentId"), c.Query("expires"), c.Query("signature"))
+	if err != nil {
+		return err
+	}
+	c.Set(fiber.HeaderCacheControl, "private, max-age=300")
+	return sendAttachment(c, download)
+}
+
+func (h *AttachmentHandler) upload(c *fiber.Ctx, store func(service.UploadFile) (*domain.AttachmentUpload, error)) error {
+	form, err := c.MultipartForm()
+	if err != nil {
+		return apperrors.NewValidationError("multipart form required", nil)
+	}
+	files := form.File["file"]
+	if len(files) == 0 {
+		return apperrors.NewValidationError("file required", nil)
+	}
+	if len(files) > maxFilesPerUpload {
+		return apperrors.NewValidationError("too many files", map[string]any{"max_files": maxFilesPerUpload})
+	}
+	var total int64
+	for _, header := range files {
+		total += header.Size
+	}
+	if err := h.attachments.CheckUploadTotal(total); err != nil {
+		return err
+	}
+	resp := make([]dto.AttachmentUploadResponse, 0, len(files))
+	for _, header := range files {
+		upload, err := storeUploadedFile(header, store)
+		if err != nil {
+			return err
+		}
+		resp = append(resp, dto.AttachmentUploadResponse{
+			ID:         upload.ID,
+			StorageKey: upload.StorageKey,
+			FileName:   upload.FileName,
+			MimeType:   upload.MimeType,
+			SizeBytes:  upload.SizeBytes,
+			ScanStatus: upload.ScanStatus,
+			CreatedAt:  upload.CreatedAt,
+		})
+	}
+	return c.Status(http.StatusCreated).JSON(fiber.Map{"data": resp})
+}
+
+func storeUploadedFile(header *multipart.FileHeader, store func(service.UploadFile) (*domain.AttachmentUpload, error)) (*domain.AttachmentUpload, error) {
+	content, err := header.Open()
+	if err != nil {
+		return nil, apperrors.NewValidationError("unreadable file", map[string]any{"file_name": header.Filename})
+	}
+	defer content.Close()
+	return store(service.UploadFile{
+		FileName: header.Filename,
+		Size:     header.Size,
+		Content:  content,
+	})
+}
+
+// sendAttachment streams an attachment as a download; nosniff stops browsers from
+// reinterpreting uploaded content as something executable.
+func sendAttachment(c *fiber.Ctx, download *service.AttachmentDownload) error {
+	attachment := download.Attachment
+	c.Set(fiber.HeaderContentType, attachment.MimeType)
+	c.Set(fiber.HeaderContentDisposition, contentDisposition(attachment.FileName))
+	c.Set(fiber.HeaderXContentTypeOptions, "nosniff")
+	return c.SendStream(download.Content, int(attachment.SizeBytes))
+}
+
+// contentDisposition builds an attachment header with an ASCII fallback name and the
+// UTF-8 original for clients that support it.
+func contentDisposition(name string) string {
+	fallback := strings.Map(func(r rune) rune {
+		if r < 0x20 || r > 0x7e || r == '"' || r == '\\' {
+			return '_'
+		}
+		return r
+	}, name)
+	return `attachment; filename="` + fallback + `"; filename*=UTF-8''` + url.PathEscape(name)
+}
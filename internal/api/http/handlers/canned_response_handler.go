@@ -0,0 +1,137 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/spec-kit/ticket-service/internal/api/dto"
+	"github.com/spec-kit/ticket-service/internal/domain"
+	"github.com/spec-kit/ticket-service/internal/service"
+	apperrors "github.com/spec-kit/ticket-service/pkg/util/errorutil"
+)
+
+// CannedResponseHandler exposes personal and team-shared canned responses.
+type CannedResponseHandler struct {
+	responses *service.CannedResponseService
+}
+
+// NewCannedResponseHandler constructs handler.
+func NewCannedResponseHandler(responseService *service.CannedResponseService) *CannedResponseHandler {
+	return &CannedResponseHandler{responses: responseService}
+}
+
+// ListResponses handles GET /staff/canned-responses; q filters by name.
+func (h *CannedResponseHandler) ListResponses(c *fiber.Ctx) error {
+	staff, err := staffPrincipal(c)
+	if err != nil {
+		return err
+	}
+	responses, err := h.responses.ListResponses(c.Context(), staff, c.Query("q"))
+	if err != nil {
+		return err
+	}
+	resp := make([]dto.CannedResponseResponse, 0, len(responses))
+	for i := range responses {
+		resp = append(resp, cannedResponseResponse(&responses[i]))
+	}
+	return c.JSON(fiber.Map{"data": resp})
+}
+
+// CreateResponse handles POST /staff/canned-responses.
+func (h *CannedResponseHandler) CreateResponse(c *fiber.Ctx) error {
+	staff, err := staffPrincipal(c)
+	if err != nil {
+		return err
+	}
+	var req dto.CannedResponseRequest
+	if err := c.BodyParser(&req); err != nil {
+		return apperrors.NewValidationError("invalid payload", nil)
+	}
+	response, err := h.responses.CreateResponse(c.Context(), staff, cannedResponseInput(req))
+	if err != nil {
+		return err
+	}
+	return c.Status(http.StatusCreated).JSON(fiber.Map{"data": cannedResponseResponse(response)})
+}
+
+// GetResponse handles GET /staff/canned-responses/:id.
+func (h *CannedResponseHandler) GetResponse(c *fiber.Ctx) error {
+	staff, err := staffPrincipal(c)
+	if err != nil {
+		return err
+	}
+	response, err := h.responses.GetResponse(c.Context(), staff, c.Params("id"))
+	if err != nil {
+		return err
+	}
+	return c.JSON(fiber.Map{"data": cannedResponseResponse(response)})
+}
+
+// UpdateResponse handles PUT /staff/canned-responses/:id.
+func (h *CannedResponseHandler) UpdateResponse(c *fiber.Ctx) error {
+	staff, err := staffPrincipal(c)
+	if err != nil {
+		return err
+	}
+	var req dto.CannedResponseRequest
+	if err := c.BodyParser(&req); err != nil {
+		return apperrors.NewValidationError("invalid payload", nil)
+	}
+	response, err := h.responses.UpdateResponse(c.Context(), staff, c.Params("id"), cannedResponseInput(req))
+	if err != nil {
+		return err
+	}
+	return c.JSON(fiber.Map{"data": cannedResponseResponse(response)})
+}
+
+// DeleteResponse handles DELETE /staff/canned-responses/:id.
+func (h *CannedResponseHandler) DeleteResponse(c *fiber.Ctx) error {
+	staff, err := staffPrincipal(c)
+	if err != nil {
+		return err
+	}
+	if err := h.responses.DeleteResponse(c.Context(), staff, c.Params("id")); err != nil {
+		return err
+	}
+	return c.SendStatus(http.StatusNoContent)
+}
+
+// RenderResponse handles GET /staff/tickets/:id/canned-responses/:responseId.
+func (h *CannedResponseHandler) RenderResponse(c *fiber.Ctx) error {
+	staff, err := staffPrincipal(c)
+	if err != nil {
+		return err
+	}
+	response, body, err := h.responses.RenderResponse(c.Context(), staff, c.Params("id"), c.Params("responseId"))
+	if err != nil {
+		return err
+	}
+	return c.JSON(fiber.Map{"data": dto.RenderedReplyResponse{
+		ID:       response.ID,
+		Name:     response.Name,
+		TicketID: c.Params("id"),
+		Body:     body,
+	}})
+}
+
+func cannedResponseInput(req dto.CannedResponseRequest) service.CannedResponseInput {
+	return service.CannedResponseInput{
+		Name:   req.Name,
+		TeamID: req.TeamID,
+		Body:   req.Body,
+	}
+}
+
+func cannedResponseResponse(response *domain.CannedResponse) dto.CannedResponseResponse {
+	return dto.CannedResponseResponse{
+		ID:           response.ID,
+		Name:         response.Name,
+		OwnerStaffID: response.OwnerStaffID,
+		TeamID:       response.TeamID,
+		Shared:       response.TeamID != nil,
+		Body:         response.Body,
+		CreatedAt:    response.CreatedAt,
+		UpdatedAt:    response.UpdatedAt,
+	}
+}
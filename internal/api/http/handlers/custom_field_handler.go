@@ -0,0 +1,104 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/spec-kit/ticket-service/internal/api/dto"
+	"github.com/spec-kit/ticket-service/internal/domain"
+	"github.com/spec-kit/ticket-service/internal/service"
+	apperrors "github.com/spec-kit/ticket-service/pkg/util/errorutil"
+)
+
+// CustomFieldHandler exposes admin endpoints for custom field definitions.
+type CustomFieldHandler struct {
+	service *service.CustomFieldService
+}
+
+// NewCustomFieldHandler constructs handler.
+func NewCustomFieldHandler(customFieldService *service.CustomFieldService) *CustomFieldHandler {
+	return &CustomFieldHandler{service: customFieldService}
+}
+
+// CreateField handles POST /staff/custom-fields.
+func (h *CustomFieldHandler) CreateField(c *fiber.Ctx) error {
+	staff, err := staffPrincipal(c)
+	if err != nil {
+		return err
+	}
+	var req dto.CustomFieldRequest
+	if err := c.BodyParser(&req); err != nil {
+		return apperrors.NewValidationError("invalid payload", nil)
+	}
+	field, err := h.service.CreateField(c.Context(), staff, customFieldInput(req))
+	if err != nil {
+		return err
+	}
+	return c.Status(http.StatusCreated).JSON(fiber.Map{"data": customFieldResponse(field)})
+}
+
+// ListFields handles GET /staff/custom-fields.
+func (h *CustomFieldHandler) ListFields(c *fiber.Ctx) error {
+	staff, err := staffPrincipal(c)
+	if err != nil {
+		return err
+	}
+	var departmentID *string
+	if dept := c.Query("department_id"); dept != "" {
+		departmentID = &dept
+	}
+	fields, err := h.service.ListFields(c.Context(), staff, departmentID, parseBoolQuery(c, "include_inactive", false))
+	if err != nil {
+		return err
+	}
+	resp := make([]dto.CustomFieldResponse, 0, len(fields))
+	for i := range fields {
+		resp = append(resp, customFieldResponse(&fields[i]))
+	}
+	return c.JSON(fiber.Map{"data": resp})
+}
+
+// UpdateField handles PUT /staff/custom-fields/:id.
+func (h *CustomFieldHandler) UpdateField(c *fiber.Ctx) error {
+	staff, err := staffPrincipal(c)
+	if err != nil {
+		return err
+	}
+	var req dto.CustomFieldRequest
+	if err := c.BodyParser(&req); err != nil {
+		return apperrors.NewValidationError("invalid payload", nil)
+	}
+	field, err := h.service.UpdateField(c.Context(), staff, c.Params("id"), customFieldInput(req))
+	if err != nil {
+		return err
+	}
+	return c.JSON(fiber.Map{"data": customFieldResponse(field)})
+}
+
+func customFieldInput(req dto.CustomFieldRequest) service.CustomFieldInput {
+	return service.CustomFieldInput{
+		Key:          req.Key,
+		Label:        req.Label,
+		Type:         req.Type,
+		Options:      req.Options,
+		Required:     req.Required,
+		DepartmentID: req.DepartmentID,
+		IsActive:     req.IsActive,
+	}
+}
+
+func customFieldResponse(field *domain.CustomFieldDefinition) dto.CustomFieldResponse {
+	return dto.CustomFieldResponse{
+		ID:           field.ID,
+		Key:          field.Key,
+		Label:        field.Label,
+		Type:         field.Type,
+		Options:      field.Options,
+		Required:     field.Required,
+		DepartmentID: field.DepartmentID,
+		IsActive:     field.IsActive,
+		CreatedAt:    field.CreatedAt,
+		UpdatedAt:    field.UpdatedAt,
+	}
+}
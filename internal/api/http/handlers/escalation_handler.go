@@ -0,0 +1,100 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/spec-kit/ticket-service/internal/api/dto"
+	"github.com/spec-kit/ticket-service/internal/domain"
+	"github.com/spec-kit/ticket-service/internal/service"
+	apperrors "github.com/spec-kit/ticket-service/pkg/util/errorutil"
+)
+
+// EscalationHandler exposes admin endpoints for escalation rules.
+type EscalationHandler struct {
+	service *service.EscalationService
+}
+
+// NewEscalationHandler constructs handler.
+func NewEscalationHandler(escalationService *service.EscalationService) *EscalationHandler {
+	return &EscalationHandler{service: escalationService}
+}
+
+// CreateRule handles POST /staff/escalation-rules.
+func (h *EscalationHandler) CreateRule(c *fiber.Ctx) error {
+	staff, err := staffPrincipal(c)
+	if err != nil {
+		return err
+	}
+	var req dto.EscalationRuleRequest
+	if err := c.BodyParser(&req); err != nil {
+		return apperrors.NewValidationError("invalid payload", nil)
+	}
+	rule, err := h.service.CreateRule(c.Context(), staff, escalationRuleInput(req))
+	if err != nil {
+		return err
+	}
+	return c.Status(http.StatusCreated).JSON(fiber.Map{"data": escalationRuleResponse(rule)})
+}
+
+// ListRules handles GET /staff/escalation-rules.
+func (h *EscalationHandler) ListRules(c *fiber.Ctx) error {
+	staff, err := staffPrincipal(c)
+	if err != nil {
+		return err
+	}
+	rules, err := h.service.ListRules(c.Context(), staff, parseBoolQuery(c, "include_inactive", false))
+	if err != nil {
+		return err
+	}
+	resp := make([]dto.EscalationRuleResponse, 0, len(rules))
+	for i := range rules {
+		resp = append(resp, escalationRuleResponse(&rules[i]))
+	}
+	return c.JSON(fiber.Map{"data": resp})
+}
+
+// UpdateRule handles PUT /staff/escalation-rules/:id.
+func (h *EscalationHandler) UpdateRule(c *fiber.Ctx) error {
+	staff, err := staffPrincipal(c)
+	if err != nil {
+		return err
+	}
+	var req dto.EscalationRuleRequest
+	if err := c.BodyParser(&req); err != nil {
+		return apperrors.NewValidationError("invalid payload", nil)
+	}
+	rule, err := h.service.UpdateRule(c.Context(), staff, c.Params("id"), escalationRuleInput(req))
+	if err != nil {
+		return err
+	}
+	return c.JSON(fiber.Map{"data": escalationRuleResponse(rule)})
+}
+
+func escalationRuleInput(req dto.EscalationRuleRequest) service.EscalationRuleInput {
+	return service.EscalationRuleInput{
+		Name:             req.Name,
+		DepartmentID:     req.DepartmentID,
+		Priorities:       req.Priorities,
+		Condition:        req.Condition,
+		ThresholdMinutes: req.ThresholdMinutes,
+		Action:           req.Action,
+		IsActive:         req.IsActive,
+	}
+}
+
+func escalationRuleResponse(rule *domain.EscalationRule) dto.EscalationRuleResponse {
+	return dto.EscalationRuleResponse{
+		ID:               rule.ID,
+		Name:             rule.Name,
+		DepartmentID:     rule.DepartmentID,
+		Priorities:       rule.Priorities,
+		Condition:        rule.Condition,
+		ThresholdMinutes: rule.ThresholdMinutes,
+		Action:           rule.Action,
+		IsActive:         rule.IsActive,
+		CreatedAt:        rule.CreatedAt,
+		UpdatedAt:        rule.UpdatedAt,
+	}
+}
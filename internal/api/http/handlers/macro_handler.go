@@ -0,0 +1,141 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/spec-kit/ticket-service/internal/api/dto"
+	"github.com/spec-kit/ticket-service/internal/domain"
+	"github.com/spec-kit/ticket-service/internal/service"
+	apperrors "github.com/spec-kit/ticket-service/pkg/util/errorutil"
+)
+
+// MacroHandler exposes staff macros.
+type MacroHandler struct {
+	macros *service.MacroService
+}
+
+// NewMacroHandler constructs handler.
+func NewMacroHandler(macroService *service.MacroService) *MacroHandler {
+	return &MacroHandler{macros: macroService}
+}
+
+// ListMacros handles GET /staff/macros.
+func (h *MacroHandler) ListMacros(c *fiber.Ctx) error {
+	staff, err := staffPrincipal(c)
+	if err != nil {
+		return err
+	}
+	macros, err := h.macros.ListMacros(c.Context(), staff)
+	if err != nil {
+		return err
+	}
+	resp := make([]dto.MacroResponse, 0, len(macros))
+	for i := range macros {
+		resp = append(resp, macroResponse(&macros[i]))
+	}
+	return c.JSON(fiber.Map{"data": resp})
+}
+
+// CreateMacro handles POST /staff/macros.
+func (h *MacroHandler) CreateMacro(c *fiber.Ctx) error {
+	staff, err := staffPrincipal(c)
+	if err != nil {
+		return err
+	}
+	var req dto.MacroRequest
+	if err := c.BodyParser(&req); err != nil {
+		return apperrors.NewValidationError("invalid payload", nil)
+	}
+	macro, err := h.macros.CreateMacro(c.Context(), staff, macroInput(req))
+	if err != nil {
+		return err
+	}
+	return c.Status(http.StatusCreated).JSON(fiber.Map{"data": macroResponse(macro)})
+}
+
+// GetMacro handles GET /staff/macros/:id.
+func (h *MacroHandler) GetMacro(c *fiber.Ctx) error {
+	staff, err := staffPrincipal(c)
+	if err != nil {
+		return err
+	}
+	macro, err := h.macros.GetMacro(c.Context(), staff, c.Params("id"))
+	if err != nil {
+		return err
+	}
+	return c.JSON(fiber.Map{"data": macroResponse(macro)})
+}
+
+// UpdateMacro handles PUT /staff/macros/:id.
+func (h *MacroHandler) UpdateMacro(c *fiber.Ctx) error {
+	staff, err := staffPrincipal(c)
+	if err != nil {
+		return err
+	}
+	var req dto.MacroRequest
+	if err := c.BodyParser(&req); err != nil {
+		return apperrors.NewValidationError("invalid payload", nil)
+	}
+	macro, err := h.macros.UpdateMacro(c.Context(), staff, c.Params("id"), macroInput(req))
+	if err != nil {
+		return err
+	}
+	return c.JSON(fiber.Map{"data": macroResponse(macro)})
+}
+
+// DeleteMacro handles DELETE /staff/macros/:id.
+func (h *MacroHandler) DeleteMacro(c *fiber.Ctx) error {
+	staff, err := staffPrincipal(c)
+	if err != nil {
+		return err
+	}
+	if err := h.macros.DeleteMacro(c.Context(), staff, c.Params("id")); err != nil {
+		return err
+	}
+	return c.SendStatus(http.StatusNoContent)
+}
+
+// ApplyMacro handles POST /staff/tickets/:id/macros/:macroId.
+func (h *MacroHandler) ApplyMacro(c *fiber.Ctx) error {
+	staff, err := staffPrincipal(c)
+	if err != nil {
+		return err
+	}
+	result, err := h.macros.ApplyMacro(c.Context(), staff, c.Params("id"), c.Params("macroId"))
+	if err != nil {
+		return err
+	}
+	resp := dto.ApplyMacroResponse{Ticket: ticketSummary(result.Ticket)}
+	if result.Message != nil {
+		msg := ticketMessageResponse(result.Message)
+		resp.Message = &msg
+	}
+	return c.JSON(fiber.Map{"data": resp})
+}
+
+func macroInput(req dto.MacroRequest) service.MacroInput {
+	return service.MacroInput{
+		Name:      req.Name,
+		TeamID:    req.TeamID,
+		ReplyBody: req.ReplyBody,
+		ReplyType: req.ReplyType,
+		Actions:   req.Actions,
+	}
+}
+
+func macroResponse(macro *domain.Macro) dto.MacroResponse {
+	return dto.MacroResponse{
+		ID:           macro.ID,
+		Name:         macro.Name,
+		OwnerStaffID: macro.OwnerStaffID,
+		TeamID:       macro.TeamID,
+		Shared:       macro.TeamID != nil,
+		ReplyBody:    macro.ReplyBody,
+		ReplyType:    macro.ReplyType,
+		Actions:      macro.Actions,
+		CreatedAt:    macro.CreatedAt,
+		UpdatedAt:    macro.UpdatedAt,
+	}
+}
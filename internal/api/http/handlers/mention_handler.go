@@ -0,0 +1,74 @@
+package handlers
+
+import (
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/spec-kit/ticket-service/internal/api/dto"
+	"github.com/spec-kit/ticket-service/internal/domain"
+	"github.com/spec-kit/ticket-service/internal/repository"
+	"github.com/spec-kit/ticket-service/internal/service"
+	apperrors "github.com/spec-kit/ticket-service/pkg/util/errorutil"
+)
+
+// MentionHandler exposes the staff @mention inbox.
+type MentionHandler struct {
+	mentions *service.MentionService
+}
+
+// NewMentionHandler constructs handler.
+func NewMentionHandler(mentionService *service.MentionService) *MentionHandler {
+	return &MentionHandler{mentions: mentionService}
+}
+
+// ListMentions handles GET /staff/me/mentions.
+func (h *MentionHandler) ListMentions(c *fiber.Ctx) error {
+	staff, err := staffPrincipal(c)
+	if err != nil {
+		return err
+	}
+	page, err := h.mentions.ListMentions(c.Context(), staff, service.MentionFilter{
+		UnreadOnly: parseBoolQuery(c, "unread", false),
+		Limit:      parseInt(c.Query("page_size"), repository.DefaultPageSize),
+		Cursor:     c.Query("cursor"),
+	})
+	if err != nil {
+		return err
+	}
+	return c.JSON(listResponse(page, mentionResponse))
+}
+
+// MarkMentions handles POST /staff/me/mentions/read.
+func (h *MentionHandler) MarkMentions(c *fiber.Ctx) error {
+	staff, err := staffPrincipal(c)
+	if err != nil {
+		return err
+	}
+	var req dto.MarkMentionsRequest
+	if err := c.BodyParser(&req); err != nil {
+		return apperrors.NewValidationError("invalid payload", nil)
+	}
+	read := true
+	if req.Read != nil {
+		read = *req.Read
+	}
+	updated, err := h.mentions.MarkMentions(c.Context(), staff, req.MentionIDs, req.All, read)
+	if err != nil {
+		return err
+	}
+	return c.JSON(fiber.Map{"data": fiber.Map{"updated": updated}})
+}
+
+func mentionResponse(mention *domain.StaffMention) dto.StaffMentionResponse {
+	return dto.StaffMentionResponse{
+		ID:                 mention.ID,
+		TicketID:           mention.TicketID,
+		TicketExternalKey:  mention.TicketExternalKey,
+		TicketTitle:        mention.TicketTitle,
+		MessageID:          mention.MessageID,
+		BodyPreview:        mention.BodyPreview,
+		MentionedByStaffID: mention.MentionedByStaffID,
+		Read:               mention.ReadAt != nil,
+		ReadAt:             mention.ReadAt,
+		CreatedAt:          mention.CreatedAt,
+	}
+}
@@ -0,0 +1,22 @@
+package handlers
+
+import (
+	"github.com/spec-kit/ticket-service/internal/api/dto"
+	"github.com/spec-kit/ticket-service/internal/repository"
+)
+
+// listResponse converts a repository page into the list envelope.
+func listResponse[T, R any](page repository.Page[T], convert func(*T) R) dto.ListResponse[R] {
+	resp := dto.ListResponse[R]{
+		Data:  make([]R, 0, len(page.Items)),
+		Total: page.Total,
+	}
+	for i := range page.Items {
+		resp.Data = append(resp.Data, convert(&page.Items[i]))
+	}
+	if page.NextCursor != "" {
+		next := page.NextCursor
+		resp.NextCursor = &next
+	}
+	return resp
+}
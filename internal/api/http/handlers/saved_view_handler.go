@@ -0,0 +1,163 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/spec-kit/ticket-service/internal/api/dto"
+	"github.com/spec-kit/ticket-service/internal/domain"
+	"github.com/spec-kit/ticket-service/internal/repository"
+	"github.com/spec-kit/ticket-service/internal/service"
+	apperrors "github.com/spec-kit/ticket-service/pkg/util/errorutil"
+)
+
+// SavedViewHandler exposes staff saved views and shared queues.
+type SavedViewHandler struct {
+	views   *service.SavedViewService
+	tickets *service.TicketService
+}
+
+// NewSavedViewHandler constructs handler.
+func NewSavedViewHandler(viewService *service.SavedViewService, ticketService *service.TicketService) *SavedViewHandler {
+	return &SavedViewHandler{views: viewService, tickets: ticketService}
+}
+
+// ListViews handles GET /staff/views.
+func (h *SavedViewHandler) ListViews(c *fiber.Ctx) error {
+	staff, err := staffPrincipal(c)
+	if err != nil {
+		return err
+	}
+	views, err := h.views.ListViews(c.Context(), staff)
+	if err != nil {
+		return err
+	}
+	resp := make([]dto.SavedViewResponse, 0, len(views))
+	for i := range views {
+		resp = append(resp, savedViewResponse(&views[i]))
+	}
+	return c.JSON(fiber.Map{"data": resp})
+}
+
+// ViewCounts handles GET /staff/views/counts.
+func (h *SavedViewHandler) ViewCounts(c *fiber.Ctx) error {
+	staff, err := staffPrincipal(c)
+	if err != nil {
+		return err
+	}
+	counts, err := h.views.ViewCounts(c.Context(), staff)
+	if err != nil {
+		return err
+	}
+	resp := make([]dto.SavedViewCountResponse, 0, len(counts))
+	for _, entry := range counts {
+		resp = append(resp, dto.SavedViewCountResponse{
+			ID:     entry.View.ID,
+			Name:   entry.View.Name,
+			Shared: entry.View.TeamID != nil,
+			Count:  entry.Count,
+		})
+	}
+	return c.JSON(fiber.Map{"data": resp})
+}
+
+// CreateView handles POST /staff/views.
+func (h *SavedViewHandler) CreateView(c *fiber.Ctx) error {
+	staff, err := staffPrincipal(c)
+	if err != nil {
+		return err
+	}
+	var req dto.SavedViewRequest
+	if err := c.BodyParser(&req); err != nil {
+		return apperrors.NewValidationError("invalid payload", nil)
+	}
+	view, err := h.views.CreateView(c.Context(), staff, savedViewInput(req))
+	if err != nil {
+		return err
+	}
+	return c.Status(http.StatusCreated).JSON(fiber.Map{"data": savedViewResponse(view)})
+}
+
+// GetView handles GET /staff/views/:id.
+func (h *SavedViewHandler) GetView(c *fiber.Ctx) error {
+	staff, err := staffPrincipal(c)
+	if err != nil {
+		return err
+	}
+	view, err := h.views.GetView(c.Context(), staff, c.Params("id"))
+	if err != nil {
+		return err
+	}
+	return c.JSON(fiber.Map{"data": savedViewResponse(view)})
+}
+
+// UpdateView handles PUT /staff/views/:id.
+func (h *SavedViewHandler) UpdateView(c *fiber.Ctx) error {
+	staff, err := staffPrincipal(c)
+	if err != nil {
+		return err
+	}
+	var req dto.SavedViewRequest
+	if err := c.BodyParser(&req); err != nil {
+		return apperrors.NewValidationError("invalid payload", nil)
+	}
+	view, err := h.views.UpdateView(c.Context(), staff, c.Params("id"), savedViewInput(req))
+	if err != nil {
+		return err
+	}
+	return c.JSON(fiber.Map{"data": savedViewResponse(view)})
+}
+
+// DeleteView handles DELETE /staff/views/:id.
+func (h *SavedViewHandler) DeleteView(c *fiber.Ctx) error {
+	staff, err := staffPrincipal(c)
+	if err != nil {
+		return err
+	}
+	if err := h.views.DeleteView(c.Context(), staff, c.Params("id")); err != nil {
+		return err
+	}
+	return c.SendStatus(http.StatusNoContent)
+}
+
+// RunView handles GET /staff/views/:id/tickets; sort, cursor and page_size apply on top of the view.
+func (h *SavedViewHandler) RunView(c *fiber.Ctx) error {
+	staff, err := staffPrincipal(c)
+	if err != nil {
+		return err
+	}
+	filter, err := h.views.ViewFilter(c.Context(), staff, c.Params("id"))
+	if err != nil {
+		return err
+	}
+	if sort := c.Query("sort"); sort != "" {
+		filter.Sort = sort
+	}
+	filter.Limit = parseInt(c.Query("page_size"), repository.DefaultPageSize)
+	filter.Cursor = c.Query("cursor")
+	return staffTicketList(c, h.tickets, staff, filter)
+}
+
+func savedViewInput(req dto.SavedViewRequest) service.SavedViewInput {
+	return service.SavedViewInput{
+		Name:   req.Name,
+		TeamID: req.TeamID,
+		Filter: req.Filter,
+		Sort:   req.Sort,
+	}
+}
+
+func savedViewResponse(view *domain.SavedView) dto.SavedViewResponse {
+	return dto.SavedViewResponse{
+		ID:           view.ID,
+		Name:         view.Name,
+		OwnerStaffID: view.OwnerStaffID,
+		TeamID:       view.TeamID,
+		Shared:       view.TeamID != nil,
+		Filter:       view.Filter,
+		Sort:         view.Sort,
+		CreatedAt:    view.CreatedAt,
+		UpdatedAt:    view.UpdatedAt,
+	}
+}
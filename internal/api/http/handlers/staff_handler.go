@@ -128,7 +128,11 @@ func (h *StaffHandler) CreateDepartment(c *fiber.Ctx) error {
 	if req.Name == "" {
 		return apperrors.NewValidationError("name required", nil)
 	}
-	dept, err := h.orgService.CreateDepartment(c.Context(), admin, req.Name, req.Description)
+	var allowedTypes []string
+	if req.AllowedAttachmentTypes != nil {
+		allowedTypes = *req.AllowedAttachmentTypes
+	}
+	dept, err := h.orgService.CreateDepartment(c.Context(), admin, req.Name, req.Description, allowedTypes)
 	if err != nil {
 		return err
 	}
@@ -187,6 +191,9 @@ func (h *StaffHandler) UpdateDepartment(c *fiber.Ctx) error {
 	if req.IsActive != nil {
 		dept.IsActive = *req.IsActive
 	}
+	if req.AllowedAttachmentTypes != nil {
+		dept.AllowedAttachmentTypes = *req.AllowedAttachmentTypes
+	}
 	updated, err := h.orgService.UpdateDepartment(c.Context(), admin, dept)
 	if err != nil {
 		return err
@@ -316,11 +323,7 @@ func (h *StaffHandler) ListStaff(c *fiber.Ctx) error {
 	if err != nil {
 		return err
 	}
-	resp := make([]dto.StaffResponse, 0, len(list))
-	for i := range list {
-		resp = append(resp, staffResponse(&list[i]))
-	}
-	return c.JSON(fiber.Map{"data": resp})
+	return c.JSON(listResponse(list, staffResponse))
 }
 
 // GetStaff handles GET /staff/members/:id.
@@ -390,10 +393,8 @@ func parseStaffListFilters(c *fiber.Ctx) service.StaffListFilters {
 			filters.Active = &val
 		}
 	}
-	page := parseIntQuery(c, "page", 1)
-	pageSize := parseIntQuery(c, "page_size", 50)
-	filters.Offset = (page - 1) * pageSize
-	filters.Limit = pageSize
+	filters.Limit = parseIntQuery(c, "page_size", 50)
+	filters.Cursor = c.Query("cursor")
 	return filters
 }
 
@@ -408,10 +409,11 @@ func parseIntQuery(c *fiber.Ctx, key string, defaultVal int) int {
 
 func departmentResponse(dept *domain.Department) dto.DepartmentResponse {
 	return dto.DepartmentResponse{
-		ID:          dept.ID,
-		Name:        dept.Name,
-		Description: dept.Description,
-		IsActive:    dept.IsActive,
+		ID:                     dept.ID,
+		Name:                   dept.Name,
+		Description:            dept.Description,
+		IsActive:               dept.IsActive,
+		AllowedAttachmentTypes: append([]string{}, dept.AllowedAttachmentTypes...),
 	}
 }
 
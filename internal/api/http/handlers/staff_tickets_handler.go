@@ -9,6 +9,7 @@ import (
 	"github.com/spec-kit/ticket-service/internal/api/dto"
 	"github.com/spec-kit/ticket-service/internal/auth"
 	"github.com/spec-kit/ticket-service/internal/domain"
+	"github.com/spec-kit/ticket-service/internal/repository"
 	"github.com/spec-kit/ticket-service/internal/service"
 	apperrors "github.com/spec-kit/ticket-service/pkg/util/errorutil"
 )
@@ -30,16 +31,29 @@ func (h *StaffTicketsHandler) ListStaffTickets(c *fiber.Ctx) error {
 	if err != nil {
 		return err
 	}
-	filter := parseStaffTicketFilter(c)
-	tickets, err := h.tickets.ListStaffTickets(c.Context(), staff, filter)
+	return staffTicketList(c, h.tickets, staff, parseStaffTicketFilter(c))
+}
+
+// staffTicketList runs a staff listing, switching to ranked results when the filter has a search term.
+func staffTicketList(c *fiber.Ctx, tickets *service.TicketService, staff *domain.StaffMember, filter service.TicketStaffFilter) error {
+	if filter.SearchTerm != nil {
+		hits, err := tickets.SearchStaffTickets(c.Context(), staff, filter)
+		if err != nil {
+			return err
+		}
+		return c.JSON(listResponse(hits, func(hit *domain.TicketSearchHit) dto.TicketSearchResult {
+			return dto.TicketSearchResult{
+				TicketSummary: ticketSummary(&hit.Ticket),
+				Rank:          hit.Rank,
+				Snippet:       hit.Snippet,
+			}
+		}))
+	}
+	page, err := tickets.ListStaffTickets(c.Context(), staff, filter)
 	if err != nil {
 		return err
 	}
-	items := make([]dto.TicketSummary, 0, len(tickets))
-	for i := range tickets {
-		items = append(items, ticketSummary(&tickets[i]))
-	}
-	return c.JSON(fiber.Map{"data": items})
+	return c.JSON(listResponse(page, ticketSummary))
 }
 
 // GetStaffTicket GET /staff/tickets/:id.
@@ -52,11 +66,22 @@ func (h *StaffTicketsHandler) GetStaffTicket(c *fiber.Ctx) error {
 	if err != nil {
 		return err
 	}
-	history, err := h.tickets.ListHistoryForStaff(c.Context(), staff, ticket.ID, 100, 0)
+	history, err := h.tickets.ListHistoryForStaff(c.Context(), staff, ticket.ID, repository.MaxPageSize, "")
+	if err != nil {
+		return err
+	}
+	links, err := h.tickets.ListLinks(c.Context(), staff, ticket.ID)
+	if err != nil {
+		return err
+	}
+	participants, err := h.tickets.ListParticipants(c.Context(), staff, ticket.ID)
 	if err != nil {
 		return err
 	}
-	return c.JSON(fiber.Map{"data": ticketDetail(ticket, msgs, history)})
+	detail := ticketDetail(ticket, msgs, history.Items)
+	detail.Links = ticketLinkResponses(links)
+	detail.Participants = participantResponses(participants)
+	return c.JSON(fiber.Map{"data": detail})
 }
 
 // AddStaffMessage POST /staff/tickets/:id/messages.
@@ -85,7 +110,7 @@ func (h *StaffTicketsHandler) AddStaffMessage(c *fiber.Ctx) error {
 			SizeBytes:  att.SizeBytes,
 		})
 	}
-	msg, err := h.tickets.AddMessage(c.Context(), domain.SubjectTypeStaff, staff.ID, staff, c.Params("id"), msgType, req.Body, attachments)
+	msg, err := h.tickets.AddMessage(c.Context(), domain.SubjectTypeStaff, staff.ID, staff, c.Params("id"), msgType, req.Body, req.BodyFormat, attachments)
 	if err != nil {
 		return err
 	}
@@ -185,19 +210,106 @@ func (h *StaffTicketsHandler) UpdatePriority(c *fiber.Ctx) error {
 	return c.JSON(fiber.Map{"data": ticketSummary(ticket)})
 }
 
+// MergeTickets handles POST /staff/tickets/:id/merge.
+func (h *StaffTicketsHandler) MergeTickets(c *fiber.Ctx) error {
+	staff, err := staffPrincipal(c)
+	if err != nil {
+		return err
+	}
+	var req dto.MergeTicketsRequest
+	if err := c.BodyParser(&req); err != nil {
+		return apperrors.NewValidationError("invalid payload", nil)
+	}
+	ticket, err := h.tickets.MergeTickets(c.Context(), staff, c.Params("id"), req.SourceTicketIDs)
+	if err != nil {
+		return err
+	}
+	return c.JSON(fiber.Map{"data": ticketSummary(ticket)})
+}
+
+// SplitTicket handles POST /staff/tickets/:id/split.
+func (h *StaffTicketsHandler) SplitTicket(c *fiber.Ctx) error {
+	staff, err := staffPrincipal(c)
+	if err != nil {
+		return err
+	}
+	var req dto.SplitTicketRequest
+	if err := c.BodyParser(&req); err != nil {
+		return apperrors.NewValidationError("invalid payload", nil)
+	}
+	ticket, err := h.tickets.SplitTicket(c.Context(), staff, c.Params("id"), service.TicketSplitInput{
+		MessageIDs: req.MessageIDs,
+		Title:      req.Title,
+		Priority:   req.Priority,
+	})
+	if err != nil {
+		return err
+	}
+	return c.Status(http.StatusCreated).JSON(fiber.Map{"data": ticketSummary(ticket)})
+}
+
+// UpdateTags handles POST /staff/tickets/:id/tags.
+func (h *StaffTicketsHandler) UpdateTags(c *fiber.Ctx) error {
+	staff, err := staffPrincipal(c)
+	if err != nil {
+		return err
+	}
+	var req dto.UpdateTicketTagsRequest
+	if err := c.BodyParser(&req); err != nil {
+		return apperrors.NewValidationError("invalid payload", nil)
+	}
+	if len(req.Add) == 0 && len(req.Remove) == 0 {
+		return apperrors.NewValidationError("add or remove required", nil)
+	}
+	ticket, err := h.tickets.UpdateTags(c.Context(), staff, c.Params("id"), req.Add, req.Remove)
+	if err != nil {
+		return err
+	}
+	return c.JSON(fiber.Map{"data": ticketSummary(ticket)})
+}
+
+// UpdateCustomFields handles POST /staff/tickets/:id/custom-fields.
+func (h *StaffTicketsHandler) UpdateCustomFields(c *fiber.Ctx) error {
+	staff, err := staffPrincipal(c)
+	if err != nil {
+		return err
+	}
+	var req dto.UpdateCustomFieldsRequest
+	if err := c.BodyParser(&req); err != nil {
+		return apperrors.NewValidationError("invalid payload", nil)
+	}
+	if len(req.CustomFields) == 0 {
+		return apperrors.NewValidationError("custom_fields required", nil)
+	}
+	ticket, err := h.tickets.UpdateCustomFields(c.Context(), staff, c.Params("id"), req.CustomFields)
+	if err != nil {
+		return err
+	}
+	return c.JSON(fiber.Map{"data": ticketSummary(ticket)})
+}
+
 // GetHistory handles GET /staff/tickets/:id/history.
 func (h *StaffTicketsHandler) GetHistory(c *fiber.Ctx) error {
 	staff, err := staffPrincipal(c)
 	if err != nil {
 		return err
 	}
-	page := parseInt(c.Query("page"), 1)
 	pageSize := parseInt(c.Query("page_size"), 50)
-	history, err := h.tickets.ListHistoryForStaff(c.Context(), staff, c.Params("id"), pageSize, (page-1)*pageSize)
+	history, err := h.tickets.ListHistoryForStaff(c.Context(), staff, c.Params("id"), pageSize, c.Query("cursor"))
 	if err != nil {
 		return err
 	}
-	return c.JSON(fiber.Map{"data": historyResponses(history)})
+	return c.JSON(listResponse(history, historyResponse))
+}
+
+func splitQueryList(raw string) []string {
+	var values []string
+	for _, part := range strings.Split(raw, ",") {
+		if part = strings.TrimSpace(part); part != "" {
+			values = append(values, part)
+		}
+	}
+	return values
 }
 
 func staffPrincipal(c *fiber.Ctx) (*domain.StaffMember, error) {
@@ -244,9 +356,21 @@ func parseStaffTicketFilter(c *fiber.Ctx) service.TicketStaffFilter {
 	if updatedTo := parseTime(c.Query("updated_to")); updatedTo != nil {
 		filter.UpdatedTo = updatedTo
 	}
-	page := parseInt(c.Query("page"), 1)
-	pageSize := parseInt(c.Query("page_size"), 20)
-	filter.Offset = (page - 1) * pageSize
-	filter.Limit = pageSize
+	filter.TagsAny = splitQueryList(c.Query("tags_any"))
+	filter.TagsAll = splitQueryList(c.Query("tags_all"))
+	filter.TagsNone = splitQueryList(c.Query("tags_none"))
+	c.Context().QueryArgs().VisitAll(func(key, value []byte) {
+		name, ok := strings.CutPrefix(string(key), "cf.")
+		if !ok || name == "" {
+			return
+		}
+		if filter.CustomFields == nil {
+			filter.CustomFields = map[string]string{}
+		}
+		filter.CustomFields[name] = string(value)
+	})
+	filter.Limit = parseInt(c.Query("page_size"), repository.DefaultPageSize)
+	filter.Sort = c.Query("sort")
+	filter.Cursor = c.Query("cursor")
 	return filter
 }
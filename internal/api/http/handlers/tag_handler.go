@@ -0,0 +1,146 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/spec-kit/ticket-service/internal/api/dto"
+	"github.com/spec-kit/ticket-service/internal/domain"
+	"github.com/spec-kit/ticket-service/internal/repository"
+	"github.com/spec-kit/ticket-service/internal/service"
+	apperrors "github.com/spec-kit/ticket-service/pkg/util/errorutil"
+)
+
+// TagHandler exposes the tag catalog.
+type TagHandler struct {
+	service *service.TagService
+}
+
+// NewTagHandler constructs handler.
+func NewTagHandler(tagService *service.TagService) *TagHandler {
+	return &TagHandler{service: tagService}
+}
+
+// ListTags handles GET /staff/tags.
+func (h *TagHandler) ListTags(c *fiber.Ctx) error {
+	if _, err := staffPrincipal(c); err != nil {
+		return err
+	}
+	tags, err := h.service.ListTags(c.Context(), repository.TagFilter{
+		Prefix:            c.Query("q"),
+		IncludeDeprecated: parseBoolQuery(c, "include_deprecated", false),
+	})
+	if err != nil {
+		return err
+	}
+	resp := make([]dto.TagResponse, 0, len(tags))
+	for i := range tags {
+		resp = append(resp, tagResponse(&tags[i]))
+	}
+	return c.JSON(fiber.Map{"data": resp})
+}
+
+// TagUsage handles GET /staff/tags/usage.
+func (h *TagHandler) TagUsage(c *fiber.Ctx) error {
+	staff, err := staffPrincipal(c)
+	if err != nil {
+		return err
+	}
+	filter := repository.TagUsageFilter{}
+	if deptID := c.Query("department_id"); deptID != "" {
+		filter.DepartmentID = &deptID
+	}
+	if teamID := c.Query("team_id"); teamID != "" {
+		filter.TeamID = &teamID
+	}
+	usage, err := h.service.TagUsage(c.Context(), staff, filter)
+	if err != nil {
+		return err
+	}
+	resp := make([]dto.TagUsageResponse, 0, len(usage))
+	for i := range usage {
+		resp = append(resp, dto.TagUsageResponse{
+			Tag:         tagResponse(&usage[i].Tag),
+			TicketCount: usage[i].TicketCount,
+			OpenCount:   usage[i].OpenCount,
+			LastUsedAt:  usage[i].LastUsedAt,
+		})
+	}
+	return c.JSON(fiber.Map{"data": resp})
+}
+
+// CreateTag handles POST /staff/tags.
+func (h *TagHandler) CreateTag(c *fiber.Ctx) error {
+	admin, err := staffPrincipal(c)
+	if err != nil {
+		return err
+	}
+	var req dto.TagRequest
+	if err := c.BodyParser(&req); err != nil {
+		return apperrors.NewValidationError("invalid payload", nil)
+	}
+	tag, err := h.service.CreateTag(c.Context(), admin, tagInput(req))
+	if err != nil {
+		return err
+	}
+	return c.Status(http.StatusCreated).JSON(fiber.Map{"data": tagResponse(tag)})
+}
+
+// UpdateTag handles PUT /staff/tags/:id.
+func (h *TagHandler) UpdateTag(c *fiber.Ctx) error {
+	admin, err := staffPrincipal(c)
+	if err != nil {
+		return err
+	}
+	var req dto.TagRequest
+	if err := c.BodyParser(&req); err != nil {
+		return apperrors.NewValidationError("invalid payload", nil)
+	}
+	tag, err := h.service.UpdateTag(c.Context(), admin, c.Params("id"), tagInput(req))
+	if err != nil {
+		return err
+	}
+	return c.JSON(fiber.Map{"data": tagResponse(tag)})
+}
+
+// MergeTag handles POST /staff/tags/:id/merge.
+func (h *TagHandler) MergeTag(c *fiber.Ctx) error {
+	admin, err := staffPrincipal(c)
+	if err != nil {
+		return err
+	}
+	var req dto.MergeTagRequest
+	if err := c.BodyParser(&req); err != nil || req.TargetID == "" {
+		return apperrors.NewValidationError("target_id required", nil)
+	}
+	tag, retagged, err := h.service.MergeTag(c.Context(), admin, c.Params("id"), req.TargetID)
+	if err != nil {
+		return err
+	}
+	return c.JSON(fiber.Map{"data": dto.MergeTagResponse{
+		Tag:             tagResponse(tag),
+		TicketsRetagged: retagged,
+	}})
+}
+
+func tagInput(req dto.TagRequest) service.TagInput {
+	return service.TagInput{
+		Name:        req.Name,
+		Color:       req.Color,
+		Description: req.Description,
+		Deprecated:  req.Deprecated,
+	}
+}
+
+func tagResponse(tag *domain.Tag) dto.TagResponse {
+	return dto.TagResponse{
+		ID:          tag.ID,
+		Name:        tag.Name,
+		Color:       tag.Color,
+		Description: tag.Description,
+		Deprecated:  tag.Deprecated,
+		CreatedAt:   tag.CreatedAt,
+		UpdatedAt:   tag.UpdatedAt,
+	}
+}
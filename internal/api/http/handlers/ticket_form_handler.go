@@ -0,0 +1,115 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/spec-kit/ticket-service/internal/api/dto"
+	"github.com/spec-kit/ticket-service/internal/domain"
+	"github.com/spec-kit/ticket-service/internal/service"
+	apperrors "github.com/spec-kit/ticket-service/pkg/util/errorutil"
+)
+
+// TicketFormHandler exposes department ticket forms.
+type TicketFormHandler struct {
+	service *service.TicketFormService
+}
+
+// NewTicketFormHandler constructs handler.
+func NewTicketFormHandler(formService *service.TicketFormService) *TicketFormHandler {
+	return &TicketFormHandler{service: formService}
+}
+
+// GetDepartmentForm handles GET /departments/:id/form.
+func (h *TicketFormHandler) GetDepartmentForm(c *fiber.Ctx) error {
+	view, err := h.service.GetDepartmentForm(c.Context(), c.Params("id"))
+	if err != nil {
+		return err
+	}
+	fields := make([]dto.FormFieldResponse, 0, len(view.Fields))
+	for _, field := range view.Fields {
+		options := make([]dto.FormOptionResponse, 0, len(field.Options))
+		for _, option := range field.Options {
+			options = append(options, dto.FormOptionResponse{Value: option.Value, Label: option.Label})
+		}
+		label := field.Label
+		if label == "" {
+			label = field.Key
+		}
+		fields = append(fields, dto.FormFieldResponse{
+			Key:         field.Key,
+			Label:       label,
+			HelpText:    field.HelpText,
+			Type:        field.Type,
+			Required:    field.Required,
+			Builtin:     field.Builtin,
+			Options:     options,
+			VisibleWhen: field.VisibleWhen,
+		})
+	}
+	return c.JSON(fiber.Map{"data": dto.DepartmentFormResponse{
+		ID:           view.ID,
+		DepartmentID: view.DepartmentID,
+		Name:         view.Name,
+		Fields:       fields,
+	}})
+}
+
+// GetForm handles GET /staff/departments/:id/form.
+func (h *TicketFormHandler) GetForm(c *fiber.Ctx) error {
+	staff, err := staffPrincipal(c)
+	if err != nil {
+		return err
+	}
+	form, err := h.service.GetForm(c.Context(), staff, c.Params("id"))
+	if err != nil {
+		return err
+	}
+	return c.JSON(fiber.Map{"data": ticketFormResponse(form)})
+}
+
+// SaveForm handles PUT /staff/departments/:id/form.
+func (h *TicketFormHandler) SaveForm(c *fiber.Ctx) error {
+	staff, err := staffPrincipal(c)
+	if err != nil {
+		return err
+	}
+	var req dto.TicketFormRequest
+	if err := c.BodyParser(&req); err != nil {
+		return apperrors.NewValidationError("invalid payload", nil)
+	}
+	form, err := h.service.SaveForm(c.Context(), staff, c.Params("id"), service.TicketFormInput{
+		Name:     req.Name,
+		Fields:   req.Fields,
+		IsActive: req.IsActive,
+	})
+	if err != nil {
+		return err
+	}
+	return c.JSON(fiber.Map{"data": ticketFormResponse(form)})
+}
+
+// DeleteForm handles DELETE /staff/departments/:id/form.
+func (h *TicketFormHandler) DeleteForm(c *fiber.Ctx) error {
+	staff, err := staffPrincipal(c)
+	if err != nil {
+		return err
+	}
+	if err := h.service.DeleteForm(c.Context(), staff, c.Params("id")); err != nil {
+		return err
+	}
+	return c.SendStatus(http.StatusNoContent)
+}
+
+func ticketFormResponse(form *domain.TicketForm) dto.TicketFormResponse {
+	return dto.TicketFormResponse{
+		ID:           form.ID,
+		DepartmentID: form.DepartmentID,
+		Name:         form.Name,
+		Fields:       form.Fields,
+		IsActive:     form.IsActive,
+		CreatedAt:    form.CreatedAt,
+		UpdatedAt:    form.UpdatedAt,
+	}
+}
@@ -0,0 +1,96 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/spec-kit/ticket-service/internal/api/dto"
+	"github.com/spec-kit/ticket-service/internal/domain"
+	"github.com/spec-kit/ticket-service/internal/service"
+	apperrors "github.com/spec-kit/ticket-service/pkg/util/errorutil"
+)
+
+// ListLinks handles GET /staff/tickets/:id/links.
+func (h *StaffTicketsHandler) ListLinks(c *fiber.Ctx) error {
+	staff, err := staffPrincipal(c)
+	if err != nil {
+		return err
+	}
+	links, err := h.tickets.ListLinks(c.Context(), staff, c.Params("id"))
+	if err != nil {
+		return err
+	}
+	return c.JSON(fiber.Map{"data": ticketLinkResponses(links)})
+}
+
+// CreateLink handles POST /staff/tickets/:id/links.
+func (h *StaffTicketsHandler) CreateLink(c *fiber.Ctx) error {
+	staff, err := staffPrincipal(c)
+	if err != nil {
+		return err
+	}
+	var req dto.CreateTicketLinkRequest
+	if err := c.BodyParser(&req); err != nil {
+		return apperrors.NewValidationError("invalid payload", nil)
+	}
+	linked, err := h.tickets.LinkTickets(c.Context(), staff, c.Params("id"), service.TicketLinkInput{
+		TicketID:  req.TicketID,
+		Type:      req.Type,
+		Propagate: req.Propagate,
+	})
+	if err != nil {
+		return err
+	}
+	return c.Status(http.StatusCreated).JSON(fiber.Map{"data": ticketLinkResponse(linked)})
+}
+
+// UpdateLink handles PUT /staff/tickets/:id/links/:linkId.
+func (h *StaffTicketsHandler) UpdateLink(c *fiber.Ctx) error {
+	staff, err := staffPrincipal(c)
+	if err != nil {
+		return err
+	}
+	var req dto.UpdateTicketLinkRequest
+	if err := c.BodyParser(&req); err != nil {
+		return apperrors.NewValidationError("invalid payload", nil)
+	}
+	if _, err := h.tickets.SetLinkPropagation(c.Context(), staff, c.Params("id"), c.Params("linkId"), req.Propagate); err != nil {
+		return err
+	}
+	return h.ListLinks(c)
+}
+
+// DeleteLink handles DELETE /staff/tickets/:id/links/:linkId.
+func (h *StaffTicketsHandler) DeleteLink(c *fiber.Ctx) error {
+	staff, err := staffPrincipal(c)
+	if err != nil {
+		return err
+	}
+	if err := h.tickets.UnlinkTickets(c.Context(), staff, c.Params("id"), c.Params("linkId")); err != nil {
+		return err
+	}
+	return c.SendStatus(http.StatusNoContent)
+}
+
+func ticketLinkResponses(links []domain.LinkedTicket) []dto.TicketLinkResponse {
+	resp := make([]dto.TicketLinkResponse, 0, len(links))
+	for i := range links {
+		resp = append(resp, ticketLinkResponse(&links[i]))
+	}
+	return resp
+}
+
+func ticketLinkResponse(linked *domain.LinkedTicket) dto.TicketLinkResponse {
+	return dto.TicketLinkResponse{
+		ID:          linked.Link.ID,
+		Type:        linked.Type,
+		TicketID:    linked.TicketID,
+		ExternalKey: linked.ExternalKey,
+		Title:       linked.Title,
+		Status:      linked.Status,
+		Priority:    linked.Priority,
+		Propagate:   linked.Link.Propagate,
+		CreatedAt:   linked.Link.CreatedAt,
+	}
+}
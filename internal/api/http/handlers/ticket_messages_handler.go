@@ -0,0 +1,83 @@
+package handlers
+
+import (
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/spec-kit/ticket-service/internal/api/dto"
+	"github.com/spec-kit/ticket-service/internal/service"
+	apperrors "github.com/spec-kit/ticket-service/pkg/util/errorutil"
+)
+
+// EditMessage handles PUT /staff/tickets/:id/messages/:messageId.
+func (h *StaffTicketsHandler) EditMessage(c *fiber.Ctx) error {
+	staff, err := staffPrincipal(c)
+	if err != nil {
+		return err
+	}
+	var req dto.EditMessageRequest
+	if err := c.BodyParser(&req); err != nil {
+		return apperrors.NewValidationError("invalid payload", nil)
+	}
+	msg, err := h.tickets.EditMessage(c.Context(), staff, c.Params("id"), c.Params("messageId"), req.Body)
+	if err != nil {
+		return err
+	}
+	return c.JSON(fiber.Map{"data": ticketMessageResponse(msg)})
+}
+
+// RedactMessage handles POST /staff/tickets/:id/messages/:messageId/redact.
+func (h *StaffTicketsHandler) RedactMessage(c *fiber.Ctx) error {
+	staff, err := staffPrincipal(c)
+	if err != nil {
+		return err
+	}
+	var req dto.RedactMessageRequest
+	if err := c.BodyParser(&req); err != nil {
+		return apperrors.NewValidationError("invalid payload", nil)
+	}
+	msg, err := h.tickets.RedactMessage(c.Context(), staff, c.Params("id"), c.Params("messageId"), service.MessageRedactionInput{
+		Segments:      req.Segments,
+		AttachmentIDs: req.AttachmentIDs,
+		Reason:        req.Reason,
+	})
+	if err != nil {
+		return err
+	}
+	return c.JSON(fiber.Map{"data": ticketMessageResponse(msg)})
+}
+
+// ListMessageRevisions handles GET /staff/tickets/:id/messages/:messageId/revisions.
+func (h *StaffTicketsHandler) ListMessageRevisions(c *fiber.Ctx) error {
+	staff, err := staffPrincipal(c)
+	if err != nil {
+		return err
+	}
+	revisions, err := h.tickets.ListMessageRevisions(c.Context(), staff, c.Params("id"), c.Params("messageId"))
+	if err != nil {
+		return err
+	}
+	resp := make([]dto.MessageRevisionResponse, 0, len(revisions))
+	for _, revision := range revisions {
+		removed := make([]dto.AttachmentResponse, 0, len(revision.RemovedAttachments))
+		for _, att := range revision.RemovedAttachments {
+			removed = append(removed, dto.AttachmentResponse{
+				ID:         att.ID,
+				FileName:   att.FileName,
+				MimeType:   att.MimeType,
+				SizeBytes:  att.SizeBytes,
+				ScanStatus: att.ScanStatus,
+			})
+		}
+		resp = append(resp, dto.MessageRevisionResponse{
+			ID:                 revision.ID,
+			Kind:               revision.Kind,
+			EditorStaffID:      revision.EditorStaffID,
+			PreviousBody:       revision.PreviousBody,
+			RedactedSegments:   revision.RedactedSegments,
+			RemovedAttachments: removed,
+			Reason:             revision.Reason,
+			CreatedAt:          revision.CreatedAt,
+		})
+	}
+	return c.JSON(fiber.Map{"data": resp})
+}
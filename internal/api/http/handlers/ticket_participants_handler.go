@@ -0,0 +1,146 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/spec-kit/ticket-service/internal/api/dto"
+	"github.com/spec-kit/ticket-service/internal/auth"
+	"github.com/spec-kit/ticket-service/internal/domain"
+	"github.com/spec-kit/ticket-service/internal/service"
+	apperrors "github.com/spec-kit/ticket-service/pkg/util/errorutil"
+)
+
+// ListParticipants handles GET /staff/tickets/:id/participants.
+func (h *StaffTicketsHandler) ListParticipants(c *fiber.Ctx) error {
+	staff, err := staffPrincipal(c)
+	if err != nil {
+		return err
+	}
+	participants, err := h.tickets.ListParticipants(c.Context(), staff, c.Params("id"))
+	if err != nil {
+		return err
+	}
+	return c.JSON(fiber.Map{"data": participantResponses(participants)})
+}
+
+// AddParticipant handles POST /staff/tickets/:id/participants.
+func (h *StaffTicketsHandler) AddParticipant(c *fiber.Ctx) error {
+	staff, err := staffPrincipal(c)
+	if err != nil {
+		return err
+	}
+	var req dto.AddParticipantRequest
+	if err := c.BodyParser(&req); err != nil {
+		return apperrors.NewValidationError("invalid payload", nil)
+	}
+	participant, err := h.tickets.AddParticipant(c.Context(), staff, c.Params("id"), service.ParticipantInput{
+		Role:    req.Role,
+		StaffID: req.StaffID,
+		UserID:  req.UserID,
+		Email:   req.Email,
+	})
+	if err != nil {
+		return err
+	}
+	return c.Status(http.StatusCreated).JSON(fiber.Map{"data": participantResponse(participant)})
+}
+
+// RemoveParticipant handles DELETE /staff/tickets/:id/participants/:participantId.
+func (h *StaffTicketsHandler) RemoveParticipant(c *fiber.Ctx) error {
+	staff, err := staffPrincipal(c)
+	if err != nil {
+		return err
+	}
+	if err := h.tickets.RemoveParticipant(c.Context(), staff, c.Params("id"), c.Params("participantId")); err != nil {
+		return err
+	}
+	return c.SendStatus(http.StatusNoContent)
+}
+
+// WatchTicket handles POST /staff/tickets/:id/watch.
+func (h *StaffTicketsHandler) WatchTicket(c *fiber.Ctx) error {
+	staff, err := staffPrincipal(c)
+	if err != nil {
+		return err
+	}
+	participant, err := h.tickets.WatchTicket(c.Context(), staff, c.Params("id"))
+	if err != nil {
+		return err
+	}
+	return c.JSON(fiber.Map{"data": participantResponse(participant)})
+}
+
+// UnwatchTicket handles DELETE /staff/tickets/:id/watch.
+func (h *StaffTicketsHandler) UnwatchTicket(c *fiber.Ctx) error {
+	staff, err := staffPrincipal(c)
+	if err != nil {
+		return err
+	}
+	if err := h.tickets.UnwatchTicket(c.Context(), staff, c.Params("id")); err != nil {
+		return err
+	}
+	return c.SendStatus(http.StatusNoContent)
+}
+
+// ListCC handles GET /tickets/:id/cc.
+func (h *TicketsHandler) ListCC(c *fiber.Ctx) error {
+	principal, ok := auth.PrincipalFromContext(c)
+	if !ok || principal.User == nil {
+		return apperrors.NewUnauthorized("user required")
+	}
+	cc, err := h.service.ListCCForUser(c.Context(), principal.User.ID, c.Params("id"))
+	if err != nil {
+		return err
+	}
+	return c.JSON(fiber.Map{"data": participantResponses(cc)})
+}
+
+// AddCC handles POST /tickets/:id/cc.
+func (h *TicketsHandler) AddCC(c *fiber.Ctx) error {
+	principal, ok := auth.PrincipalFromContext(c)
+	if !ok || principal.User == nil {
+		return apperrors.NewUnauthorized("user required")
+	}
+	var req dto.AddCCRequest
+	if err := c.BodyParser(&req); err != nil {
+		return apperrors.NewValidationError("invalid payload", nil)
+	}
+	participant, err := h.service.AddCCForUser(c.Context(), principal.User.ID, c.Params("id"), req.Email)
+	if err != nil {
+		return err
+	}
+	return c.Status(http.StatusCreated).JSON(fiber.Map{"data": participantResponse(participant)})
+}
+
+// RemoveCC handles DELETE /tickets/:id/cc/:participantId; CC users may remove themselves.
+func (h *TicketsHandler) RemoveCC(c *fiber.Ctx) error {
+	principal, ok := auth.PrincipalFromContext(c)
+	if !ok || principal.User == nil {
+		return apperrors.NewUnauthorized("user required")
+	}
+	if err := h.service.RemoveCCForUser(c.Context(), principal.User.ID, c.Params("id"), c.Params("participantId")); err != nil {
+		return err
+	}
+	return c.SendStatus(http.StatusNoContent)
+}
+
+func participantResponses(participants []domain.TicketParticipant) []dto.TicketParticipantResponse {
+	resp := make([]dto.TicketParticipantResponse, 0, len(participants))
+	for i := range participants {
+		resp = append(resp, participantResponse(&participants[i]))
+	}
+	return resp
+}
+
+func participantResponse(participant *domain.TicketParticipant) dto.TicketParticipantResponse {
+	return dto.TicketParticipantResponse{
+		ID:        participant.ID,
+		Role:      participant.Role,
+		StaffID:   participant.StaffID,
+		UserID:    participant.UserID,
+		Email:     participant.Email,
+		CreatedAt: participant.CreatedAt,
+	}
+}
@@ -10,6 +10,8 @@ import (
 	"github.com/spec-kit/ticket-service/internal/api/dto"
 	"github.com/spec-kit/ticket-service/internal/auth"
 	"github.com/spec-kit/ticket-service/internal/domain"
+	"github.com/spec-kit/ticket-service/internal/repository"
+	"github.com/spec-kit/ticket-service/internal/richtext"
 	"github.com/spec-kit/ticket-service/internal/service"
 	apperrors "github.com/spec-kit/ticket-service/pkg/util/errorutil"
 )
@@ -17,11 +19,12 @@ import (
 // TicketsHandler manages end-user ticket endpoints.
 type TicketsHandler struct {
 	service *service.TicketService
+	forms   *service.TicketFormService
 }
 
 // NewTicketsHandler constructs handler.
-func NewTicketsHandler(ticketService *service.TicketService) *TicketsHandler {
-	return &TicketsHandler{service: ticketService}
+func NewTicketsHandler(ticketService *service.TicketService, formService *service.TicketFormService) *TicketsHandler {
+	return &TicketsHandler{service: ticketService, forms: formService}
 }
 
 // CreateTicket POST /tickets.
@@ -45,6 +48,14 @@ func (h *TicketsHandler) CreateTicket(c *fiber.Ctx) error {
 		Description:  req.Description,
 		Priority:     req.Priority,
 		Tags:         req.Tags,
+		CustomFields: req.CustomFields,
+	}
+	if h.forms != nil {
+		validated, err := h.forms.ValidateSubmission(c.Context(), input)
+		if err != nil {
+			return err
+		}
+		input = validated
 	}
 	ticket, err := h.service.CreateTicket(c.Context(), principal.User.ID, input)
 	if err != nil {
@@ -64,11 +75,7 @@ func (h *TicketsHandler) ListTickets(c *fiber.Ctx) error {
 	if err != nil {
 		return err
 	}
-	items := make([]dto.TicketSummary, 0, len(tickets))
-	for i := range tickets {
-		items = append(items, ticketSummary(&tickets[i]))
-	}
-	return c.JSON(fiber.Map{"data": items})
+	return c.JSON(listResponse(tickets, ticketSummary))
 }
 
 // GetTicket GET /tickets/:id.
@@ -85,7 +92,13 @@ func (h *TicketsHandler) GetTicket(c *fiber.Ctx) error {
 	if err != nil {
 		return err
 	}
-	return c.JSON(fiber.Map{"data": ticketDetail(ticket, msgs, history)})
+	cc, err := h.service.ListCCForUser(c.Context(), principal.User.ID, ticket.ID)
+	if err != nil {
+		return err
+	}
+	detail := ticketDetail(ticket, msgs, history)
+	detail.Participants = participantResponses(cc)
+	return c.JSON(fiber.Map{"data": detail})
 }
 
 // AddMessage POST /tickets/:id/messages.
@@ -111,7 +124,7 @@ func (h *TicketsHandler) AddMessage(c *fiber.Ctx) error {
 			SizeBytes:  att.SizeBytes,
 		})
 	}
-	msg, err := h.service.AddMessage(c.Context(), domain.SubjectTypeUser, principal.User.ID, nil, c.Params("id"), messageType, req.Body, attachments)
+	msg, err := h.service.AddMessage(c.Context(), domain.SubjectTypeUser, principal.User.ID, nil, c.Params("id"), messageType, req.Body, req.BodyFormat, attachments)
 	if err != nil {
 		return err
 	}
@@ -149,10 +162,12 @@ func parseUserTicketQuery(c *fiber.Ctx) service.TicketUserFilter {
 	if to := parseTime(c.Query("created_to")); to != nil {
 		filter.CreatedTo = to
 	}
-	page := parseInt(c.Query("page"), 1)
-	pageSize := parseInt(c.Query("page_size"), 20)
-	filter.Offset = (page - 1) * pageSize
-	filter.Limit = pageSize
+	if search := c.Query("search"); search != "" {
+		filter.SearchTerm = &search
+	}
+	filter.Limit = parseInt(c.Query("page_size"), repository.DefaultPageSize)
+	filter.Sort = c.Query("sort")
+	filter.Cursor = c.Query("cursor")
 	return filter
 }
 
@@ -180,16 +195,20 @@ func parseInt(val string, def int) int {
 
 func ticketSummary(ticket *domain.Ticket) dto.TicketSummary {
 	return dto.TicketSummary{
-		ID:           ticket.ID,
-		ExternalKey:  ticket.ExternalKey,
-		DepartmentID: ticket.DepartmentID,
-		TeamID:       ticket.TeamID,
-		Title:        ticket.Title,
-		Status:       ticket.Status,
-		Priority:     ticket.Priority,
-		Tags:         ticket.Tags,
-		CreatedAt:    ticket.CreatedAt,
-		UpdatedAt:    ticket.UpdatedAt,
+		ID:             ticket.ID,
+		ExternalKey:    ticket.ExternalKey,
+		DepartmentID:   ticket.DepartmentID,
+		TeamID:         ticket.TeamID,
+		Title:          ticket.Title,
+		Status:         ticket.Status,
+		WorkflowStatus: ticket.WorkflowStatus,
+		Priority:       ticket.Priority,
+		Tags:           ticket.Tags,
+		CustomFields:   ticket.CustomFields,
+		SLADueAt:       ticket.SLADueAt,
+		MergedIntoID:   ticket.MergedIntoID,
+		CreatedAt:      ticket.CreatedAt,
+		UpdatedAt:      ticket.UpdatedAt,
 	}
 }
 
@@ -200,20 +219,24 @@ func ticketDetail(ticket *domain.Ticket, messages []domain.TicketMessage, histor
 	}
 	historyResp := historyResponses(history)
 	return dto.TicketDetailResponse{
-		ID:           ticket.ID,
-		ExternalKey:  ticket.ExternalKey,
-		DepartmentID: ticket.DepartmentID,
-		TeamID:       ticket.TeamID,
-		Title:        ticket.Title,
-		Description:  ticket.Description,
-		Status:       ticket.Status,
-		Priority:     ticket.Priority,
-		Tags:         ticket.Tags,
-		CreatedAt:    ticket.CreatedAt,
-		UpdatedAt:    ticket.UpdatedAt,
-		ClosedAt:     ticket.ClosedAt,
-		Messages:     msgs,
-		History:      historyResp,
+		ID:             ticket.ID,
+		ExternalKey:    ticket.ExternalKey,
+		DepartmentID:   ticket.DepartmentID,
+		TeamID:         ticket.TeamID,
+		Title:          ticket.Title,
+		Description:    ticket.Description,
+		Status:         ticket.Status,
+		WorkflowStatus: ticket.WorkflowStatus,
+		Priority:       ticket.Priority,
+		Tags:           ticket.Tags,
+		CustomFields:   ticket.CustomFields,
+		SLADueAt:       ticket.SLADueAt,
+		MergedIntoID:   ticket.MergedIntoID,
+		CreatedAt:      ticket.CreatedAt,
+		UpdatedAt:      ticket.UpdatedAt,
+		ClosedAt:       ticket.ClosedAt,
+		Messages:       msgs,
+		History:        historyResp,
 	}
 }
 
@@ -221,10 +244,13 @@ func ticketMessageResponse(msg *domain.TicketMessage) dto.TicketMessageResponse
 	attachments := make([]dto.AttachmentResponse, 0, len(msg.Attachments))
 	for _, att := range msg.Attachments {
 		attachments = append(attachments, dto.AttachmentResponse{
-			ID:        att.ID,
-			FileName:  att.FileName,
-			MimeType:  att.MimeType,
-			SizeBytes: att.SizeBytes,
+			ID:           att.ID,
+			FileName:     att.FileName,
+			MimeType:     att.MimeType,
+			SizeBytes:    att.SizeBytes,
+			ScanStatus:   att.ScanStatus,
+			URL:          att.DownloadURL,
+			ThumbnailURL: att.ThumbnailURL,
 		})
 	}
 	return dto.TicketMessageResponse{
@@ -232,24 +258,42 @@ func ticketMessageResponse(msg *domain.TicketMessage) dto.TicketMessageResponse
 		MessageType: msg.MessageType,
 		AuthorType:  msg.AuthorType,
 		AuthorID:    msg.AuthorID,
+		Author:      authorResponse(msg.Author),
 		Body:        msg.Body,
+		BodyFormat:  msg.BodyFormat,
+		BodyHTML:    richtext.Render(msg.BodyFormat, msg.Body),
 		Attachments: attachments,
 		CreatedAt:   msg.CreatedAt,
+		EditedAt:    msg.EditedAt,
+		Redacted:    msg.RedactedAt != nil,
 	}
 }
 
 func historyResponses(entries []domain.TicketHistory) []dto.TicketHistoryResponse {
 	resp := make([]dto.TicketHistoryResponse, 0, len(entries))
-	for _, entry := range entries {
-		resp = append(resp, dto.TicketHistoryResponse{
-			ID:            entry.ID,
-			ChangeType:    entry.ChangeType,
-			ChangedByType: entry.ChangedByType,
-			ChangedByID:   entry.ChangedByID,
-			OldValue:      entry.OldValue,
-			NewValue:      entry.NewValue,
-			CreatedAt:     entry.CreatedAt,
-		})
+	for i := range entries {
+		resp = append(resp, historyResponse(&entries[i]))
 	}
 	return resp
 }
+
+func historyResponse(entry *domain.TicketHistory) dto.TicketHistoryResponse {
+	return dto.TicketHistoryResponse{
+		ID:            entry.ID,
+		ChangeType:    entry.ChangeType,
+		ChangedByType: entry.ChangedByType,
+		ChangedByID:   entry.ChangedByID,
+		ChangedBy:     authorResponse(entry.ChangedBy),
+		OldValue:      entry.OldValue,
+		NewValue:      entry.NewValue,
+		CreatedAt:     entry.CreatedAt,
+	}
+}
+
+func authorResponse(author domain.AuthorProfile) dto.AuthorResponse {
+	return dto.AuthorResponse{
+		Name:      author.Name,
+		Role:      author.Role,
+		AvatarURL: author.AvatarURL,
+	}
+}
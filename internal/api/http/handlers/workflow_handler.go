@@ -0,0 +1,84 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/spec-kit/ticket-service/internal/api/dto"
+	"github.com/spec-kit/ticket-service/internal/domain"
+	"github.com/spec-kit/ticket-service/internal/service"
+	apperrors "github.com/spec-kit/ticket-service/pkg/util/errorutil"
+)
+
+// WorkflowHandler exposes admin endpoints for department workflows.
+type WorkflowHandler struct {
+	service *service.WorkflowService
+}
+
+// NewWorkflowHandler constructs handler.
+func NewWorkflowHandler(workflowService *service.WorkflowService) *WorkflowHandler {
+	return &WorkflowHandler{service: workflowService}
+}
+
+// GetWorkflow handles GET /staff/departments/:id/workflow.
+func (h *WorkflowHandler) GetWorkflow(c *fiber.Ctx) error {
+	staff, err := staffPrincipal(c)
+	if err != nil {
+		return err
+	}
+	workflow, err := h.service.GetWorkflow(c.Context(), staff, c.Params("id"))
+	if err != nil {
+		return err
+	}
+	return c.JSON(fiber.Map{"data": workflowResponse(workflow)})
+}
+
+// SaveWorkflow handles PUT /staff/departments/:id/workflow.
+func (h *WorkflowHandler) SaveWorkflow(c *fiber.Ctx) error {
+	staff, err := staffPrincipal(c)
+	if err != nil {
+		return err
+	}
+	var req dto.WorkflowRequest
+	if err := c.BodyParser(&req); err != nil {
+		return apperrors.NewValidationError("invalid payload", nil)
+	}
+	workflow, err := h.service.SaveWorkflow(c.Context(), staff, c.Params("id"), service.WorkflowInput{
+		Name:          req.Name,
+		InitialStatus: req.InitialStatus,
+		Statuses:      req.Statuses,
+		Transitions:   req.Transitions,
+		IsActive:      req.IsActive,
+	})
+	if err != nil {
+		return err
+	}
+	return c.JSON(fiber.Map{"data": workflowResponse(workflow)})
+}
+
+// DeleteWorkflow handles DELETE /staff/departments/:id/workflow.
+func (h *WorkflowHandler) DeleteWorkflow(c *fiber.Ctx) error {
+	staff, err := staffPrincipal(c)
+	if err != nil {
+		return err
+	}
+	if err := h.service.DeleteWorkflow(c.Context(), staff, c.Params("id")); err != nil {
+		return err
+	}
+	return c.SendStatus(http.StatusNoContent)
+}
+
+func workflowResponse(workflow *domain.Workflow) dto.WorkflowResponse {
+	return dto.WorkflowResponse{
+		ID:            workflow.ID,
+		DepartmentID:  workflow.DepartmentID,
+		Name:          workflow.Name,
+		InitialStatus: workflow.InitialStatus,
+		Statuses:      workflow.Statuses,
+		Transitions:   workflow.Transitions,
+		IsActive:      workflow.IsActive,
+		CreatedAt:     workflow.CreatedAt,
+		UpdatedAt:     workflow.UpdatedAt,
+	}
+}
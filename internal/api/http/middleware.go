@@ -2,6 +2,8 @@ package http
 
 import (
 	"context"
+	"net/http"
+	"regexp"
 	"runtime/debug"
 	"time"
 
@@ -12,13 +14,40 @@ import (
 	apperrors "github.com/spec-kit/ticket-service/pkg/util/errorutil"
 )
 
-// RegisterMiddlewares attaches global middlewares such as error handling and logging.
-func RegisterMiddlewares(app *fiber.App, logger *zap.Logger, metrics *observability.Metrics, timeout time.Duration) {
+// attachmentUploadPath matches the multipart upload routes, the only ones allowed bodies above
+// the app's body limit.
+var attachmentUploadPath = regexp.MustCompile(`(?i)^(/staff)?/tickets/[^/]+/attachments/?$`)
+
+// RegisterMiddlewares attaches global middlewares such as error handling and logging. Request
+// bodies are held to the app's body limit, or to uploadBodyLimit on the attachment upload routes.
+func RegisterMiddlewares(app *fiber.App, logger *zap.Logger, metrics *observability.Metrics, timeout time.Duration, uploadBodyLimit int) {
 	if timeout > 0 {
 		app.Use(requestTimeoutMiddleware(timeout))
 	}
 	app.Use(errorHandlingMiddleware(logger, metrics))
 	app.Use(observability.RequestLogger(logger, metrics))
+	app.Use(bodyLimitMiddleware(app.Config().BodyLimit, uploadBodyLimit))
+}
+
+// bodyLimitMiddleware rejects bodies over the limit from their declared length. The server
+// streams request bodies instead of enforcing its own limit, so chunked bodies of unknown
+// length are refused as well. A rejected body is left unread, so the connection is closed.
+func bodyLimitMiddleware(limit, uploadLimit int) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		max := limit
+		if uploadLimit > limit && c.Method() == fiber.MethodPost && attachmentUploadPath.MatchString(c.Path()) {
+			max = uploadLimit
+		}
+		switch length := c.Request().Header.ContentLength(); {
+		case length > max:
+			c.Context().SetConnectionClose()
+			return apperrors.NewDomainError("PAYLOAD_TOO_LARGE", "request body too large", http.StatusRequestEntityTooLarge, map[string]any{"max_bytes": max})
+		case length == -1:
+			c.Context().SetConnectionClose()
+			return apperrors.NewDomainError("LENGTH_REQUIRED", "request body length required", http.StatusLengthRequired, nil)
+		}
+		return c.Next()
+	}
 }
 
 func requestTimeoutMiddleware(timeout time.Duration) fiber.Handler {
@@ -10,12 +10,22 @@ import (
 
 // RouteConfig bundles dependencies for route registration.
 type RouteConfig struct {
-	Health         *handlers.HealthHandler
-	Users          *handlers.UsersHandler
-	Staff          *handlers.StaffHandler
-	Tickets        *handlers.TicketsHandler
-	StaffTickets   *handlers.StaffTicketsHandler
-	AuthMiddleware *auth.AuthMiddleware
+	Health          *handlers.HealthHandler
+	Users           *handlers.UsersHandler
+	Staff           *handlers.StaffHandler
+	Tickets         *handlers.TicketsHandler
+	StaffTickets    *handlers.StaffTicketsHandler
+	Escalations     *handlers.EscalationHandler
+	Workflows       *handlers.WorkflowHandler
+	CustomFields    *handlers.CustomFieldHandler
+	TicketForms     *handlers.TicketFormHandler
+	SavedViews      *handlers.SavedViewHandler
+	Tags            *handlers.TagHandler
+	Mentions        *handlers.MentionHandler
+	CannedResponses *handlers.CannedResponseHandler
+	Macros          *handlers.MacroHandler
+	Attachments     *handlers.AttachmentHandler
+	AuthMiddleware  *auth.AuthMiddleware
 }
 
 // RegisterRoutes wires HTTP routes.
@@ -34,12 +44,24 @@ func RegisterRoutes(app *fiber.App, cfg RouteConfig) {
 	protected := authGroup.Group("", cfg.AuthMiddleware.Handle, auth.RequireAnyRole())
 	protected.Post("/password/change", cfg.Staff.ChangePassword)
 
+	app.Get("/departments/:id/form", cfg.TicketForms.GetDepartmentForm)
+
 	ticketsGroup := app.Group("/tickets", cfg.AuthMiddleware.Handle, auth.RequireUser())
 	ticketsGroup.Post("/", cfg.Tickets.CreateTicket)
 	ticketsGroup.Get("/", cfg.Tickets.ListTickets)
 	ticketsGroup.Get("/:id", cfg.Tickets.GetTicket)
 	ticketsGroup.Post("/:id/messages", cfg.Tickets.AddMessage)
 	ticketsGroup.Post("/:id/close", cfg.Tickets.CloseTicket)
+	ticketsGroup.Post("/:id/attachments", cfg.Attachments.UploadUserAttachments)
+	ticketsGroup.Get("/:id/attachments/:attachmentId", cfg.Attachments.DownloadUserAttachment)
+	ticketsGroup.Get("/:id/cc", cfg.Tickets.ListCC)
+	ticketsGroup.Post("/:id/cc", cfg.Tickets.AddCC)
+	ticketsGroup.Delete("/:id/cc/:participantId", cfg.Tickets.RemoveCC)
+
+	// signed links authorize these downloads, so no bearer token is required
+	linkedAttachments := app.Group("/attachments")
+	linkedAttachments.Get("/:attachmentId", cfg.Attachments.DownloadLinkedAttachment)
+	linkedAttachments.Get("/:attachmentId/thumbnail", cfg.Attachments.AttachmentThumbnail)
 
 	staffBase := app.Group("/staff")
 	adminGroup := staffBase.Group("", cfg.AuthMiddleware.Handle, auth.RequireStaffRole(domain.StaffRoleAdmin))
@@ -47,6 +69,12 @@ func RegisterRoutes(app *fiber.App, cfg RouteConfig) {
 	adminGroup.Get("/departments", cfg.Staff.ListDepartments)
 	adminGroup.Get("/departments/:id", cfg.Staff.GetDepartment)
 	adminGroup.Put("/departments/:id", cfg.Staff.UpdateDepartment)
+	adminGroup.Get("/departments/:id/workflow", cfg.Workflows.GetWorkflow)
+	adminGroup.Put("/departments/:id/workflow", cfg.Workflows.SaveWorkflow)
+	adminGroup.Delete("/departments/:id/workflow", cfg.Workflows.DeleteWorkflow)
+	adminGroup.Get("/departments/:id/form", cfg.TicketForms.GetForm)
+	adminGroup.Put("/departments/:id/form", cfg.TicketForms.SaveForm)
+	adminGroup.Delete("/departments/:id/form", cfg.TicketForms.DeleteForm)
 
 	adminGroup.Post("/teams", cfg.Staff.CreateTeam)
 	adminGroup.Get("/teams", cfg.Staff.ListTeams)
@@ -58,16 +86,80 @@ func RegisterRoutes(app *fiber.App, cfg RouteConfig) {
 	adminGroup.Get("/members/:id", cfg.Staff.GetStaff)
 	adminGroup.Put("/members/:id", cfg.Staff.UpdateStaff)
 
+	adminGroup.Post("/escalation-rules", cfg.Escalations.CreateRule)
+	adminGroup.Get("/escalation-rules", cfg.Escalations.ListRules)
+	adminGroup.Put("/escalation-rules/:id", cfg.Escalations.UpdateRule)
+
+	adminGroup.Post("/custom-fields", cfg.CustomFields.CreateField)
+	adminGroup.Get("/custom-fields", cfg.CustomFields.ListFields)
+	adminGroup.Put("/custom-fields/:id", cfg.CustomFields.UpdateField)
+
+	adminGroup.Post("/tickets/:id/messages/:messageId/redact", cfg.StaffTickets.RedactMessage)
+	adminGroup.Get("/tickets/:id/messages/:messageId/revisions", cfg.StaffTickets.ListMessageRevisions)
+
+	adminGroup.Post("/tags", cfg.Tags.CreateTag)
+	adminGroup.Put("/tags/:id", cfg.Tags.UpdateTag)
+	adminGroup.Post("/tags/:id/merge", cfg.Tags.MergeTag)
+
 	staffTicketsBase := staffBase.Group("/tickets")
 	staffTickets := staffTicketsBase.Group("", cfg.AuthMiddleware.Handle, auth.RequireStaffRole(domain.StaffRoleAgent, domain.StaffRoleTeamLead, domain.StaffRoleAdmin))
 	staffTickets.Get("/", cfg.StaffTickets.ListStaffTickets)
 	staffTickets.Get("/:id", cfg.StaffTickets.GetStaffTicket)
 	staffTickets.Post("/:id/messages", cfg.StaffTickets.AddStaffMessage)
+	staffTickets.Put("/:id/messages/:messageId", cfg.StaffTickets.EditMessage)
+	staffTickets.Post("/:id/attachments", cfg.Attachments.UploadStaffAttachments)
+	staffTickets.Get("/:id/attachments/:attachmentId", cfg.Attachments.DownloadStaffAttachment)
 	staffTickets.Post("/:id/assign/self", cfg.StaffTickets.SelfAssignTicket)
 	staffTickets.Post("/:id/status", cfg.StaffTickets.UpdateStatus)
 	staffTickets.Post("/:id/priority", cfg.StaffTickets.UpdatePriority)
+	staffTickets.Post("/:id/custom-fields", cfg.StaffTickets.UpdateCustomFields)
+	staffTickets.Post("/:id/tags", cfg.StaffTickets.UpdateTags)
+	staffTickets.Post("/:id/merge", cfg.StaffTickets.MergeTickets)
+	staffTickets.Post("/:id/split", cfg.StaffTickets.SplitTicket)
+	staffTickets.Get("/:id/links", cfg.StaffTickets.ListLinks)
+	staffTickets.Post("/:id/links", cfg.StaffTickets.CreateLink)
+	staffTickets.Put("/:id/links/:linkId", cfg.StaffTickets.UpdateLink)
+	staffTickets.Delete("/:id/links/:linkId", cfg.StaffTickets.DeleteLink)
+	staffTickets.Get("/:id/participants", cfg.StaffTickets.ListParticipants)
+	staffTickets.Post("/:id/participants", cfg.StaffTickets.AddParticipant)
+	staffTickets.Delete("/:id/participants/:participantId", cfg.StaffTickets.RemoveParticipant)
+	staffTickets.Post("/:id/watch", cfg.StaffTickets.WatchTicket)
+	staffTickets.Delete("/:id/watch", cfg.StaffTickets.UnwatchTicket)
+	staffTickets.Get("/:id/canned-responses/:responseId", cfg.CannedResponses.RenderResponse)
+	staffTickets.Post("/:id/macros/:macroId", cfg.Macros.ApplyMacro)
 	staffTickets.Get("/:id/history", cfg.StaffTickets.GetHistory)
 
+	staffViews := staffBase.Group("/views", cfg.AuthMiddleware.Handle, auth.RequireStaffRole(domain.StaffRoleAgent, domain.StaffRoleTeamLead, domain.StaffRoleAdmin))
+	staffViews.Get("/", cfg.SavedViews.ListViews)
+	staffViews.Post("/", cfg.SavedViews.CreateView)
+	staffViews.Get("/counts", cfg.SavedViews.ViewCounts)
+	staffViews.Get("/:id", cfg.SavedViews.GetView)
+	staffViews.Put("/:id", cfg.SavedViews.UpdateView)
+	staffViews.Delete("/:id", cfg.SavedViews.DeleteView)
+	staffViews.Get("/:id/tickets", cfg.SavedViews.RunView)
+
+	staffTags := staffBase.Group("/tags", cfg.AuthMiddleware.Handle, auth.RequireStaffRole(domain.StaffRoleAgent, domain.StaffRoleTeamLead, domain.StaffRoleAdmin))
+	staffTags.Get("/", cfg.Tags.ListTags)
+	staffTags.Get("/usage", cfg.Tags.TagUsage)
+
+	staffResponses := staffBase.Group("/canned-responses", cfg.AuthMiddleware.Handle, auth.RequireStaffRole(domain.StaffRoleAgent, domain.StaffRoleTeamLead, domain.StaffRoleAdmin))
+	staffResponses.Get("/", cfg.CannedResponses.ListResponses)
+	staffResponses.Post("/", cfg.CannedResponses.CreateResponse)
+	staffResponses.Get("/:id", cfg.CannedResponses.GetResponse)
+	staffResponses.Put("/:id", cfg.CannedResponses.UpdateResponse)
+	staffResponses.Delete("/:id", cfg.CannedResponses.DeleteResponse)
+
+	staffMacros := staffBase.Group("/macros", cfg.AuthMiddleware.Handle, auth.RequireStaffRole(domain.StaffRoleAgent, domain.StaffRoleTeamLead, domain.StaffRoleAdmin))
+	staffMacros.Get("/", cfg.Macros.ListMacros)
+	staffMacros.Post("/", cfg.Macros.CreateMacro)
+	staffMacros.Get("/:id", cfg.Macros.GetMacro)
+	staffMacros.Put("/:id", cfg.Macros.UpdateMacro)
+	staffMacros.Delete("/:id", cfg.Macros.DeleteMacro)
+
+	staffMe := staffBase.Group("/me", cfg.AuthMiddleware.Handle, auth.RequireStaffRole(domain.StaffRoleAgent, domain.StaffRoleTeamLead, domain.StaffRoleAdmin))
+	staffMe.Get("/mentions", cfg.Mentions.ListMentions)
+	staffMe.Post("/mentions/read", cfg.Mentions.MarkMentions)
+
 	assignGroup := staffTicketsBase.Group("", cfg.AuthMiddleware.Handle, auth.RequireStaffRole(domain.StaffRoleTeamLead, domain.StaffRoleAdmin))
 	assignGroup.Post("/:id/assign", cfg.StaffTickets.AssignTicket)
 	assignGroup.Post("/:id/assign/team", cfg.StaffTickets.AssignTicketToTeam)
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/joho/godotenv"
@@ -17,6 +18,15 @@ type Config struct {
 	Logger       LoggerConfig
 	Auth         AuthConfig
 	Notification NotificationConfig
+	SLA          SLAConfig
+	Escalation   EscalationConfig
+	AutoClose    AutoCloseConfig
+	Messages     MessageEffectsConfig
+	Storage      StorageConfig
+	Attachments  AttachmentPolicyConfig
+	Scanning     AttachmentScanConfig
+	Links        AttachmentLinkConfig
+	Authors      AuthorDisplayConfig
 }
 
 // AppConfig controls server level behavior.
@@ -65,6 +75,94 @@ type NotificationConfig struct {
 	WebhookURL string
 }
 
+// SLAConfig defines resolution targets per ticket priority.
+type SLAConfig struct {
+	LowMinutes    int
+	MediumMinutes int
+	HighMinutes   int
+	UrgentMinutes int
+}
+
+// EscalationConfig controls the escalation scheduler.
+type EscalationConfig struct {
+	Enabled         bool
+	IntervalSeconds int
+	BatchSize       int
+}
+
+// AutoCloseConfig controls closing of idle resolved and pending-user tickets.
+type AutoCloseConfig struct {
+	Enabled          bool
+	IntervalSeconds  int
+	BatchSize        int
+	ResolvedHours    int
+	PendingUserHours int
+	WarningHours     int
+}
+
+// MessageEffectsConfig controls status changes triggered by new messages and how long
+// staff may edit their own messages.
+type MessageEffectsConfig struct {
+	ReopenOnRequesterReply  bool
+	FollowUpOnClosedReply   bool
+	PendingUserOnStaffReply bool
+	EditWindowMinutes       int
+}
+
+// StorageConfig selects where attachment bytes are kept. Backend is "local" or "s3"; the S3
+// settings also work with S3-compatible servers such as MinIO via S3Endpoint and S3PathStyle.
+type StorageConfig struct {
+	Backend     string
+	LocalDir    string
+	S3Endpoint  string
+	S3Region    string
+	S3Bucket    string
+	S3AccessKey string
+	S3SecretKey string
+	S3PathStyle bool
+}
+
+// AttachmentPolicyConfig limits what may be attached to messages. AllowedMimeTypes accepts
+// exact types or "type/*" wildcards and applies to departments without their own list; an
+// empty list allows any type. BlockedExtensions always apply. Non-positive size limits are not
+// enforced.
+type AttachmentPolicyConfig struct {
+	MaxFileBytes      int64
+	MaxMessageBytes   int64
+	AllowedMimeTypes  []string
+	BlockedExtensions []string
+}
+
+// AttachmentScanConfig selects the malware scanner for uploads. Backend is "none", "clamd" or
+// "fake"; with "none" uploads are treated as clean. ClamdAddress is tcp://host:port or
+// unix:///path/to/socket. Scans that keep failing are given up after MaxAttempts.
+type AttachmentScanConfig struct {
+	Backend         string
+	ClamdAddress    string
+	TimeoutSeconds  int
+	IntervalSeconds int
+	BatchSize       int
+	MaxAttempts     int
+}
+
+// AttachmentLinkConfig controls signed attachment download links. BaseURL is prefixed to link
+// paths and may be empty for relative links. SigningKey falls back to a key derived from the JWT
+// secret.
+type AttachmentLinkConfig struct {
+	BaseURL    string
+	SigningKey string
+	TTLSeconds int
+}
+
+// AuthorDisplayConfig controls how message authors and history actors are shown. End-users see
+// staff as PublicAgentAlias with PublicAgentAvatarURL. AvatarURLTemplate builds avatar links from
+// the "{email_hash}" placeholder, the SHA-256 of the lowercased email; empty disables avatars.
+type AuthorDisplayConfig struct {
+	PublicAgentAlias     string
+	PublicAgentAvatarURL string
+	AvatarURLTemplate    string
+}
+
 // Load reads configuration from environment variables, applying defaults where possible.
 func Load() (*Config, error) {
 	_ = godotenv.Load()
@@ -115,6 +213,65 @@ func Load() (*Config, error) {
 			EmailFrom:  getEnv("NOTIFY_EMAIL_FROM", "noreply@example.com"),
 			WebhookURL: getEnv("NOTIFY_WEBHOOK_URL", ""),
 		},
+		SLA: SLAConfig{
+			LowMinutes:    getEnvAsInt("SLA_LOW_MINUTES", 4320),
+			MediumMinutes: getEnvAsInt("SLA_MEDIUM_MINUTES", 1440),
+			HighMinutes:   getEnvAsInt("SLA_HIGH_MINUTES", 480),
+			UrgentMinutes: getEnvAsInt("SLA_URGENT_MINUTES", 120),
+		},
+		Escalation: EscalationConfig{
+			Enabled:         getEnvAsBool("ESCALATION_ENABLED", true),
+			IntervalSeconds: getEnvAsInt("ESCALATION_INTERVAL_SECONDS", 60),
+			BatchSize:       getEnvAsInt("ESCALATION_BATCH_SIZE", 100),
+		},
+		AutoClose: AutoCloseConfig{
+			Enabled:          getEnvAsBool("AUTO_CLOSE_ENABLED", true),
+			IntervalSeconds:  getEnvAsInt("AUTO_CLOSE_INTERVAL_SECONDS", 300),
+			BatchSize:        getEnvAsInt("AUTO_CLOSE_BATCH_SIZE", 100),
+			ResolvedHours:    getEnvAsInt("AUTO_CLOSE_RESOLVED_HOURS", 72),
+			PendingUserHours: getEnvAsInt("AUTO_CLOSE_PENDING_USER_HOURS", 168),
+			WarningHours:     getEnvAsInt("AUTO_CLOSE_WARNING_HOURS", 24),
+		},
+		Messages: MessageEffectsConfig{
+			ReopenOnRequesterReply:  getEnvAsBool("MESSAGE_REOPEN_ON_REQUESTER_REPLY", true),
+			FollowUpOnClosedReply:   getEnvAsBool("MESSAGE_FOLLOW_UP_ON_CLOSED_REPLY", true),
+			PendingUserOnStaffReply: getEnvAsBool("MESSAGE_PENDING_USER_ON_STAFF_REPLY", false),
+			EditWindowMinutes:       getEnvAsInt("MESSAGE_EDIT_WINDOW_MINUTES", 15),
+		},
+		Storage: StorageConfig{
+			Backend:     getEnv("STORAGE_BACKEND", "local"),
+			LocalDir:    getEnv("STORAGE_LOCAL_DIR", "./data/attachments"),
+			S3Endpoint:  getEnv("STORAGE_S3_ENDPOINT", "https://s3.amazonaws.com"),
+			S3Region:    getEnv("STORAGE_S3_REGION", "us-east-1"),
+			S3Bucket:    os.Getenv("STORAGE_S3_BUCKET"),
+			S3AccessKey: os.Getenv("STORAGE_S3_ACCESS_KEY"),
+			S3SecretKey: os.Getenv("STORAGE_S3_SECRET_KEY"),
+			S3PathStyle: getEnvAsBool("STORAGE_S3_PATH_STYLE", false),
+		},
+		Attachments: AttachmentPolicyConfig{
+			MaxFileBytes:      int64(getEnvAsInt("ATTACHMENT_MAX_FILE_BYTES", 25<<20)),
+			MaxMessageBytes:   int64(getEnvAsInt("ATTACHMENT_MAX_MESSAGE_BYTES", 50<<20)),
+			AllowedMimeTypes:  getEnvAsList("ATTACHMENT_ALLOWED_MIME_TYPES", nil),
+			BlockedExtensions: getEnvAsList("ATTACHMENT_BLOCKED_EXTENSIONS", []string{".exe", ".bat", ".cmd", ".com", ".scr", ".pif", ".msi", ".dll", ".vbs", ".js", ".jse", ".wsf", ".ps1", ".sh", ".jar", ".apk"}),
+		},
+		Scanning: AttachmentScanConfig{
+			Backend:         getEnv("ATTACHMENT_SCAN_BACKEND", "none"),
+			ClamdAddress:    getEnv("ATTACHMENT_SCAN_CLAMD_ADDRESS", "tcp://127.0.0.1:3310"),
+			TimeoutSeconds:  getEnvAsInt("ATTACHMENT_SCAN_TIMEOUT_SECONDS", 60),
+			IntervalSeconds: getEnvAsInt("ATTACHMENT_SCAN_INTERVAL_SECONDS", 30),
+			BatchSize:       getEnvAsInt("ATTACHMENT_SCAN_BATCH_SIZE", 20),
+			MaxAttempts:     getEnvAsInt("ATTACHMENT_SCAN_MAX_ATTEMPTS", 5),
+		},
+		Links: AttachmentLinkConfig{
+			BaseURL:    strings.TrimRight(os.Getenv("ATTACHMENT_LINK_BASE_URL"), "/"),
+			SigningKey: os.Getenv("ATTACHMENT_LINK_SIGNING_KEY"),
+			TTLSeconds: getEnvAsInt("ATTACHMENT_LINK_TTL_SECONDS", 300),
+		},
+		Authors: AuthorDisplayConfig{
+			PublicAgentAlias:     getEnv("PUBLIC_AGENT_ALIAS", "Support Team"),
+			PublicAgentAvatarURL: os.Getenv("PUBLIC_AGENT_AVATAR_URL"),
+			AvatarURLTemplate:    os.Getenv("AUTHOR_AVATAR_URL_TEMPLATE"),
+		},
 	}
 
 	return cfg, nil
@@ -133,6 +290,54 @@ func (a AppConfig) RequestTimeout() time.Duration {
 	return time.Duration(a.RequestTimeoutSeconds) * time.Second
 }
 
+// Interval returns how often escalation rules are evaluated.
+func (e EscalationConfig) Interval() time.Duration {
+	if e.IntervalSeconds <= 0 {
+		return time.Minute
+	}
+	return time.Duration(e.IntervalSeconds) * time.Second
+}
+
+// Interval returns how often idle tickets are checked.
+func (a AutoCloseConfig) Interval() time.Duration {
+	if a.IntervalSeconds <= 0 {
+		return 5 * time.Minute
+	}
+	return time.Duration(a.IntervalSeconds) * time.Second
+}
+
+// EditWindow returns how long after posting staff may edit a message; zero disables editing.
+func (m MessageEffectsConfig) EditWindow() time.Duration {
+	if m.EditWindowMinutes <= 0 {
+		return 0
+	}
+	return time.Duration(m.EditWindowMinutes) * time.Minute
+}
+
+// Timeout bounds a single scan.
+func (a AttachmentScanConfig) Timeout() time.Duration {
+	if a.TimeoutSeconds <= 0 {
+		return time.Minute
+	}
+	return time.Duration(a.TimeoutSeconds) * time.Second
+}
+
+// Interval returns how often pending uploads are picked up when no upload wakes the scanner.
+func (a AttachmentScanConfig) Interval() time.Duration {
+	if a.IntervalSeconds <= 0 {
+		return 30 * time.Second
+	}
+	return time.Duration(a.IntervalSeconds) * time.Second
+}
+
+// TTL returns how long a signed attachment link stays valid.
+func (a AttachmentLinkConfig) TTL() time.Duration {
+	if a.TTLSeconds <= 0 {
+		return 5 * time.Minute
+	}
+	return time.Duration(a.TTLSeconds) * time.Second
+}
+
 func getEnv(key, fallback string) string {
 	if val := os.Getenv(key); val != "" {
 		return val
@@ -152,6 +357,20 @@ func getEnvAsInt(key string, fallback int) int {
 	return parsed
 }
 
+func getEnvAsList(key string, fallback []string) []string {
+	val := os.Getenv(key)
+	if val == "" {
+		return fallback
+	}
+	var result []string
+	for _, item := range strings.Split(val, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			result = append(result, item)
+		}
+	}
+	return result
+}
+
 func getEnvAsBool(key string, fallback bool) bool {
 	val := os.Getenv(key)
 	if val == "" {
@@ -0,0 +1,35 @@
+package domain
+
+import "time"
+
+// AttachmentScanStatus tracks the malware scan of stored attachment bytes. Only CLEAN files may
+// be downloaded; INFECTED and FAILED files stay quarantined.
+type AttachmentScanStatus string
+
+const (
+	ScanStatusPending  AttachmentScanStatus = "PENDING"
+	ScanStatusClean    AttachmentScanStatus = "CLEAN"
+	ScanStatusInfected AttachmentScanStatus = "INFECTED"
+	ScanStatusFailed   AttachmentScanStatus = "FAILED"
+)
+
+// AttachmentUpload is a file stored for a ticket that has not yet been attached to a message.
+// Messages reference uploads by their server-issued StorageKey.
+type AttachmentUpload struct {
+	ID           string
+	TicketID     string
+	StorageKey   string
+	FileName     string
+	MimeType     string
+	SizeBytes    int64
+	UploaderType MessageAuthorType
+	UploaderID   string
+	// MessageID is set once the upload is attached to a message.
+	MessageID *string
+	// ScanSignature names the malware found when ScanStatus is INFECTED.
+	ScanStatus    AttachmentScanStatus
+	ScanSignature *string
+	ScanAttempts  int
+	ScannedAt     *time.Time
+	CreatedAt     time.Time
+}
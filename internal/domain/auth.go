@@ -8,6 +8,8 @@ type SubjectType string
 const (
 	SubjectTypeUser  SubjectType = "USER"
 	SubjectTypeStaff SubjectType = "STAFF"
+	// SubjectTypeSystem marks actions taken by background jobs rather than a caller.
+	SubjectTypeSystem SubjectType = "SYSTEM"
 )
 
 // Token represents issued authentication tokens (JWT or opaque) metadata.
@@ -0,0 +1,20 @@
+package domain
+
+// AuthorRole is the display role of whoever wrote a message or changed a ticket.
+type AuthorRole string
+
+const (
+	AuthorRoleCustomer AuthorRole = "CUSTOMER"
+	AuthorRoleAgent    AuthorRole = "AGENT"
+	AuthorRoleTeamLead AuthorRole = "TEAM_LEAD"
+	AuthorRoleAdmin    AuthorRole = "ADMIN"
+	AuthorRoleSystem   AuthorRole = "SYSTEM"
+)
+
+// AuthorProfile is how a message author or history actor is displayed. It is resolved when
+// messages and history are loaded and is not stored.
+type AuthorProfile struct {
+	Name      string
+	Role      AuthorRole
+	AvatarURL *string
+}
@@ -0,0 +1,15 @@
+package domain
+
+import "time"
+
+// CannedResponse is a reusable reply body with {{placeholders}} filled in per ticket.
+type CannedResponse struct {
+	ID           string
+	OwnerStaffID string
+	// TeamID shares the response with every member of the team; nil keeps it personal.
+	TeamID    *string
+	Name      string
+	Body      string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
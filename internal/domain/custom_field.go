@@ -0,0 +1,31 @@
+package domain
+
+import "time"
+
+// CustomFieldType enumerates value types supported by custom fields.
+type CustomFieldType string
+
+const (
+	CustomFieldTypeText        CustomFieldType = "TEXT"
+	CustomFieldTypeNumber      CustomFieldType = "NUMBER"
+	CustomFieldTypeDate        CustomFieldType = "DATE"
+	CustomFieldTypeEnum        CustomFieldType = "ENUM"
+	CustomFieldTypeMultiSelect CustomFieldType = "MULTI_SELECT"
+)
+
+// CustomFieldDateLayout is the storage format for DATE values.
+const CustomFieldDateLayout = "2006-01-02"
+
+// CustomFieldDefinition describes an admin-defined ticket field; a nil DepartmentID applies to every department.
+type CustomFieldDefinition struct {
+	ID           string
+	Key          string
+	Label        string
+	Type         CustomFieldType
+	Options      []string
+	Required     bool
+	DepartmentID *string
+	IsActive     bool
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
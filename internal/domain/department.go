@@ -2,12 +2,14 @@ package domain
 
 import "time"
 
-// Department represents a high-level organizational unit.
+// Department represents a high-level organizational unit. AllowedAttachmentTypes overrides the
+// global attachment MIME allowlist when non-empty.
 type Department struct {
-	ID          string
-	Name        string
-	Description string
-	IsActive    bool
-	CreatedAt   time.Time
-	UpdatedAt   time.Time
+	ID                     string
+	Name                   string
+	Description            string
+	IsActive               bool
+	AllowedAttachmentTypes []string
+	CreatedAt              time.Time
+	UpdatedAt              time.Time
 }
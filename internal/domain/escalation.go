@@ -0,0 +1,43 @@
+package domain
+
+import "time"
+
+// EscalationCondition enumerates the time-based triggers a rule can watch.
+type EscalationCondition string
+
+const (
+	EscalationConditionUnassigned   EscalationCondition = "UNASSIGNED_FOR"
+	EscalationConditionNoStaffReply EscalationCondition = "NO_STAFF_REPLY_FOR"
+	EscalationConditionSLABreach    EscalationCondition = "SLA_BREACH_WITHIN"
+)
+
+// EscalationAction enumerates what happens when a rule fires.
+type EscalationAction string
+
+const (
+	EscalationActionBumpPriority   EscalationAction = "BUMP_PRIORITY"
+	EscalationActionAssignTeamLead EscalationAction = "ASSIGN_TEAM_LEAD"
+	EscalationActionNotifyManager  EscalationAction = "NOTIFY_MANAGER"
+)
+
+// EscalationRule describes a condition evaluated by the scheduler and the action applied when it matches.
+type EscalationRule struct {
+	ID               string
+	Name             string
+	DepartmentID     *string
+	Priorities       []TicketPriority
+	Condition        EscalationCondition
+	ThresholdMinutes int
+	Action           EscalationAction
+	IsActive         bool
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+}
+
+// EscalationFiring records that a rule has fired for a ticket; each pair fires at most once.
+type EscalationFiring struct {
+	ID       string
+	RuleID   string
+	TicketID string
+	FiredAt  time.Time
+}
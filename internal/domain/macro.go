@@ -0,0 +1,30 @@
+package domain
+
+import "time"
+
+// MacroAssigneeSelf in MacroActions.AssigneeStaffID assigns the ticket to whoever runs the macro.
+const MacroAssigneeSelf = "me"
+
+// MacroActions are the field changes a macro applies; nil or empty fields are left untouched.
+type MacroActions struct {
+	// Status accepts a system status or a department workflow status key.
+	Status          *TicketStatus   `json:"status,omitempty"`
+	Priority        *TicketPriority `json:"priority,omitempty"`
+	AddTags         []string        `json:"add_tags,omitempty"`
+	RemoveTags      []string        `json:"remove_tags,omitempty"`
+	AssigneeStaffID *string         `json:"assignee_staff_id,omitempty"`
+}
+
+// Macro bundles an optional templated reply with field changes applied in one step.
+type Macro struct {
+	ID           string
+	OwnerStaffID string
+	// TeamID shares the macro with every member of the team; nil keeps it personal.
+	TeamID    *string
+	Name      string
+	ReplyBody string
+	ReplyType TicketMessageType
+	Actions   MacroActions
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
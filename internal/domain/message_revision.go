@@ -0,0 +1,25 @@
+package domain
+
+import "time"
+
+// MessageRevisionKind distinguishes author edits from admin redactions.
+type MessageRevisionKind string
+
+const (
+	MessageRevisionEdit   MessageRevisionKind = "EDIT"
+	MessageRevisionRedact MessageRevisionKind = "REDACT"
+)
+
+// MessageRevision is an admin-only audit record of a change to a message body or attachments.
+type MessageRevision struct {
+	ID            string
+	MessageID     string
+	Kind          MessageRevisionKind
+	EditorStaffID *string
+	// PreviousBody holds the body before an edit. Redactions never keep the removed text.
+	PreviousBody       *string
+	RedactedSegments   int
+	RemovedAttachments []AttachmentReference
+	Reason             string
+	CreatedAt          time.Time
+}
@@ -0,0 +1,36 @@
+package domain
+
+import "time"
+
+// SavedViewFilter is the serialized staff ticket filter behind a saved view.
+type SavedViewFilter struct {
+	DepartmentID *string `json:"department_id,omitempty"`
+	TeamID       *string `json:"team_id,omitempty"`
+	AssigneeID   *string `json:"assignee_staff_id,omitempty"`
+	// AssignedToMe resolves to whoever runs the view, so one shared view serves a whole team.
+	AssignedToMe bool              `json:"assigned_to_me,omitempty"`
+	Statuses     []TicketStatus    `json:"statuses,omitempty"`
+	Priorities   []TicketPriority  `json:"priorities,omitempty"`
+	Search       *string           `json:"search,omitempty"`
+	CreatedFrom  *time.Time        `json:"created_from,omitempty"`
+	CreatedTo    *time.Time        `json:"created_to,omitempty"`
+	UpdatedFrom  *time.Time        `json:"updated_from,omitempty"`
+	UpdatedTo    *time.Time        `json:"updated_to,omitempty"`
+	CustomFields map[string]string `json:"custom_fields,omitempty"`
+	TagsAny      []string          `json:"tags_any,omitempty"`
+	TagsAll      []string          `json:"tags_all,omitempty"`
+	TagsNone     []string          `json:"tags_none,omitempty"`
+}
+
+// SavedView is a named ticket filter owned by a staff member, optionally shared with a team as a queue.
+type SavedView struct {
+	ID           string
+	OwnerStaffID string
+	// TeamID shares the view with every member of the team; nil keeps it personal.
+	TeamID    *string
+	Name      string
+	Filter    SavedViewFilter
+	Sort      string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
@@ -0,0 +1,20 @@
+package domain
+
+import "time"
+
+// StaffMention records a staff member being @mentioned in an internal note.
+type StaffMention struct {
+	ID                 string
+	TicketID           string
+	MessageID          string
+	MentionedStaffID   string
+	MentionedByStaffID *string
+	ReadAt             *time.Time
+	CreatedAt          time.Time
+	// Populated on inbox listings.
+	TicketExternalKey string
+	TicketTitle       string
+	// BodyPreview is the note's plain text, cut short by the service.
+	BodyPreview string
+	BodyFormat  MessageBodyFormat
+}
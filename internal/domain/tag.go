@@ -0,0 +1,42 @@
+package domain
+
+import (
+	"regexp"
+	"strings"
+	"time"
+)
+
+var (
+	tagNamePattern  = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{0,49}$`)
+	tagSpacePattern = regexp.MustCompile(`\s+`)
+)
+
+// Tag is a managed catalog entry; tickets reference tags by name.
+type Tag struct {
+	ID          string
+	Name        string
+	Color       *string
+	Description string
+	// Deprecated tags stay on existing tickets but can no longer be added.
+	Deprecated bool
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+// TagUsage counts how many tickets carry a tag.
+type TagUsage struct {
+	Tag         Tag
+	TicketCount int
+	OpenCount   int
+	LastUsedAt  *time.Time
+}
+
+// NormalizeTagName lowercases a tag, trims it and joins words with hyphens.
+func NormalizeTagName(name string) string {
+	return tagSpacePattern.ReplaceAllString(strings.ToLower(strings.TrimSpace(name)), "-")
+}
+
+// IsValidTagName reports whether a normalized tag name is allowed.
+func IsValidTagName(name string) bool {
+	return tagNamePattern.MatchString(name)
+}
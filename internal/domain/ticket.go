@@ -14,6 +14,16 @@ const (
 	TicketStatusCancelled   TicketStatus = "CANCELLED"
 )
 
+// IsValid reports whether the status is one of the built-in lifecycle states.
+func (s TicketStatus) IsValid() bool {
+	switch s {
+	case TicketStatusOpen, TicketStatusInProgress, TicketStatusPendingUser,
+		TicketStatusResolved, TicketStatusClosed, TicketStatusCancelled:
+		return true
+	}
+	return false
+}
+
 // TicketPriority enumerates SLA urgency.
 type TicketPriority string
 
@@ -26,18 +36,31 @@ const (
 
 // Ticket is the aggregate for support requests.
 type Ticket struct {
-	ID           string
-	ExternalKey  string
-	RequesterID  string
-	DepartmentID string
-	TeamID       *string
-	AssigneeID   *string
-	Title        string
-	Description  string
-	Status       TicketStatus
-	Priority     TicketPriority
-	Tags         []string
-	CreatedAt    time.Time
-	UpdatedAt    time.Time
-	ClosedAt     *time.Time
+	ID             string
+	ExternalKey    string
+	RequesterID    string
+	DepartmentID   string
+	TeamID         *string
+	AssigneeID     *string
+	Title          string
+	Description    string
+	Status         TicketStatus
+	WorkflowStatus *string
+	Priority       TicketPriority
+	Tags           []string
+	CustomFields   map[string]any
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+	ClosedAt       *time.Time
+	SLADueAt       *time.Time
+	// MergedIntoID points at the ticket this duplicate was folded into.
+	MergedIntoID *string
+}
+
+// TicketSearchHit is a ticket matched by full-text search with its relevance and highlighted
+// excerpt. Snippet is HTML-escaped text with matches wrapped in <mark> tags.
+type TicketSearchHit struct {
+	Ticket  Ticket
+	Rank    float32
+	Snippet string
 }
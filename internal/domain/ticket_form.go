@@ -0,0 +1,48 @@
+package domain
+
+import "time"
+
+// Built-in ticket fields a form may place alongside custom fields.
+const (
+	FormFieldTitle       = "title"
+	FormFieldDescription = "description"
+	FormFieldPriority    = "priority"
+	FormFieldTags        = "tags"
+	FormFieldTeam        = "team_id"
+)
+
+// FormCondition shows a form field only when another field holds one of the listed values.
+type FormCondition struct {
+	Field  string   `json:"field"`
+	Values []string `json:"values"`
+}
+
+// TicketFormField places a built-in or custom field on a form.
+type TicketFormField struct {
+	Key         string         `json:"key"`
+	Label       string         `json:"label,omitempty"`
+	HelpText    string         `json:"help_text,omitempty"`
+	Required    bool           `json:"required"`
+	VisibleWhen *FormCondition `json:"visible_when,omitempty"`
+}
+
+// TicketForm is the ordered set of fields requesters fill in when opening a ticket in a department.
+type TicketForm struct {
+	ID           string
+	DepartmentID string
+	Name         string
+	Fields       []TicketFormField
+	IsActive     bool
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+// IsBuiltinFormField reports whether key names a core ticket attribute rather than a custom field.
+func IsBuiltinFormField(key string) bool {
+	switch key {
+	case FormFieldTitle, FormFieldDescription, FormFieldPriority, FormFieldTags, FormFieldTeam:
+		return true
+	default:
+		return false
+	}
+}
@@ -6,12 +6,19 @@ import "time"
 type TicketChangeType string
 
 const (
-	ChangeTypeStatus     TicketChangeType = "STATUS_CHANGE"
-	ChangeTypeAssignee   TicketChangeType = "ASSIGNEE_CHANGE"
-	ChangeTypePriority   TicketChangeType = "PRIORITY_CHANGE"
-	ChangeTypeTeam       TicketChangeType = "TEAM_CHANGE"
-	ChangeTypeDepartment TicketChangeType = "DEPARTMENT_CHANGE"
-	ChangeTypeTags       TicketChangeType = "TAGS_CHANGE"
+	ChangeTypeStatus        TicketChangeType = "STATUS_CHANGE"
+	ChangeTypeAssignee      TicketChangeType = "ASSIGNEE_CHANGE"
+	ChangeTypePriority      TicketChangeType = "PRIORITY_CHANGE"
+	ChangeTypeTeam          TicketChangeType = "TEAM_CHANGE"
+	ChangeTypeDepartment    TicketChangeType = "DEPARTMENT_CHANGE"
+	ChangeTypeTags          TicketChangeType = "TAGS_CHANGE"
+	ChangeTypeCustomFields  TicketChangeType = "CUSTOM_FIELDS_CHANGE"
+	ChangeTypeMerge         TicketChangeType = "MERGE"
+	ChangeTypeSplit         TicketChangeType = "SPLIT"
+	ChangeTypeLink          TicketChangeType = "LINK"
+	ChangeTypeFollowUp      TicketChangeType = "FOLLOW_UP"
+	ChangeTypeMessageEdit   TicketChangeType = "MESSAGE_EDIT"
+	ChangeTypeMessageRedact TicketChangeType = "MESSAGE_REDACT"
 )
 
 // TicketHistory is an immutable audit trail entry.
@@ -20,8 +27,10 @@ type TicketHistory struct {
 	TicketID      string
 	ChangedByType MessageAuthorType
 	ChangedByID   *string
-	ChangeType    TicketChangeType
-	OldValue      map[string]any
-	NewValue      map[string]any
-	CreatedAt     time.Time
+	// ChangedBy is resolved when history is loaded for display.
+	ChangedBy  AuthorProfile
+	ChangeType TicketChangeType
+	OldValue   map[string]any
+	NewValue   map[string]any
+	CreatedAt  time.Time
 }
@@ -0,0 +1,79 @@
+package domain
+
+import "time"
+
+// TicketLinkType names a typed relationship between two tickets. Links are stored in one
+// canonical direction; the inverse types are only used when describing a link from the other side.
+type TicketLinkType string
+
+const (
+	TicketLinkParentOf     TicketLinkType = "PARENT_OF"
+	TicketLinkChildOf      TicketLinkType = "CHILD_OF"
+	TicketLinkBlocks       TicketLinkType = "BLOCKS"
+	TicketLinkBlockedBy    TicketLinkType = "BLOCKED_BY"
+	TicketLinkRelated      TicketLinkType = "RELATED"
+	TicketLinkDuplicateOf  TicketLinkType = "DUPLICATE_OF"
+	TicketLinkDuplicatedBy TicketLinkType = "DUPLICATED_BY"
+)
+
+// IsValid reports whether the link type is known, in either direction.
+func (t TicketLinkType) IsValid() bool {
+	switch t {
+	case TicketLinkParentOf, TicketLinkChildOf, TicketLinkBlocks, TicketLinkBlockedBy,
+		TicketLinkRelated, TicketLinkDuplicateOf, TicketLinkDuplicatedBy:
+		return true
+	}
+	return false
+}
+
+// Inverse returns the type as seen from the other ticket.
+func (t TicketLinkType) Inverse() TicketLinkType {
+	switch t {
+	case TicketLinkParentOf:
+		return TicketLinkChildOf
+	case TicketLinkChildOf:
+		return TicketLinkParentOf
+	case TicketLinkBlocks:
+		return TicketLinkBlockedBy
+	case TicketLinkBlockedBy:
+		return TicketLinkBlocks
+	case TicketLinkDuplicateOf:
+		return TicketLinkDuplicatedBy
+	case TicketLinkDuplicatedBy:
+		return TicketLinkDuplicateOf
+	}
+	return t
+}
+
+// Canonical reports the stored type and whether source and target must be swapped to store it.
+func (t TicketLinkType) Canonical() (TicketLinkType, bool) {
+	switch t {
+	case TicketLinkChildOf, TicketLinkBlockedBy, TicketLinkDuplicatedBy:
+		return t.Inverse(), true
+	}
+	return t, false
+}
+
+// TicketLink relates a source ticket to a target ticket.
+type TicketLink struct {
+	ID             string
+	SourceTicketID string
+	TargetTicketID string
+	LinkType       TicketLinkType
+	// Propagate copies status changes and public replies from a parent to this child.
+	// Only meaningful on PARENT_OF links.
+	Propagate        bool
+	CreatedByStaffID *string
+	CreatedAt        time.Time
+}
+
+// LinkedTicket is a link described from one ticket's side, with a summary of the other ticket.
+type LinkedTicket struct {
+	Link        TicketLink
+	Type        TicketLinkType
+	TicketID    string
+	ExternalKey string
+	Title       string
+	Status      TicketStatus
+	Priority    TicketPriority
+}
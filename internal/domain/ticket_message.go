@@ -20,25 +20,57 @@ const (
 	MessageTypeSystemEvent  TicketMessageType = "SYSTEM_EVENT"
 )
 
+// MessageBodyFormat is how a message body is written and rendered.
+type MessageBodyFormat string
+
+const (
+	BodyFormatText     MessageBodyFormat = "text"
+	BodyFormatMarkdown MessageBodyFormat = "markdown"
+	BodyFormatHTML     MessageBodyFormat = "html"
+)
+
+// IsValid reports whether the format is supported.
+func (f MessageBodyFormat) IsValid() bool {
+	switch f {
+	case BodyFormatText, BodyFormatMarkdown, BodyFormatHTML:
+		return true
+	}
+	return false
+}
+
 // TicketMessage captures communications in a ticket thread.
 type TicketMessage struct {
-	ID          string
-	TicketID    string
-	AuthorType  MessageAuthorType
-	AuthorID    *string
+	ID         string
+	TicketID   string
+	AuthorType MessageAuthorType
+	AuthorID   *string
+	// Author is resolved when messages are loaded for display.
+	Author      AuthorProfile
 	MessageType TicketMessageType
 	Body        string
+	// BodyFormat defaults to text; HTML bodies are stored already sanitized.
+	BodyFormat  MessageBodyFormat
 	Attachments []AttachmentReference
 	CreatedAt   time.Time
+	// EditedAt is set when the author last edited the body; RedactedAt when an admin last redacted it.
+	EditedAt   *time.Time
+	RedactedAt *time.Time
+	// CopiedFromID is the parent ticket reply this message was propagated from.
+	CopiedFromID *string
 }
 
-// AttachmentReference stores metadata for ticket message attachments.
+// AttachmentReference stores metadata for ticket message attachments. ScanStatus mirrors the
+// scan of the upload sharing its StorageKey.
 type AttachmentReference struct {
-	ID              string
-	TicketMessageID string
-	StorageKey      string
-	FileName        string
-	MimeType        string
-	SizeBytes       int64
-	CreatedAt       time.Time
+	ID              string               `json:"id"`
+	TicketMessageID string               `json:"ticket_message_id"`
+	StorageKey      string               `json:"storage_key"`
+	FileName        string               `json:"file_name"`
+	MimeType        string               `json:"mime_type"`
+	SizeBytes       int64                `json:"size_bytes"`
+	ScanStatus      AttachmentScanStatus `json:"scan_status"`
+	CreatedAt       time.Time            `json:"created_at"`
+	// DownloadURL and ThumbnailURL are signed links issued to the caller; they are never stored.
+	DownloadURL  string `json:"-"`
+	ThumbnailURL string `json:"-"`
 }
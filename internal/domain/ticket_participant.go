@@ -0,0 +1,26 @@
+package domain
+
+import "time"
+
+// ParticipantRole describes how a participant is involved in a ticket beyond requester and assignee.
+type ParticipantRole string
+
+const (
+	// ParticipantRoleWatcher is a staff member following the ticket.
+	ParticipantRoleWatcher ParticipantRole = "WATCHER"
+	// ParticipantRoleCC is a user account or external email copied on public replies.
+	ParticipantRoleCC ParticipantRole = "CC"
+)
+
+// TicketParticipant is a watcher or CC on a ticket. Exactly one of StaffID, UserID and Email is set.
+type TicketParticipant struct {
+	ID          string
+	TicketID    string
+	Role        ParticipantRole
+	StaffID     *string
+	UserID      *string
+	Email       *string
+	AddedByType MessageAuthorType
+	AddedByID   *string
+	CreatedAt   time.Time
+}
@@ -0,0 +1,106 @@
+package domain
+
+import "time"
+
+// StatusCategory groups workflow statuses by where the ticket sits in its lifecycle.
+type StatusCategory string
+
+const (
+	StatusCategoryOpen    StatusCategory = "OPEN"
+	StatusCategoryPending StatusCategory = "PENDING"
+	StatusCategorySolved  StatusCategory = "SOLVED"
+	StatusCategoryClosed  StatusCategory = "CLOSED"
+)
+
+// WorkflowTransitionAny matches every source status in a transition.
+const WorkflowTransitionAny = "*"
+
+// Ticket fields a transition may require to be populated.
+const (
+	WorkflowFieldAssignee = "assignee_staff_id"
+	WorkflowFieldTeam     = "team_id"
+	WorkflowFieldTags     = "tags"
+)
+
+// WorkflowStatus is an admin-defined status backed by one of the built-in ticket statuses.
+type WorkflowStatus struct {
+	Key          string         `json:"key"`
+	Name         string         `json:"name"`
+	Category     StatusCategory `json:"category"`
+	SystemStatus TicketStatus   `json:"system_status"`
+}
+
+// WorkflowTransition allows moving from one workflow status to another.
+type WorkflowTransition struct {
+	From           string   `json:"from"`
+	To             string   `json:"to"`
+	RequireComment bool     `json:"require_comment"`
+	RequiredFields []string `json:"required_fields,omitempty"`
+}
+
+// Workflow is the per-department status model used instead of the default transitions.
+type Workflow struct {
+	ID            string
+	DepartmentID  string
+	Name          string
+	InitialStatus string
+	Statuses      []WorkflowStatus
+	Transitions   []WorkflowTransition
+	IsActive      bool
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+// CategoryForStatus returns the category a built-in status belongs to.
+func CategoryForStatus(status TicketStatus) StatusCategory {
+	switch status {
+	case TicketStatusPendingUser:
+		return StatusCategoryPending
+	case TicketStatusResolved:
+		return StatusCategorySolved
+	case TicketStatusClosed, TicketStatusCancelled:
+		return StatusCategoryClosed
+	default:
+		return StatusCategoryOpen
+	}
+}
+
+// Status returns the workflow status with the given key.
+func (w *Workflow) Status(key string) (WorkflowStatus, bool) {
+	for _, status := range w.Statuses {
+		if status.Key == key {
+			return status, true
+		}
+	}
+	return WorkflowStatus{}, false
+}
+
+// StatusFor returns the first workflow status backed by the given built-in status.
+func (w *Workflow) StatusFor(system TicketStatus) (WorkflowStatus, bool) {
+	for _, status := range w.Statuses {
+		if status.SystemStatus == system {
+			return status, true
+		}
+	}
+	return WorkflowStatus{}, false
+}
+
+// Transition returns the transition between two status keys; an exact source wins over a wildcard.
+func (w *Workflow) Transition(from, to string) (WorkflowTransition, bool) {
+	var wildcard *WorkflowTransition
+	for i, transition := range w.Transitions {
+		if transition.To != to {
+			continue
+		}
+		if transition.From == from {
+			return transition, true
+		}
+		if transition.From == WorkflowTransitionAny && wildcard == nil {
+			wildcard = &w.Transitions[i]
+		}
+	}
+	if wildcard != nil {
+		return *wildcard, true
+	}
+	return WorkflowTransition{}, false
+}
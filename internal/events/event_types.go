@@ -10,11 +10,17 @@ import (
 type EventType string
 
 const (
-	EventTicketCreated         EventType = "ticket_created"
-	EventTicketStatusChanged   EventType = "ticket_status_changed"
-	EventTicketPriorityChanged EventType = "ticket_priority_changed"
-	EventTicketAssigned        EventType = "ticket_assigned"
-	EventTicketMessageAdded    EventType = "ticket_message_added"
+	EventTicketCreated          EventType = "ticket_created"
+	EventTicketStatusChanged    EventType = "ticket_status_changed"
+	EventTicketPriorityChanged  EventType = "ticket_priority_changed"
+	EventTicketAssigned         EventType = "ticket_assigned"
+	EventTicketMessageAdded     EventType = "ticket_message_added"
+	EventTicketEscalated        EventType = "ticket_escalated"
+	EventTicketAutoClosePending EventType = "ticket_auto_close_pending"
+	EventTicketMerged           EventType = "ticket_merged"
+	EventTicketFollowUpOpened   EventType = "ticket_follow_up_opened"
+	EventTicketStaffMentioned   EventType = "ticket_staff_mentioned"
+	EventAttachmentInfected     EventType = "attachment_infected"
 )
 
 // Actor encapsulates actor metadata for an event.
@@ -69,3 +75,54 @@ type TicketMessageAddedPayload struct {
 	AuthorID    *string                  `json:"author_id,omitempty"`
 	BodyPreview string                   `json:"body_preview"`
 }
+
+// TicketEscalatedPayload payload.
+type TicketEscalatedPayload struct {
+	RuleID          string                     `json:"rule_id"`
+	RuleName        string                     `json:"rule_name"`
+	Condition       domain.EscalationCondition `json:"condition"`
+	Action          domain.EscalationAction    `json:"action"`
+	Priority        domain.TicketPriority      `json:"priority"`
+	AssigneeStaffID *string                    `json:"assignee_staff_id,omitempty"`
+	// ManagerStaffID is the lead or admin alerted by a NOTIFY_MANAGER rule.
+	ManagerStaffID *string `json:"manager_staff_id,omitempty"`
+}
+
+// TicketAutoClosePendingPayload payload.
+type TicketAutoClosePendingPayload struct {
+	Status  domain.TicketStatus `json:"status"`
+	CloseAt time.Time           `json:"close_at"`
+}
+
+// TicketMergedPayload is published on each source ticket folded into a target.
+type TicketMergedPayload struct {
+	TargetTicketID    string `json:"target_ticket_id"`
+	TargetExternalKey string `json:"target_external_key"`
+	RequesterUserID   string `json:"requester_user_id"`
+	MessagesMoved     int    `json:"messages_moved"`
+}
+
+// TicketFollowUpOpenedPayload is published on a closed ticket when a requester reply opens a follow-up.
+type TicketFollowUpOpenedPayload struct {
+	FollowUpTicketID    string `json:"follow_up_ticket_id"`
+	FollowUpExternalKey string `json:"follow_up_external_key"`
+}
+
+// TicketStaffMentionedPayload is published once per staff member mentioned in an internal note.
+type TicketStaffMentionedPayload struct {
+	MentionID        string `json:"mention_id"`
+	MessageID        string `json:"message_id"`
+	MentionedStaffID string `json:"mentioned_staff_id"`
+	BodyPreview      string `json:"body_preview"`
+}
+
+// AttachmentInfectedPayload is published when a malware scan quarantines an upload.
+type AttachmentInfectedPayload struct {
+	UploadID      string                   `json:"upload_id"`
+	FileName      string                   `json:"file_name"`
+	Signature     string                   `json:"signature"`
+	UploaderType  domain.MessageAuthorType `json:"uploader_type"`
+	UploaderID    string                   `json:"uploader_id"`
+	MessageID     *string                  `json:"message_id,omitempty"`
+	NoteMessageID string                   `json:"note_message_id"`
+}
@@ -3,6 +3,7 @@ package repository
 import (
 	"context"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 
 	"github.com/spec-kit/ticket-service/internal/domain"
@@ -12,8 +13,18 @@ import (
 type AttachmentRepository interface {
 	Create(ctx context.Context, attachment *domain.AttachmentReference) error
 	ListByMessage(ctx context.Context, messageID string) ([]domain.AttachmentReference, error)
+	// ListByMessages loads the attachments of many messages in one query.
+	ListByMessages(ctx context.Context, messageIDs []string) ([]domain.AttachmentReference, error)
+	GetByID(ctx context.Context, id string) (*domain.AttachmentReference, error)
+	Delete(ctx context.Context, id string) error
+	// CountByStorageKey reports how many references share an object, e.g. after reply propagation.
+	CountByStorageKey(ctx context.Context, storageKey string) (int, error)
+	// SyncScanStatus copies finished upload scans onto references still marked PENDING.
+	SyncScanStatus(ctx context.Context) (int64, error)
 }
 
+const attachmentColumns = `id, ticket_message_id, storage_key, file_name, mime_type, size_bytes, scan_status, created_at`
+
 type attachmentRepository struct {
 	pool *pgxpool.Pool
 }
@@ -23,25 +34,47 @@ func NewAttachmentRepository(pool *pgxpool.Pool) AttachmentRepository {
 	return &attachmentRepository{pool: pool}
 }
 
+// Create inherits the scan status of the upload that stored the bytes.
 func (r *attachmentRepository) Create(ctx context.Context, attachment *domain.AttachmentReference) error {
 	const query = `
-        INSERT INTO attachment_references (ticket_message_id, storage_key, file_name, mime_type, size_bytes)
-        VALUES ($1,$2,$3,$4,$5)
-        RETURNING id, created_at`
-	return r.pool.QueryRow(ctx, query,
+        INSERT INTO attachment_references (ticket_message_id, storage_key, file_name, mime_type, size_bytes, scan_status)
+        VALUES ($1,$2,$3,$4,$5, COALESCE((SELECT scan_status FROM attachment_uploads WHERE storage_key=$2), 'PENDING'))
+        RETURNING id, scan_status, created_at`
+	return conn(ctx, r.pool).QueryRow(ctx, query,
 		attachment.TicketMessageID,
 		attachment.StorageKey,
 		attachment.FileName,
 		attachment.MimeType,
 		attachment.SizeBytes,
-	).Scan(&attachment.ID, &attachment.CreatedAt)
+	).Scan(&attachment.ID, &attachment.ScanStatus, &attachment.CreatedAt)
 }
 
 func (r *attachmentRepository) ListByMessage(ctx context.Context, messageID string) ([]domain.AttachmentReference, error) {
-	const query = `
-        SELECT id, ticket_message_id, storage_key, file_name, mime_type, size_bytes, created_at
-        FROM attachment_references WHERE ticket_message_id=$1`
-	rows, err := r.pool.Query(ctx, query, messageID)
+	query := `SELECT ` + attachmentColumns + ` FROM attachment_references WHERE ticket_message_id=$1`
+	rows, err := conn(ctx, r.pool).Query(ctx, query, messageID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []domain.AttachmentReference
+	for rows.Next() {
+		var attachment domain.AttachmentReference
+		if err := scanAttachment(rows, &attachment); err != nil {
+			return nil, err
+		}
+		result = append(result, attachment)
+	}
+	return result, rows.Err()
+}
+
+func (r *attachmentRepository) ListByMessages(ctx context.Context, messageIDs []string) ([]domain.AttachmentReference, error) {
+	if len(messageIDs) == 0 {
+		return nil, nil
+	}
+	query := `SELECT ` + attachmentColumns + ` FROM attachment_references
+        WHERE ticket_message_id = ANY($1::uuid[]) ORDER BY created_at, id`
+	rows, err := conn(ctx, r.pool).Query(ctx, query, messageIDs)
 	if err != nil {
 		return nil, err
 	}
@@ -50,18 +83,61 @@ func (r *attachmentRepository) ListByMessage(ctx context.Context, messageID stri
 	var result []domain.AttachmentReference
 	for rows.Next() {
 		var attachment domain.AttachmentReference
-		if err := rows.Scan(
-			&attachment.ID,
-			&attachment.TicketMessageID,
-			&attachment.StorageKey,
-			&attachment.FileName,
-			&attachment.MimeType,
-			&attachment.SizeBytes,
-			&attachment.CreatedAt,
-		); err != nil {
+		if err := scanAttachment(rows, &attachment); err != nil {
 			return nil, err
 		}
 		result = append(result, attachment)
 	}
 	return result, rows.Err()
 }
+
+func (r *attachmentRepository) GetByID(ctx context.Context, id string) (*domain.AttachmentReference, error) {
+	query := `SELECT ` + attachmentColumns + ` FROM attachment_references WHERE id=$1`
+	var attachment domain.AttachmentReference
+	if err := scanAttachment(conn(ctx, r.pool).QueryRow(ctx, query, id), &attachment); err != nil {
+		return nil, err
+	}
+	return &attachment, nil
+}
+
+func (r *attachmentRepository) Delete(ctx context.Context, id string) error {
+	cmd, err := conn(ctx, r.pool).Exec(ctx, `DELETE FROM attachment_references WHERE id=$1`, id)
+	if err != nil {
+		return err
+	}
+	if cmd.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+	return nil
+}
+
+func (r *attachmentRepository) CountByStorageKey(ctx context.Context, storageKey string) (int, error) {
+	var count int
+	err := conn(ctx, r.pool).QueryRow(ctx, `SELECT COUNT(*) FROM attachment_references WHERE storage_key=$1`, storageKey).Scan(&count)
+	return count, err
+}
+
+func (r *attachmentRepository) SyncScanStatus(ctx context.Context) (int64, error) {
+	const query = `
+        UPDATE attachment_references r SET scan_status = u.scan_status
+        FROM attachment_uploads u
+        WHERE r.storage_key = u.storage_key AND r.scan_status = 'PENDING' AND u.scan_status <> 'PENDING'`
+	cmd, err := conn(ctx, r.pool).Exec(ctx, query)
+	if err != nil {
+		return 0, err
+	}
+	return cmd.RowsAffected(), nil
+}
+
+func scanAttachment(row pgx.Row, attachment *domain.AttachmentReference) error {
+	return row.Scan(
+		&attachment.ID,
+		&attachment.TicketMessageID,
+		&attachment.StorageKey,
+		&attachment.FileName,
+		&attachment.MimeType,
+		&attachment.SizeBytes,
+		&attachment.ScanStatus,
+		&attachment.CreatedAt,
+	)
+}
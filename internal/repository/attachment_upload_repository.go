@@ -0,0 +1,139 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/spec-kit/ticket-service/internal/domain"
+)
+
+// AttachmentUploadRepository tracks uploaded files waiting to be attached to a message.
+type AttachmentUploadRepository interface {
+	Create(ctx context.Context, upload *domain.AttachmentUpload) error
+	// ListPending returns the unattached uploads on a ticket matching the storage keys.
+	ListPending(ctx context.Context, ticketID string, storageKeys []string) ([]domain.AttachmentUpload, error)
+	// MarkAttached links uploads to the message they were posted with.
+	MarkAttached(ctx context.Context, ids []string, messageID string) error
+	// ListPendingScan returns the oldest uploads still waiting for a malware scan.
+	ListPendingScan(ctx context.Context, limit int) ([]domain.AttachmentUpload, error)
+	// RecordScanResult stores a finished scan; signature is set for infected files.
+	RecordScanResult(ctx context.Context, id string, status domain.AttachmentScanStatus, signature *string) error
+	// RecordScanAttempt counts a scan that could not complete and returns the attempts so far.
+	RecordScanAttempt(ctx context.Context, id string) (int, error)
+}
+
+const attachmentUploadColumns = `id, ticket_id, storage_key, file_name, mime_type, size_bytes, uploader_type, uploader_id, message_id, scan_status, scan_signature, scan_attempts, scanned_at, created_at`
+
+type attachmentUploadRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewAttachmentUploadRepository constructs repository.
+func NewAttachmentUploadRepository(pool *pgxpool.Pool) AttachmentUploadRepository {
+	return &attachmentUploadRepository{pool: pool}
+}
+
+func (r *attachmentUploadRepository) Create(ctx context.Context, upload *domain.AttachmentUpload) error {
+	const query = `
+        INSERT INTO attachment_uploads (ticket_id, storage_key, file_name, mime_type, size_bytes, uploader_type, uploader_id, scan_status)
+        VALUES ($1,$2,$3,$4,$5,$6,$7,$8)
+        RETURNING id, created_at`
+	return conn(ctx, r.pool).QueryRow(ctx, query,
+		upload.TicketID,
+		upload.StorageKey,
+		upload.FileName,
+		upload.MimeType,
+		upload.SizeBytes,
+		upload.UploaderType,
+		upload.UploaderID,
+		upload.ScanStatus,
+	).Scan(&upload.ID, &upload.CreatedAt)
+}
+
+func (r *attachmentUploadRepository) ListPending(ctx context.Context, ticketID string, storageKeys []string) ([]domain.AttachmentUpload, error) {
+	query := `SELECT ` + attachmentUploadColumns + ` FROM attachment_uploads
+        WHERE ticket_id=$1 AND storage_key = ANY($2) AND message_id IS NULL`
+	rows, err := conn(ctx, r.pool).Query(ctx, query, ticketID, storageKeys)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []domain.AttachmentUpload
+	for rows.Next() {
+		var upload domain.AttachmentUpload
+		if err := scanAttachmentUpload(rows, &upload); err != nil {
+			return nil, err
+		}
+		result = append(result, upload)
+	}
+	return result, rows.Err()
+}
+
+func (r *attachmentUploadRepository) MarkAttached(ctx context.Context, ids []string, messageID string) error {
+	_, err := conn(ctx, r.pool).Exec(ctx, `UPDATE attachment_uploads SET message_id=$1 WHERE id = ANY($2::uuid[])`, messageID, ids)
+	return err
+}
+
+func (r *attachmentUploadRepository) ListPendingScan(ctx context.Context, limit int) ([]domain.AttachmentUpload, error) {
+	query := `SELECT ` + attachmentUploadColumns + ` FROM attachment_uploads
+        WHERE scan_status = 'PENDING' ORDER BY created_at LIMIT $1`
+	rows, err := conn(ctx, r.pool).Query(ctx, query, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []domain.AttachmentUpload
+	for rows.Next() {
+		var upload domain.AttachmentUpload
+		if err := scanAttachmentUpload(rows, &upload); err != nil {
+			return nil, err
+		}
+		result = append(result, upload)
+	}
+	return result, rows.Err()
+}
+
+func (r *attachmentUploadRepository) RecordScanResult(ctx context.Context, id string, status domain.AttachmentScanStatus, signature *string) error {
+	const query = `
+        UPDATE attachment_uploads SET scan_status=$1, scan_signature=$2, scanned_at=NOW()
+        WHERE id=$3`
+	cmd, err := conn(ctx, r.pool).Exec(ctx, query, status, signature, id)
+	if err != nil {
+		return err
+	}
+	if cmd.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+	return nil
+}
+
+func (r *attachmentUploadRepository) RecordScanAttempt(ctx context.Context, id string) (int, error) {
+	var attempts int
+	err := conn(ctx, r.pool).QueryRow(ctx,
+		`UPDATE attachment_uploads SET scan_attempts = scan_attempts + 1 WHERE id=$1 RETURNING scan_attempts`, id,
+	).Scan(&attempts)
+	return attempts, err
+}
+
+func scanAttachmentUpload(row pgx.Row, upload *domain.AttachmentUpload) error {
+	return row.Scan(
+		&upload.ID,
+		&upload.TicketID,
+		&upload.StorageKey,
+		&upload.FileName,
+		&upload.MimeType,
+		&upload.SizeBytes,
+		&upload.UploaderType,
+		&upload.UploaderID,
+		&upload.MessageID,
+		&upload.ScanStatus,
+		&upload.ScanSignature,
+		&upload.ScanAttempts,
+		&upload.ScannedAt,
+		&upload.CreatedAt,
+	)
+}
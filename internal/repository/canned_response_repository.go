@@ -0,0 +1,110 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/spec-kit/ticket-service/internal/domain"
+)
+
+// CannedResponseRepository persists personal and team-shared canned responses.
+type CannedResponseRepository interface {
+	Create(ctx context.Context, response *domain.CannedResponse) error
+	Update(ctx context.Context, response *domain.CannedResponse) error
+	GetByID(ctx context.Context, id string) (*domain.CannedResponse, error)
+	Delete(ctx context.Context, id string) error
+	// ListVisible returns the staff member's own responses plus responses shared with their team.
+	ListVisible(ctx context.Context, staffID string, teamID *string) ([]domain.CannedResponse, error)
+}
+
+const cannedResponseColumns = `id, owner_staff_id, team_id, name, body, created_at, updated_at`
+
+type cannedResponseRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewCannedResponseRepository constructs repository.
+func NewCannedResponseRepository(pool *pgxpool.Pool) CannedResponseRepository {
+	return &cannedResponseRepository{pool: pool}
+}
+
+func (r *cannedResponseRepository) Create(ctx context.Context, response *domain.CannedResponse) error {
+	const query = `
+        INSERT INTO canned_responses (owner_staff_id, team_id, name, body)
+        VALUES ($1,$2,$3,$4)
+        RETURNING id, created_at, updated_at`
+	return conn(ctx, r.pool).QueryRow(ctx, query,
+		response.OwnerStaffID,
+		response.TeamID,
+		response.Name,
+		response.Body,
+	).Scan(&response.ID, &response.CreatedAt, &response.UpdatedAt)
+}
+
+func (r *cannedResponseRepository) Update(ctx context.Context, response *domain.CannedResponse) error {
+	const query = `
+        UPDATE canned_responses SET team_id=$1, name=$2, body=$3, updated_at=NOW()
+        WHERE id=$4
+        RETURNING updated_at`
+	return conn(ctx, r.pool).QueryRow(ctx, query,
+		response.TeamID,
+		response.Name,
+		response.Body,
+		response.ID,
+	).Scan(&response.UpdatedAt)
+}
+
+func (r *cannedResponseRepository) GetByID(ctx context.Context, id string) (*domain.CannedResponse, error) {
+	query := `SELECT ` + cannedResponseColumns + ` FROM canned_responses WHERE id=$1`
+	var response domain.CannedResponse
+	if err := scanCannedResponse(conn(ctx, r.pool).QueryRow(ctx, query, id), &response); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+func (r *cannedResponseRepository) Delete(ctx context.Context, id string) error {
+	cmd, err := conn(ctx, r.pool).Exec(ctx, `DELETE FROM canned_responses WHERE id=$1`, id)
+	if err != nil {
+		return err
+	}
+	if cmd.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+	return nil
+}
+
+func (r *cannedResponseRepository) ListVisible(ctx context.Context, staffID string, teamID *string) ([]domain.CannedResponse, error) {
+	query := `SELECT ` + cannedResponseColumns + ` FROM canned_responses
+        WHERE owner_staff_id=$1 OR (team_id IS NOT NULL AND team_id=$2)
+        ORDER BY (owner_staff_id=$1) DESC, name ASC, id ASC`
+	rows, err := conn(ctx, r.pool).Query(ctx, query, staffID, teamID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []domain.CannedResponse
+	for rows.Next() {
+		var response domain.CannedResponse
+		if err := scanCannedResponse(rows, &response); err != nil {
+			return nil, err
+		}
+		result = append(result, response)
+	}
+	return result, rows.Err()
+}
+
+func scanCannedResponse(row pgx.Row, response *domain.CannedResponse) error {
+	return row.Scan(
+		&response.ID,
+		&response.OwnerStaffID,
+		&response.TeamID,
+		&response.Name,
+		&response.Body,
+		&response.CreatedAt,
+		&response.UpdatedAt,
+	)
+}
@@ -0,0 +1,128 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/spec-kit/ticket-service/internal/domain"
+)
+
+// CustomFieldFilter narrows custom field definition listings.
+type CustomFieldFilter struct {
+	// DepartmentID limits results to fields that apply to the department, including global ones.
+	DepartmentID    *string
+	IncludeInactive bool
+}
+
+// CustomFieldRepository persists custom field definitions.
+type CustomFieldRepository interface {
+	Create(ctx context.Context, field *domain.CustomFieldDefinition) error
+	Update(ctx context.Context, field *domain.CustomFieldDefinition) error
+	GetByID(ctx context.Context, id string) (*domain.CustomFieldDefinition, error)
+	List(ctx context.Context, filter CustomFieldFilter) ([]domain.CustomFieldDefinition, error)
+}
+
+const customFieldColumns = `id, key, label, type, options, required, department_id, is_active, created_at, updated_at`
+
+type customFieldRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewCustomFieldRepository constructs repository.
+func NewCustomFieldRepository(pool *pgxpool.Pool) CustomFieldRepository {
+	return &customFieldRepository{pool: pool}
+}
+
+func (r *customFieldRepository) Create(ctx context.Context, field *domain.CustomFieldDefinition) error {
+	const query = `
+        INSERT INTO custom_field_definitions (key, label, type, options, required, department_id, is_active)
+        VALUES ($1,$2,$3,$4,$5,$6,$7)
+        RETURNING id, created_at, updated_at`
+	return conn(ctx, r.pool).QueryRow(ctx, query,
+		field.Key,
+		field.Label,
+		field.Type,
+		field.Options,
+		field.Required,
+		field.DepartmentID,
+		field.IsActive,
+	).Scan(&field.ID, &field.CreatedAt, &field.UpdatedAt)
+}
+
+func (r *customFieldRepository) Update(ctx context.Context, field *domain.CustomFieldDefinition) error {
+	const query = `
+        UPDATE custom_field_definitions SET label=$1, options=$2, required=$3, department_id=$4, is_active=$5, updated_at=NOW()
+        WHERE id=$6`
+	cmd, err := conn(ctx, r.pool).Exec(ctx, query,
+		field.Label,
+		field.Options,
+		field.Required,
+		field.DepartmentID,
+		field.IsActive,
+		field.ID,
+	)
+	if err != nil {
+		return err
+	}
+	if cmd.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+	return nil
+}
+
+func (r *customFieldRepository) GetByID(ctx context.Context, id string) (*domain.CustomFieldDefinition, error) {
+	query := `SELECT ` + customFieldColumns + ` FROM custom_field_definitions WHERE id=$1`
+	var field domain.CustomFieldDefinition
+	if err := scanCustomField(conn(ctx, r.pool).QueryRow(ctx, query, id), &field); err != nil {
+		return nil, err
+	}
+	return &field, nil
+}
+
+func (r *customFieldRepository) List(ctx context.Context, filter CustomFieldFilter) ([]domain.CustomFieldDefinition, error) {
+	clauses := []string{"1=1"}
+	args := []any{}
+	if filter.DepartmentID != nil {
+		args = append(args, *filter.DepartmentID)
+		clauses = append(clauses, fmt.Sprintf("(department_id IS NULL OR department_id=$%d)", len(args)))
+	}
+	if !filter.IncludeInactive {
+		clauses = append(clauses, "is_active = TRUE")
+	}
+	query := fmt.Sprintf(`SELECT %s FROM custom_field_definitions WHERE %s ORDER BY key ASC`,
+		customFieldColumns, strings.Join(clauses, " AND "))
+	rows, err := conn(ctx, r.pool).Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []domain.CustomFieldDefinition
+	for rows.Next() {
+		var field domain.CustomFieldDefinition
+		if err := scanCustomField(rows, &field); err != nil {
+			return nil, err
+		}
+		result = append(result, field)
+	}
+	return result, rows.Err()
+}
+
+func scanCustomField(row pgx.Row, field *domain.CustomFieldDefinition) error {
+	return row.Scan(
+		&field.ID,
+		&field.Key,
+		&field.Label,
+		&field.Type,
+		&field.Options,
+		&field.Required,
+		&field.DepartmentID,
+		&field.IsActive,
+		&field.CreatedAt,
+		&field.UpdatedAt,
+	)
+}
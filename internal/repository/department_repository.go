@@ -28,24 +28,26 @@ func NewDepartmentRepository(pool *pgxpool.Pool) DepartmentRepository {
 
 func (r *departmentRepository) Create(ctx context.Context, dept *domain.Department) error {
 	const query = `
-        INSERT INTO departments (name, description, is_active)
-        VALUES ($1,$2,$3)
+        INSERT INTO departments (name, description, is_active, allowed_attachment_types)
+        VALUES ($1,$2,$3,COALESCE($4::text[], '{}'))
         RETURNING id, created_at, updated_at`
-	return r.pool.QueryRow(ctx, query,
+	return conn(ctx, r.pool).QueryRow(ctx, query,
 		dept.Name,
 		dept.Description,
 		dept.IsActive,
+		dept.AllowedAttachmentTypes,
 	).Scan(&dept.ID, &dept.CreatedAt, &dept.UpdatedAt)
 }
 
 func (r *departmentRepository) Update(ctx context.Context, dept *domain.Department) error {
 	const query = `
-        UPDATE departments SET name=$1, description=$2, is_active=$3, updated_at=NOW()
-        WHERE id=$4`
-	cmd, err := r.pool.Exec(ctx, query,
+        UPDATE departments SET name=$1, description=$2, is_active=$3, allowed_attachment_types=COALESCE($4::text[], '{}'), updated_at=NOW()
+        WHERE id=$5`
+	cmd, err := conn(ctx, r.pool).Exec(ctx, query,
 		dept.Name,
 		dept.Description,
 		dept.IsActive,
+		dept.AllowedAttachmentTypes,
 		dept.ID,
 	)
 	if err != nil {
@@ -59,14 +61,15 @@ func (r *departmentRepository) Update(ctx context.Context, dept *domain.Departme
 
 func (r *departmentRepository) GetByID(ctx context.Context, id string) (*domain.Department, error) {
 	const query = `
-        SELECT id, name, description, is_active, created_at, updated_at
+        SELECT id, name, description, is_active, allowed_attachment_types, created_at, updated_at
         FROM departments WHERE id=$1`
 	var dept domain.Department
-	if err := r.pool.QueryRow(ctx, query, id).Scan(
+	if err := conn(ctx, r.pool).QueryRow(ctx, query, id).Scan(
 		&dept.ID,
 		&dept.Name,
 		&dept.Description,
 		&dept.IsActive,
+		&dept.AllowedAttachmentTypes,
 		&dept.CreatedAt,
 		&dept.UpdatedAt,
 	); err != nil {
@@ -77,12 +80,12 @@ func (r *departmentRepository) GetByID(ctx context.Context, id string) (*domain.
 
 func (r *departmentRepository) List(ctx context.Context, includeInactive bool) ([]domain.Department, error) {
 	query := `
-        SELECT id, name, description, is_active, created_at, updated_at
+        SELECT id, name, description, is_active, allowed_attachment_types, created_at, updated_at
         FROM departments`
 	if !includeInactive {
 		query += " WHERE is_active = TRUE"
 	}
-	rows, err := r.pool.Query(ctx, query)
+	rows, err := conn(ctx, r.pool).Query(ctx, query)
 	if err != nil {
 		return nil, err
 	}
@@ -91,7 +94,7 @@ func (r *departmentRepository) List(ctx context.Context, includeInactive bool) (
 	var result []domain.Department
 	for rows.Next() {
 		var dept domain.Department
-		if err := rows.Scan(&dept.ID, &dept.Name, &dept.Description, &dept.IsActive, &dept.CreatedAt, &dept.UpdatedAt); err != nil {
+		if err := rows.Scan(&dept.ID, &dept.Name, &dept.Description, &dept.IsActive, &dept.AllowedAttachmentTypes, &dept.CreatedAt, &dept.UpdatedAt); err != nil {
 			return nil, err
 		}
 		result = append(result, dept)
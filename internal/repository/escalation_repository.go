@@ -0,0 +1,229 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/spec-kit/ticket-service/internal/domain"
+)
+
+// EscalationRepository persists escalation rules and the record of which rules fired for which tickets.
+type EscalationRepository interface {
+	CreateRule(ctx context.Context, rule *domain.EscalationRule) error
+	UpdateRule(ctx context.Context, rule *domain.EscalationRule) error
+	GetRule(ctx context.Context, id string) (*domain.EscalationRule, error)
+	ListRules(ctx context.Context, includeInactive bool) ([]domain.EscalationRule, error)
+	ListCandidates(ctx context.Context, rule *domain.EscalationRule, now time.Time, limit int) ([]domain.Ticket, error)
+	// LockCandidate re-reads one ticket with a row lock, returning nil when it no longer satisfies the rule.
+	LockCandidate(ctx context.Context, rule *domain.EscalationRule, ticketID string, now time.Time) (*domain.Ticket, error)
+	RecordFiring(ctx context.Context, ruleID, ticketID string) (bool, error)
+}
+
+type escalationRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewEscalationRepository constructs repository.
+func NewEscalationRepository(pool *pgxpool.Pool) EscalationRepository {
+	return &escalationRepository{pool: pool}
+}
+
+func (r *escalationRepository) CreateRule(ctx context.Context, rule *domain.EscalationRule) error {
+	const query = `
+        INSERT INTO escalation_rules (name, department_id, priorities, condition, threshold_minutes, action, is_active)
+        VALUES ($1,$2,$3,$4,$5,$6,$7)
+        RETURNING id, created_at, updated_at`
+	return conn(ctx, r.pool).QueryRow(ctx, query,
+		rule.Name,
+		rule.DepartmentID,
+		prioritiesToStrings(rule.Priorities),
+		rule.Condition,
+		rule.ThresholdMinutes,
+		rule.Action,
+		rule.IsActive,
+	).Scan(&rule.ID, &rule.CreatedAt, &rule.UpdatedAt)
+}
+
+func (r *escalationRepository) UpdateRule(ctx context.Context, rule *domain.EscalationRule) error {
+	const query = `
+        UPDATE escalation_rules SET name=$1, department_id=$2, priorities=$3, condition=$4,
+            threshold_minutes=$5, action=$6, is_active=$7, updated_at=NOW()
+        WHERE id=$8`
+	cmd, err := conn(ctx, r.pool).Exec(ctx, query,
+		rule.Name,
+		rule.DepartmentID,
+		prioritiesToStrings(rule.Priorities),
+		rule.Condition,
+		rule.ThresholdMinutes,
+		rule.Action,
+		rule.IsActive,
+		rule.ID,
+	)
+	if err != nil {
+		return err
+	}
+	if cmd.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+	return nil
+}
+
+func (r *escalationRepository) GetRule(ctx context.Context, id string) (*domain.EscalationRule, error) {
+	const query = `
+        SELECT id, name, department_id, priorities, condition, threshold_minutes, action, is_active, created_at, updated_at
+        FROM escalation_rules WHERE id=$1`
+	var rule domain.EscalationRule
+	if err := scanEscalationRule(conn(ctx, r.pool).QueryRow(ctx, query, id), &rule); err != nil {
+		return nil, err
+	}
+	return &rule, nil
+}
+
+func (r *escalationRepository) ListRules(ctx context.Context, includeInactive bool) ([]domain.EscalationRule, error) {
+	query := `
+        SELECT id, name, department_id, priorities, condition, threshold_minutes, action, is_active, created_at, updated_at
+        FROM escalation_rules`
+	if !includeInactive {
+		query += " WHERE is_active = TRUE"
+	}
+	query += " ORDER BY created_at ASC"
+	rows, err := conn(ctx, r.pool).Query(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []domain.EscalationRule
+	for rows.Next() {
+		var rule domain.EscalationRule
+		if err := scanEscalationRule(rows, &rule); err != nil {
+			return nil, err
+		}
+		result = append(result, rule)
+	}
+	return result, rows.Err()
+}
+
+// ListCandidates returns open tickets that currently satisfy the rule's condition and have not yet fired for it.
+func (r *escalationRepository) ListCandidates(ctx context.Context, rule *domain.EscalationRule, now time.Time, limit int) ([]domain.Ticket, error) {
+	clauses, args, err := candidateClauses(rule, now)
+	if err != nil {
+		return nil, err
+	}
+	if limit <= 0 {
+		limit = 100
+	}
+
+	query := fmt.Sprintf(`SELECT %s FROM tickets WHERE %s ORDER BY created_at ASC LIMIT %d`,
+		ticketColumns, strings.Join(clauses, " AND "), limit)
+	rows, err := conn(ctx, r.pool).Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return scanTickets(rows)
+}
+
+func (r *escalationRepository) LockCandidate(ctx context.Context, rule *domain.EscalationRule, ticketID string, now time.Time) (*domain.Ticket, error) {
+	clauses, args, err := candidateClauses(rule, now)
+	if err != nil {
+		return nil, err
+	}
+	args = append(args, ticketID)
+	clauses = append(clauses, fmt.Sprintf("id=$%d", len(args)))
+
+	query := fmt.Sprintf(`SELECT %s FROM tickets WHERE %s FOR UPDATE`, ticketColumns, strings.Join(clauses, " AND "))
+	var ticket domain.Ticket
+	if err := scanTicket(conn(ctx, r.pool).QueryRow(ctx, query, args...), &ticket); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &ticket, nil
+}
+
+// candidateClauses builds the WHERE clauses shared by ListCandidates and LockCandidate; $1 is the rule ID.
+func candidateClauses(rule *domain.EscalationRule, now time.Time) ([]string, []any, error) {
+	threshold := time.Duration(rule.ThresholdMinutes) * time.Minute
+	args := []any{rule.ID}
+	clauses := []string{
+		"status IN ('OPEN','IN_PROGRESS','PENDING_USER')",
+		"NOT EXISTS (SELECT 1 FROM escalation_firings f WHERE f.rule_id=$1 AND f.ticket_id=tickets.id)",
+	}
+
+	switch rule.Condition {
+	case domain.EscalationConditionUnassigned:
+		args = append(args, now.Add(-threshold))
+		clauses = append(clauses, "assignee_staff_id IS NULL", fmt.Sprintf("created_at <= $%d", len(args)))
+	case domain.EscalationConditionNoStaffReply:
+		args = append(args, now.Add(-threshold))
+		clauses = append(clauses, fmt.Sprintf(`COALESCE((SELECT MAX(m.created_at) FROM ticket_messages m
+            WHERE m.ticket_id=tickets.id AND m.author_type='STAFF' AND m.message_type='PUBLIC_REPLY'), created_at) <= $%d`, len(args)))
+	case domain.EscalationConditionSLABreach:
+		args = append(args, now.Add(threshold))
+		clauses = append(clauses, "sla_due_at IS NOT NULL", fmt.Sprintf("sla_due_at <= $%d", len(args)))
+	default:
+		return nil, nil, fmt.Errorf("unsupported escalation condition %q", rule.Condition)
+	}
+
+	if rule.DepartmentID != nil {
+		args = append(args, *rule.DepartmentID)
+		clauses = append(clauses, fmt.Sprintf("department_id=$%d", len(args)))
+	}
+	if len(rule.Priorities) > 0 {
+		args = append(args, prioritiesToStrings(rule.Priorities))
+		clauses = append(clauses, fmt.Sprintf("priority::text = ANY($%d)", len(args)))
+	}
+	return clauses, args, nil
+}
+
+// RecordFiring claims a rule/ticket pair, reporting false when it was already recorded.
+func (r *escalationRepository) RecordFiring(ctx context.Context, ruleID, ticketID string) (bool, error) {
+	const query = `
+        INSERT INTO escalation_firings (rule_id, ticket_id)
+        VALUES ($1,$2)
+        ON CONFLICT (rule_id, ticket_id) DO NOTHING`
+	cmd, err := conn(ctx, r.pool).Exec(ctx, query, ruleID, ticketID)
+	if err != nil {
+		return false, err
+	}
+	return cmd.RowsAffected() > 0, nil
+}
+
+func scanEscalationRule(row pgx.Row, rule *domain.EscalationRule) error {
+	var priorities []string
+	if err := row.Scan(
+		&rule.ID,
+		&rule.Name,
+		&rule.DepartmentID,
+		&priorities,
+		&rule.Condition,
+		&rule.ThresholdMinutes,
+		&rule.Action,
+		&rule.IsActive,
+		&rule.CreatedAt,
+		&rule.UpdatedAt,
+	); err != nil {
+		return err
+	}
+	rule.Priorities = make([]domain.TicketPriority, 0, len(priorities))
+	for _, p := range priorities {
+		rule.Priorities = append(rule.Priorities, domain.TicketPriority(p))
+	}
+	return nil
+}
+
+func prioritiesToStrings(priorities []domain.TicketPriority) []string {
+	result := make([]string, 0, len(priorities))
+	for _, p := range priorities {
+		result = append(result, string(p))
+	}
+	return result
+}
@@ -0,0 +1,70 @@
+package repository
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spec-kit/ticket-service/internal/domain"
+)
+
+func TestCandidateClauses(t *testing.T) {
+	now := time.Date(2026, 5, 6, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name     string
+		rule     domain.EscalationRule
+		want     []string
+		wantArgs []any
+	}{
+		{
+			name:     "unassigned since threshold",
+			rule:     domain.EscalationRule{ID: "r1", Condition: domain.EscalationConditionUnassigned, ThresholdMinutes: 30},
+			want:     []string{"assignee_staff_id IS NULL", "created_at <= $2"},
+			wantArgs: []any{"r1", now.Add(-30 * time.Minute)},
+		},
+		{
+			name:     "no staff reply, scoped to department",
+			rule:     domain.EscalationRule{ID: "r2", Condition: domain.EscalationConditionNoStaffReply, ThresholdMinutes: 60, DepartmentID: ptr("dept-1")},
+			want:     []string{"m.author_type='STAFF' AND m.message_type='PUBLIC_REPLY'), created_at) <= $2", "department_id=$3"},
+			wantArgs: []any{"r2", now.Add(-time.Hour), "dept-1"},
+		},
+		{
+			name: "SLA due within threshold, filtered by priority",
+			rule: domain.EscalationRule{
+				ID:               "r3",
+				Condition:        domain.EscalationConditionSLABreach,
+				ThresholdMinutes: 15,
+				Priorities:       []domain.TicketPriority{domain.TicketPriorityHigh, domain.TicketPriorityUrgent},
+			},
+			want:     []string{"sla_due_at IS NOT NULL", "sla_due_at <= $2", "priority::text = ANY($3)"},
+			wantArgs: []any{"r3", now.Add(15 * time.Minute), []string{"HIGH", "URGENT"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clauses, args, err := candidateClauses(&tt.rule, now)
+			if err != nil {
+				t.Fatalf("candidateClauses err = %v", err)
+			}
+			if len(clauses) != 2+len(tt.want) {
+				t.Fatalf("clauses = %q, want the shared clauses plus %q", clauses, tt.want)
+			}
+			if !strings.Contains(clauses[0], "'OPEN','IN_PROGRESS','PENDING_USER'") || !strings.Contains(clauses[1], "f.rule_id=$1") {
+				t.Errorf("shared clauses = %q", clauses[:2])
+			}
+			for i, want := range tt.want {
+				if !strings.HasSuffix(clauses[2+i], want) {
+					t.Errorf("clause %d = %q, want suffix %q", 2+i, clauses[2+i], want)
+				}
+			}
+			if !reflect.DeepEqual(args, tt.wantArgs) {
+				t.Errorf("args = %v, want %v", args, tt.wantArgs)
+			}
+		})
+	}
+
+	if _, _, err := candidateClauses(&domain.EscalationRule{ID: "r4", Condition: "IDLE_FOR"}, now); err == nil {
+		t.Error("candidateClauses accepted an unknown condition")
+	}
+}
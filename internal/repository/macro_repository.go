@@ -0,0 +1,116 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/spec-kit/ticket-service/internal/domain"
+)
+
+// MacroRepository persists personal and team-shared macros.
+type MacroRepository interface {
+	Create(ctx context.Context, macro *domain.Macro) error
+	Update(ctx context.Context, macro *domain.Macro) error
+	GetByID(ctx context.Context, id string) (*domain.Macro, error)
+	Delete(ctx context.Context, id string) error
+	// ListVisible returns the staff member's own macros plus macros shared with their team.
+	ListVisible(ctx context.Context, staffID string, teamID *string) ([]domain.Macro, error)
+}
+
+const macroColumns = `id, owner_staff_id, team_id, name, reply_body, reply_type, actions, created_at, updated_at`
+
+type macroRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewMacroRepository constructs repository.
+func NewMacroRepository(pool *pgxpool.Pool) MacroRepository {
+	return &macroRepository{pool: pool}
+}
+
+func (r *macroRepository) Create(ctx context.Context, macro *domain.Macro) error {
+	const query = `
+        INSERT INTO macros (owner_staff_id, team_id, name, reply_body, reply_type, actions)
+        VALUES ($1,$2,$3,$4,$5,$6)
+        RETURNING id, created_at, updated_at`
+	return conn(ctx, r.pool).QueryRow(ctx, query,
+		macro.OwnerStaffID,
+		macro.TeamID,
+		macro.Name,
+		macro.ReplyBody,
+		macro.ReplyType,
+		macro.Actions,
+	).Scan(&macro.ID, &macro.CreatedAt, &macro.UpdatedAt)
+}
+
+func (r *macroRepository) Update(ctx context.Context, macro *domain.Macro) error {
+	const query = `
+        UPDATE macros SET team_id=$1, name=$2, reply_body=$3, reply_type=$4, actions=$5, updated_at=NOW()
+        WHERE id=$6
+        RETURNING updated_at`
+	return conn(ctx, r.pool).QueryRow(ctx, query,
+		macro.TeamID,
+		macro.Name,
+		macro.ReplyBody,
+		macro.ReplyType,
+		macro.Actions,
+		macro.ID,
+	).Scan(&macro.UpdatedAt)
+}
+
+func (r *macroRepository) GetByID(ctx context.Context, id string) (*domain.Macro, error) {
+	query := `SELECT ` + macroColumns + ` FROM macros WHERE id=$1`
+	var macro domain.Macro
+	if err := scanMacro(conn(ctx, r.pool).QueryRow(ctx, query, id), &macro); err != nil {
+		return nil, err
+	}
+	return &macro, nil
+}
+
+func (r *macroRepository) Delete(ctx context.Context, id string) error {
+	cmd, err := conn(ctx, r.pool).Exec(ctx, `DELETE FROM macros WHERE id=$1`, id)
+	if err != nil {
+		return err
+	}
+	if cmd.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+	return nil
+}
+
+func (r *macroRepository) ListVisible(ctx context.Context, staffID string, teamID *string) ([]domain.Macro, error) {
+	query := `SELECT ` + macroColumns + ` FROM macros
+        WHERE owner_staff_id=$1 OR (team_id IS NOT NULL AND team_id=$2)
+        ORDER BY (owner_staff_id=$1) DESC, name ASC, id ASC`
+	rows, err := conn(ctx, r.pool).Query(ctx, query, staffID, teamID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []domain.Macro
+	for rows.Next() {
+		var macro domain.Macro
+		if err := scanMacro(rows, &macro); err != nil {
+			return nil, err
+		}
+		result = append(result, macro)
+	}
+	return result, rows.Err()
+}
+
+func scanMacro(row pgx.Row, macro *domain.Macro) error {
+	return row.Scan(
+		&macro.ID,
+		&macro.OwnerStaffID,
+		&macro.TeamID,
+		&macro.Name,
+		&macro.ReplyBody,
+		&macro.ReplyType,
+		&macro.Actions,
+		&macro.CreatedAt,
+		&macro.UpdatedAt,
+	)
+}
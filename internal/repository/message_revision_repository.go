@@ -0,0 +1,90 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/spec-kit/ticket-service/internal/domain"
+)
+
+// MessageRevisionRepository stores the admin-only audit trail of message edits and redactions.
+type MessageRevisionRepository interface {
+	Create(ctx context.Context, revision *domain.MessageRevision) error
+	ListByMessage(ctx context.Context, messageID string) ([]domain.MessageRevision, error)
+	// RedactPrevious replaces segments in earlier edit revisions so redacted text is not kept anywhere.
+	RedactPrevious(ctx context.Context, messageID string, segments []string, replacement string) error
+}
+
+type messageRevisionRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewMessageRevisionRepository constructs repository.
+func NewMessageRevisionRepository(pool *pgxpool.Pool) MessageRevisionRepository {
+	return &messageRevisionRepository{pool: pool}
+}
+
+func (r *messageRevisionRepository) Create(ctx context.Context, revision *domain.MessageRevision) error {
+	const query = `
+        INSERT INTO ticket_message_revisions (message_id, kind, editor_staff_id, previous_body, redacted_segments, removed_attachments, reason)
+        VALUES ($1,$2,$3,$4,$5,$6,$7)
+        RETURNING id, created_at`
+	removed := revision.RemovedAttachments
+	if removed == nil {
+		removed = []domain.AttachmentReference{}
+	}
+	return conn(ctx, r.pool).QueryRow(ctx, query,
+		revision.MessageID,
+		revision.Kind,
+		revision.EditorStaffID,
+		revision.PreviousBody,
+		revision.RedactedSegments,
+		removed,
+		revision.Reason,
+	).Scan(&revision.ID, &revision.CreatedAt)
+}
+
+func (r *messageRevisionRepository) ListByMessage(ctx context.Context, messageID string) ([]domain.MessageRevision, error) {
+	const query = `
+        SELECT id, message_id, kind, editor_staff_id, previous_body, redacted_segments, removed_attachments, reason, created_at
+        FROM ticket_message_revisions WHERE message_id=$1 ORDER BY created_at ASC`
+	rows, err := conn(ctx, r.pool).Query(ctx, query, messageID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []domain.MessageRevision
+	for rows.Next() {
+		var revision domain.MessageRevision
+		if err := rows.Scan(
+			&revision.ID,
+			&revision.MessageID,
+			&revision.Kind,
+			&revision.EditorStaffID,
+			&revision.PreviousBody,
+			&revision.RedactedSegments,
+			&revision.RemovedAttachments,
+			&revision.Reason,
+			&revision.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		result = append(result, revision)
+	}
+	return result, rows.Err()
+}
+
+func (r *messageRevisionRepository) RedactPrevious(ctx context.Context, messageID string, segments []string, replacement string) error {
+	for _, segment := range segments {
+		if _, err := conn(ctx, r.pool).Exec(ctx,
+			`UPDATE ticket_message_revisions SET previous_body = replace(previous_body, $2, $3)
+             WHERE message_id=$1 AND previous_body IS NOT NULL`,
+			messageID, segment, replacement,
+		); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+package repository
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+// Page size bounds applied to every paginated listing.
+const (
+	DefaultPageSize = 20
+	MaxPageSize     = 100
+)
+
+// ErrInvalidCursor is returned when a client-supplied cursor cannot be decoded.
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// Cursor marks the last row of a page. Keyset listings resume after (Time, ID), or after the
+// row's sort key values in Keys and ID when a custom sort is used; listings ordered by search
+// rank resume at Offset.
+type Cursor struct {
+	Time   time.Time `json:"t,omitempty"`
+	ID     string    `json:"id,omitempty"`
+	Keys   []*string `json:"k,omitempty"`
+	Offset int       `json:"o,omitempty"`
+}
+
+// Page is one page of a listing with the cursor for the next page and the total match count.
+type Page[T any] struct {
+	Items      []T
+	NextCursor string
+	Total      int
+}
+
+// EncodeCursor renders a cursor as an opaque URL-safe token.
+func EncodeCursor(cursor Cursor) string {
+	raw, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+// DecodeCursor parses a token produced by EncodeCursor; an empty token yields nil.
+func DecodeCursor(token string) (*Cursor, error) {
+	if token == "" {
+		return nil, nil
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	var cursor Cursor
+	if err := json.Unmarshal(raw, &cursor); err != nil || cursor.Offset < 0 {
+		return nil, ErrInvalidCursor
+	}
+	return &cursor, nil
+}
+
+// ClampPageSize applies the default and maximum page sizes to client-supplied limits.
+func ClampPageSize(limit int) int {
+	if limit <= 0 {
+		return DefaultPageSize
+	}
+	if limit > MaxPageSize {
+		return MaxPageSize
+	}
+	return limit
+}
+
+// pageSize applies the default page size; internal callers may exceed MaxPageSize.
+func pageSize(limit int) int {
+	if limit <= 0 {
+		return DefaultPageSize
+	}
+	return limit
+}
+
+// keysetPage trims the extra lookahead row and builds the next cursor from the last kept row.
+func keysetPage[T any](items []T, limit, total int, cursorFor func(T) Cursor) Page[T] {
+	page := Page[T]{Items: items, Total: total}
+	if len(items) > limit {
+		page.Items = items[:limit]
+		page.NextCursor = EncodeCursor(cursorFor(page.Items[limit-1]))
+	}
+	if page.Items == nil {
+		page.Items = []T{}
+	}
+	return page
+}
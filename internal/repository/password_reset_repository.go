@@ -39,7 +39,7 @@ func (r *passwordResetRepository) Create(ctx context.Context, token *PasswordRes
         INSERT INTO password_reset_tokens (subject_type, subject_id, token, expires_at)
         VALUES ($1,$2,$3,$4)
         RETURNING id, created_at`
-	return r.pool.QueryRow(ctx, query,
+	return conn(ctx, r.pool).QueryRow(ctx, query,
 		token.SubjectType,
 		token.SubjectID,
 		token.Token,
@@ -52,7 +52,7 @@ func (r *passwordResetRepository) GetByToken(ctx context.Context, tokenStr strin
         SELECT id, subject_type, subject_id, token, expires_at, used_at, created_at
         FROM password_reset_tokens WHERE token=$1`
 	var token PasswordResetToken
-	if err := r.pool.QueryRow(ctx, query, tokenStr).Scan(
+	if err := conn(ctx, r.pool).QueryRow(ctx, query, tokenStr).Scan(
 		&token.ID,
 		&token.SubjectType,
 		&token.SubjectID,
@@ -70,6 +70,6 @@ func (r *passwordResetRepository) MarkUsed(ctx context.Context, id string) error
 	const query = `
         UPDATE password_reset_tokens SET used_at=NOW()
         WHERE id=$1`
-	_, err := r.pool.Exec(ctx, query, id)
+	_, err := conn(ctx, r.pool).Exec(ctx, query, id)
 	return err
 }
@@ -0,0 +1,113 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/spec-kit/ticket-service/internal/domain"
+)
+
+// SavedViewRepository persists staff saved views.
+type SavedViewRepository interface {
+	Create(ctx context.Context, view *domain.SavedView) error
+	Update(ctx context.Context, view *domain.SavedView) error
+	GetByID(ctx context.Context, id string) (*domain.SavedView, error)
+	Delete(ctx context.Context, id string) error
+	// ListVisible returns the staff member's own views plus views shared with their team.
+	ListVisible(ctx context.Context, staffID string, teamID *string) ([]domain.SavedView, error)
+}
+
+const savedViewColumns = `id, owner_staff_id, team_id, name, filter, sort, created_at, updated_at`
+
+type savedViewRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewSavedViewRepository constructs repository.
+func NewSavedViewRepository(pool *pgxpool.Pool) SavedViewRepository {
+	return &savedViewRepository{pool: pool}
+}
+
+func (r *savedViewRepository) Create(ctx context.Context, view *domain.SavedView) error {
+	const query = `
+        INSERT INTO saved_views (owner_staff_id, team_id, name, filter, sort)
+        VALUES ($1,$2,$3,$4,$5)
+        RETURNING id, created_at, updated_at`
+	return conn(ctx, r.pool).QueryRow(ctx, query,
+		view.OwnerStaffID,
+		view.TeamID,
+		view.Name,
+		view.Filter,
+		view.Sort,
+	).Scan(&view.ID, &view.CreatedAt, &view.UpdatedAt)
+}
+
+func (r *savedViewRepository) Update(ctx context.Context, view *domain.SavedView) error {
+	const query = `
+        UPDATE saved_views SET team_id=$1, name=$2, filter=$3, sort=$4, updated_at=NOW()
+        WHERE id=$5
+        RETURNING updated_at`
+	return conn(ctx, r.pool).QueryRow(ctx, query,
+		view.TeamID,
+		view.Name,
+		view.Filter,
+		view.Sort,
+		view.ID,
+	).Scan(&view.UpdatedAt)
+}
+
+func (r *savedViewRepository) GetByID(ctx context.Context, id string) (*domain.SavedView, error) {
+	query := `SELECT ` + savedViewColumns + ` FROM saved_views WHERE id=$1`
+	var view domain.SavedView
+	if err := scanSavedView(conn(ctx, r.pool).QueryRow(ctx, query, id), &view); err != nil {
+		return nil, err
+	}
+	return &view, nil
+}
+
+func (r *savedViewRepository) Delete(ctx context.Context, id string) error {
+	cmd, err := conn(ctx, r.pool).Exec(ctx, `DELETE FROM saved_views WHERE id=$1`, id)
+	if err != nil {
+		return err
+	}
+	if cmd.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+	return nil
+}
+
+func (r *savedViewRepository) ListVisible(ctx context.Context, staffID string, teamID *string) ([]domain.SavedView, error) {
+	query := `SELECT ` + savedViewColumns + ` FROM saved_views
+        WHERE owner_staff_id=$1 OR (team_id IS NOT NULL AND team_id=$2)
+        ORDER BY (owner_staff_id=$1) DESC, name ASC, id ASC`
+	rows, err := conn(ctx, r.pool).Query(ctx, query, staffID, teamID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []domain.SavedView
+	for rows.Next() {
+		var view domain.SavedView
+		if err := scanSavedView(rows, &view); err != nil {
+			return nil, err
+		}
+		result = append(result, view)
+	}
+	return result, rows.Err()
+}
+
+func scanSavedView(row pgx.Row, view *domain.SavedView) error {
+	return row.Scan(
+		&view.ID,
+		&view.OwnerStaffID,
+		&view.TeamID,
+		&view.Name,
+		&view.Filter,
+		&view.Sort,
+		&view.CreatedAt,
+		&view.UpdatedAt,
+	)
+}
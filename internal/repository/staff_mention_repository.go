@@ -0,0 +1,135 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/spec-kit/ticket-service/internal/domain"
+)
+
+// StaffMentionRepository persists @mentions and their read state.
+type StaffMentionRepository interface {
+	// Create stores a mention; a repeated mention of the same staff member in one message is ignored.
+	Create(ctx context.Context, mention *domain.StaffMention) (bool, error)
+	ListForStaff(ctx context.Context, filter StaffMentionFilter) (Page[domain.StaffMention], error)
+	// SetRead marks the staff member's listed mentions read or unread; nil ids applies to all of them.
+	SetRead(ctx context.Context, staffID string, ids []string, read bool) (int, error)
+}
+
+// StaffMentionFilter narrows a staff member's mention inbox.
+type StaffMentionFilter struct {
+	StaffID    string
+	UnreadOnly bool
+	Limit      int
+	Cursor     *Cursor
+}
+
+type staffMentionRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewStaffMentionRepository constructs repository.
+func NewStaffMentionRepository(pool *pgxpool.Pool) StaffMentionRepository {
+	return &staffMentionRepository{pool: pool}
+}
+
+func (r *staffMentionRepository) Create(ctx context.Context, mention *domain.StaffMention) (bool, error) {
+	const query = `
+        INSERT INTO staff_mentions (ticket_id, message_id, mentioned_staff_id, mentioned_by_staff_id)
+        VALUES ($1,$2,$3,$4)
+        ON CONFLICT (message_id, mentioned_staff_id) DO NOTHING
+        RETURNING id, created_at`
+	rows, err := conn(ctx, r.pool).Query(ctx, query,
+		mention.TicketID,
+		mention.MessageID,
+		mention.MentionedStaffID,
+		mention.MentionedByStaffID,
+	)
+	if err != nil {
+		return false, err
+	}
+	defer rows.Close()
+	if !rows.Next() {
+		return false, rows.Err()
+	}
+	if err := rows.Scan(&mention.ID, &mention.CreatedAt); err != nil {
+		return false, err
+	}
+	return true, rows.Err()
+}
+
+func (r *staffMentionRepository) ListForStaff(ctx context.Context, filter StaffMentionFilter) (Page[domain.StaffMention], error) {
+	where := "m.mentioned_staff_id=$1"
+	if filter.UnreadOnly {
+		where += " AND m.read_at IS NULL"
+	}
+	var total int
+	if err := conn(ctx, r.pool).QueryRow(ctx, "SELECT COUNT(*) FROM staff_mentions m WHERE "+where, filter.StaffID).Scan(&total); err != nil {
+		return Page[domain.StaffMention]{}, err
+	}
+
+	limit := pageSize(filter.Limit)
+	args := []any{filter.StaffID}
+	if filter.Cursor != nil && filter.Cursor.ID != "" {
+		args = append(args, filter.Cursor.Time, filter.Cursor.ID)
+		where += " AND (m.created_at, m.id) < ($2, $3)"
+	}
+	query := fmt.Sprintf(`
+        SELECT m.id, m.ticket_id, m.message_id, m.mentioned_staff_id, m.mentioned_by_staff_id, m.read_at, m.created_at,
+               t.external_key, t.title, msg.body, msg.body_format
+        FROM staff_mentions m
+        JOIN tickets t ON t.id = m.ticket_id
+        JOIN ticket_messages msg ON msg.id = m.message_id
+        WHERE %s
+        ORDER BY m.created_at DESC, m.id DESC
+        LIMIT %d`, where, limit+1)
+	rows, err := conn(ctx, r.pool).Query(ctx, query, args...)
+	if err != nil {
+		return Page[domain.StaffMention]{}, err
+	}
+	defer rows.Close()
+
+	var result []domain.StaffMention
+	for rows.Next() {
+		var mention domain.StaffMention
+		if err := rows.Scan(
+			&mention.ID,
+			&mention.TicketID,
+			&mention.MessageID,
+			&mention.MentionedStaffID,
+			&mention.MentionedByStaffID,
+			&mention.ReadAt,
+			&mention.CreatedAt,
+			&mention.TicketExternalKey,
+			&mention.TicketTitle,
+			&mention.BodyPreview,
+			&mention.BodyFormat,
+		); err != nil {
+			return Page[domain.StaffMention]{}, err
+		}
+		result = append(result, mention)
+	}
+	if err := rows.Err(); err != nil {
+		return Page[domain.StaffMention]{}, err
+	}
+	return keysetPage(result, limit, total, func(mention domain.StaffMention) Cursor {
+		return Cursor{Time: mention.CreatedAt, ID: mention.ID}
+	}), nil
+}
+
+func (r *staffMentionRepository) SetRead(ctx context.Context, staffID string, ids []string, read bool) (int, error) {
+	query := `UPDATE staff_mentions SET read_at = CASE WHEN $2 THEN COALESCE(read_at, NOW()) END
+        WHERE mentioned_staff_id=$1`
+	args := []any{staffID, read}
+	if ids != nil {
+		args = append(args, ids)
+		query += ` AND id = ANY($3::uuid[])`
+	}
+	cmd, err := conn(ctx, r.pool).Exec(ctx, query, args...)
+	if err != nil {
+		return 0, err
+	}
+	return int(cmd.RowsAffected()), nil
+}
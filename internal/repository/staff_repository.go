@@ -17,7 +17,11 @@ type StaffRepository interface {
 	Update(ctx context.Context, staff *domain.StaffMember) error
 	GetByID(ctx context.Context, id string) (*domain.StaffMember, error)
 	GetByEmail(ctx context.Context, email string) (*domain.StaffMember, error)
-	List(ctx context.Context, filter StaffFilter) ([]domain.StaffMember, error)
+	// FindByHandle returns active staff whose name, lowercased with non-alphanumerics removed, equals handle.
+	FindByHandle(ctx context.Context, handle string) ([]domain.StaffMember, error)
+	// ListByIDs loads many staff members in one query; unknown IDs are skipped.
+	ListByIDs(ctx context.Context, ids []string) ([]domain.StaffMember, error)
+	List(ctx context.Context, filter StaffFilter) (Page[domain.StaffMember], error)
 }
 
 // StaffFilter defines query params for staff listing.
@@ -27,7 +31,7 @@ type StaffFilter struct {
 	DepartmentID *string
 	Active       *bool
 	Limit        int
-	Offset       int
+	Cursor       *Cursor
 }
 
 type staffRepository struct {
@@ -45,7 +49,7 @@ func (r *staffRepository) Create(ctx context.Context, staff *domain.StaffMember)
         VALUES ($1,$2,$3,$4,$5,$6,$7)
         RETURNING id, created_at, updated_at`
 
-	return r.pool.QueryRow(ctx, query,
+	return conn(ctx, r.pool).QueryRow(ctx, query,
 		staff.Name,
 		staff.Email,
 		staff.PasswordHash,
@@ -62,7 +66,7 @@ func (r *staffRepository) Update(ctx context.Context, staff *domain.StaffMember)
         SET name=$1, email=$2, password_hash=$3, role=$4, department_id=$5, team_id=$6, active_flag=$7, updated_at=NOW()
         WHERE id=$8`
 
-	cmd, err := r.pool.Exec(ctx, query,
+	cmd, err := conn(ctx, r.pool).Exec(ctx, query,
 		staff.Name,
 		staff.Email,
 		staff.PasswordHash,
@@ -87,7 +91,7 @@ func (r *staffRepository) GetByID(ctx context.Context, id string) (*domain.Staff
         FROM staff_members WHERE id=$1`
 
 	var staff domain.StaffMember
-	if err := r.pool.QueryRow(ctx, query, id).Scan(
+	if err := conn(ctx, r.pool).QueryRow(ctx, query, id).Scan(
 		&staff.ID,
 		&staff.Name,
 		&staff.Email,
@@ -110,7 +114,7 @@ func (r *staffRepository) GetByEmail(ctx context.Context, email string) (*domain
         FROM staff_members WHERE email=$1`
 
 	var staff domain.StaffMember
-	if err := r.pool.QueryRow(ctx, query, email).Scan(
+	if err := conn(ctx, r.pool).QueryRow(ctx, query, email).Scan(
 		&staff.ID,
 		&staff.Name,
 		&staff.Email,
@@ -127,12 +131,80 @@ func (r *staffRepository) GetByEmail(ctx context.Context, email string) (*domain
 	return &staff, nil
 }
 
-func (r *staffRepository) List(ctx context.Context, filter StaffFilter) ([]domain.StaffMember, error) {
-	query := `
+func (r *staffRepository) ListByIDs(ctx context.Context, ids []string) ([]domain.StaffMember, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	const query = `
+        SELECT id, name, email, password_hash, role, department_id, team_id, active_flag, created_at, updated_at
+        FROM staff_members WHERE id = ANY($1::uuid[])`
+
+	rows, err := conn(ctx, r.pool).Query(ctx, query, ids)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []domain.StaffMember
+	for rows.Next() {
+		var staff domain.StaffMember
+		if err := rows.Scan(
+			&staff.ID,
+			&staff.Name,
+			&staff.Email,
+			&staff.PasswordHash,
+			&staff.Role,
+			&staff.DepartmentID,
+			&staff.TeamID,
+			&staff.Active,
+			&staff.CreatedAt,
+			&staff.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		result = append(result, staff)
+	}
+	return result, rows.Err()
+}
+
+func (r *staffRepository) FindByHandle(ctx context.Context, handle string) ([]domain.StaffMember, error) {
+	const query = `
         SELECT id, name, email, password_hash, role, department_id, team_id, active_flag, created_at, updated_at
-        FROM staff_members`
+        FROM staff_members
+        WHERE active_flag AND regexp_replace(lower(name), '[^a-z0-9]', '', 'g') = $1
+        ORDER BY created_at, id`
+
+	rows, err := conn(ctx, r.pool).Query(ctx, query, handle)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []domain.StaffMember
+	for rows.Next() {
+		var staff domain.StaffMember
+		if err := rows.Scan(
+			&staff.ID,
+			&staff.Name,
+			&staff.Email,
+			&staff.PasswordHash,
+			&staff.Role,
+			&staff.DepartmentID,
+			&staff.TeamID,
+			&staff.Active,
+			&staff.CreatedAt,
+			&staff.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		result = append(result, staff)
+	}
+	return result, rows.Err()
+}
+
+func (r *staffRepository) List(ctx context.Context, filter StaffFilter) (Page[domain.StaffMember], error) {
 	args := []any{}
-	clauses := []string{}
+	clauses := []string{"1=1"}
 
 	if filter.Role != nil {
 		args = append(args, *filter.Role)
@@ -150,24 +222,26 @@ func (r *staffRepository) List(ctx context.Context, filter StaffFilter) ([]domai
 		args = append(args, *filter.Active)
 		clauses = append(clauses, fmt.Sprintf("active_flag=$%d", len(args)))
 	}
-	if len(clauses) > 0 {
-		query += " WHERE " + strings.Join(clauses, " AND ")
-	}
+	where := strings.Join(clauses, " AND ")
 
-	query += " ORDER BY created_at DESC"
-	limit := filter.Limit
-	if limit <= 0 {
-		limit = 50
+	var total int
+	if err := conn(ctx, r.pool).QueryRow(ctx, "SELECT COUNT(*) FROM staff_members WHERE "+where, args...).Scan(&total); err != nil {
+		return Page[domain.StaffMember]{}, err
 	}
-	offset := filter.Offset
-	if offset < 0 {
-		offset = 0
+
+	pageArgs := args
+	if filter.Cursor != nil && filter.Cursor.ID != "" {
+		pageArgs = append(append([]any{}, args...), filter.Cursor.Time, filter.Cursor.ID)
+		where += fmt.Sprintf(" AND (created_at, id) < ($%d, $%d)", len(pageArgs)-1, len(pageArgs))
 	}
-	query += fmt.Sprintf(" LIMIT %d OFFSET %d", limit, offset)
+	limit := pageSize(filter.Limit)
+	query := fmt.Sprintf(`
+        SELECT id, name, email, password_hash, role, department_id, team_id, active_flag, created_at, updated_at
+        FROM staff_members WHERE %s ORDER BY created_at DESC, id DESC LIMIT %d`, where, limit+1)
 
-	rows, err := r.pool.Query(ctx, query, args...)
+	rows, err := conn(ctx, r.pool).Query(ctx, query, pageArgs...)
 	if err != nil {
-		return nil, err
+		return Page[domain.StaffMember]{}, err
 	}
 	defer rows.Close()
 
@@ -186,9 +260,14 @@ func (r *staffRepository) List(ctx context.Context, filter StaffFilter) ([]domai
 			&staff.CreatedAt,
 			&staff.UpdatedAt,
 		); err != nil {
-			return nil, err
+			return Page[domain.StaffMember]{}, err
 		}
 		result = append(result, staff)
 	}
-	return result, rows.Err()
+	if err := rows.Err(); err != nil {
+		return Page[domain.StaffMember]{}, err
+	}
+	return keysetPage(result, limit, total, func(staff domain.StaffMember) Cursor {
+		return Cursor{Time: staff.CreatedAt, ID: staff.ID}
+	}), nil
 }
@@ -0,0 +1,237 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/spec-kit/ticket-service/internal/domain"
+)
+
+// TagFilter narrows tag catalog listings.
+type TagFilter struct {
+	// Prefix matches tag names starting with the value, for autocomplete.
+	Prefix            string
+	IncludeDeprecated bool
+}
+
+// TagUsageFilter scopes usage statistics to a subset of tickets.
+type TagUsageFilter struct {
+	DepartmentID *string
+	TeamID       *string
+}
+
+// TagRepository persists the tag catalog and keeps ticket tag arrays in step with it.
+type TagRepository interface {
+	Create(ctx context.Context, tag *domain.Tag) error
+	// Update saves tag details; a changed name is rewritten on every ticket carrying the old one.
+	Update(ctx context.Context, tag *domain.Tag) error
+	GetByID(ctx context.Context, id string) (*domain.Tag, error)
+	ListByNames(ctx context.Context, names []string) ([]domain.Tag, error)
+	List(ctx context.Context, filter TagFilter) ([]domain.Tag, error)
+	// Merge retags tickets from source to target and deletes source, returning the number of tickets changed.
+	Merge(ctx context.Context, source, target *domain.Tag) (int, error)
+	Usage(ctx context.Context, filter TagUsageFilter) ([]domain.TagUsage, error)
+}
+
+const tagColumns = `id, name, color, description, deprecated, created_at, updated_at`
+
+type tagRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewTagRepository constructs repository.
+func NewTagRepository(pool *pgxpool.Pool) TagRepository {
+	return &tagRepository{pool: pool}
+}
+
+func (r *tagRepository) Create(ctx context.Context, tag *domain.Tag) error {
+	const query = `
+        INSERT INTO tags (name, color, description, deprecated)
+        VALUES ($1,$2,$3,$4)
+        RETURNING id, created_at, updated_at`
+	return conn(ctx, r.pool).QueryRow(ctx, query,
+		tag.Name,
+		tag.Color,
+		tag.Description,
+		tag.Deprecated,
+	).Scan(&tag.ID, &tag.CreatedAt, &tag.UpdatedAt)
+}
+
+func (r *tagRepository) Update(ctx context.Context, tag *domain.Tag) error {
+	tx, err := conn(ctx, r.pool).Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck
+
+	var oldName string
+	if err := tx.QueryRow(ctx, `SELECT name FROM tags WHERE id=$1 FOR UPDATE`, tag.ID).Scan(&oldName); err != nil {
+		return err
+	}
+	const query = `
+        UPDATE tags SET name=$1, color=$2, description=$3, deprecated=$4, updated_at=NOW()
+        WHERE id=$5
+        RETURNING updated_at`
+	if err := tx.QueryRow(ctx, query,
+		tag.Name,
+		tag.Color,
+		tag.Description,
+		tag.Deprecated,
+		tag.ID,
+	).Scan(&tag.UpdatedAt); err != nil {
+		return err
+	}
+	if oldName != tag.Name {
+		if _, err := tx.Exec(ctx,
+			`UPDATE tickets SET tags=array_replace(tags, $1, $2) WHERE tags @> ARRAY[$1]::text[]`,
+			oldName, tag.Name,
+		); err != nil {
+			return err
+		}
+	}
+	return tx.Commit(ctx)
+}
+
+func (r *tagRepository) GetByID(ctx context.Context, id string) (*domain.Tag, error) {
+	query := `SELECT ` + tagColumns + ` FROM tags WHERE id=$1`
+	var tag domain.Tag
+	if err := scanTag(conn(ctx, r.pool).QueryRow(ctx, query, id), &tag); err != nil {
+		return nil, err
+	}
+	return &tag, nil
+}
+
+func (r *tagRepository) ListByNames(ctx context.Context, names []string) ([]domain.Tag, error) {
+	if len(names) == 0 {
+		return nil, nil
+	}
+	query := `SELECT ` + tagColumns + ` FROM tags WHERE name = ANY($1::text[])`
+	return r.query(ctx, query, names)
+}
+
+func (r *tagRepository) List(ctx context.Context, filter TagFilter) ([]domain.Tag, error) {
+	clauses := []string{"1=1"}
+	args := []any{}
+	if filter.Prefix != "" {
+		args = append(args, escapeLike(filter.Prefix)+"%")
+		clauses = append(clauses, fmt.Sprintf("name LIKE $%d", len(args)))
+	}
+	if !filter.IncludeDeprecated {
+		clauses = append(clauses, "deprecated = FALSE")
+	}
+	query := fmt.Sprintf(`SELECT %s FROM tags WHERE %s ORDER BY name ASC`, tagColumns, strings.Join(clauses, " AND "))
+	return r.query(ctx, query, args...)
+}
+
+func (r *tagRepository) Merge(ctx context.Context, source, target *domain.Tag) (int, error) {
+	tx, err := conn(ctx, r.pool).Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck
+
+	// drop the source name, appending the target only where the ticket does not already carry it
+	cmd, err := tx.Exec(ctx, `
+        UPDATE tickets
+        SET tags = array_remove(tags, $1) ||
+            CASE WHEN tags @> ARRAY[$2]::text[] THEN ARRAY[]::text[] ELSE ARRAY[$2]::text[] END
+        WHERE tags @> ARRAY[$1]::text[]`,
+		source.Name, target.Name,
+	)
+	if err != nil {
+		return 0, err
+	}
+	deleted, err := tx.Exec(ctx, `DELETE FROM tags WHERE id=$1`, source.ID)
+	if err != nil {
+		return 0, err
+	}
+	if deleted.RowsAffected() == 0 {
+		return 0, pgx.ErrNoRows
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return 0, err
+	}
+	return int(cmd.RowsAffected()), nil
+}
+
+func (r *tagRepository) Usage(ctx context.Context, filter TagUsageFilter) ([]domain.TagUsage, error) {
+	clauses := []string{"1=1"}
+	args := []any{}
+	if filter.DepartmentID != nil {
+		args = append(args, *filter.DepartmentID)
+		clauses = append(clauses, fmt.Sprintf("tk.department_id=$%d", len(args)))
+	}
+	if filter.TeamID != nil {
+		args = append(args, *filter.TeamID)
+		clauses = append(clauses, fmt.Sprintf("tk.team_id=$%d", len(args)))
+	}
+	query := fmt.Sprintf(`
+        SELECT t.id, t.name, t.color, t.description, t.deprecated, t.created_at, t.updated_at,
+               COALESCE(u.ticket_count, 0), COALESCE(u.open_count, 0), u.last_used_at
+        FROM tags t
+        LEFT JOIN (
+            SELECT tag,
+                   COUNT(*) AS ticket_count,
+                   COUNT(*) FILTER (WHERE tk.status NOT IN ('RESOLVED', 'CLOSED', 'CANCELLED')) AS open_count,
+                   MAX(tk.updated_at) AS last_used_at
+            FROM tickets tk, unnest(tk.tags) AS tag
+            WHERE %s
+            GROUP BY tag
+        ) u ON u.tag = t.name
+        ORDER BY COALESCE(u.ticket_count, 0) DESC, t.name ASC`, strings.Join(clauses, " AND "))
+	rows, err := conn(ctx, r.pool).Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []domain.TagUsage
+	for rows.Next() {
+		var usage domain.TagUsage
+		if err := scanTag(rows, &usage.Tag, &usage.TicketCount, &usage.OpenCount, &usage.LastUsedAt); err != nil {
+			return nil, err
+		}
+		result = append(result, usage)
+	}
+	return result, rows.Err()
+}
+
+func (r *tagRepository) query(ctx context.Context, query string, args ...any) ([]domain.Tag, error) {
+	rows, err := conn(ctx, r.pool).Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []domain.Tag
+	for rows.Next() {
+		var tag domain.Tag
+		if err := scanTag(rows, &tag); err != nil {
+			return nil, err
+		}
+		result = append(result, tag)
+	}
+	return result, rows.Err()
+}
+
+func scanTag(row pgx.Row, tag *domain.Tag, extra ...any) error {
+	dest := append([]any{
+		&tag.ID,
+		&tag.Name,
+		&tag.Color,
+		&tag.Description,
+		&tag.Deprecated,
+		&tag.CreatedAt,
+		&tag.UpdatedAt,
+	}, extra...)
+	return row.Scan(dest...)
+}
+
+// escapeLike escapes LIKE wildcards in user input.
+func escapeLike(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(value)
+}
@@ -33,7 +33,7 @@ func (r *teamRepository) Create(ctx context.Context, team *domain.Team) error {
         INSERT INTO teams (department_id, name, description, is_active)
         VALUES ($1,$2,$3,$4)
         RETURNING id, created_at, updated_at`
-	return r.pool.QueryRow(ctx, query,
+	return conn(ctx, r.pool).QueryRow(ctx, query,
 		team.DepartmentID,
 		team.Name,
 		team.Description,
@@ -45,7 +45,7 @@ func (r *teamRepository) Update(ctx context.Context, team *domain.Team) error {
 	const query = `
         UPDATE teams SET department_id=$1, name=$2, description=$3, is_active=$4, updated_at=NOW()
         WHERE id=$5`
-	cmd, err := r.pool.Exec(ctx, query,
+	cmd, err := conn(ctx, r.pool).Exec(ctx, query,
 		team.DepartmentID,
 		team.Name,
 		team.Description,
@@ -66,7 +66,7 @@ func (r *teamRepository) GetByID(ctx context.Context, id string) (*domain.Team,
         SELECT id, department_id, name, description, is_active, created_at, updated_at
         FROM teams WHERE id=$1`
 	var team domain.Team
-	if err := r.pool.QueryRow(ctx, query, id).Scan(
+	if err := conn(ctx, r.pool).QueryRow(ctx, query, id).Scan(
 		&team.ID,
 		&team.DepartmentID,
 		&team.Name,
@@ -96,7 +96,7 @@ func (r *teamRepository) List(ctx context.Context, departmentID *string, include
 	if len(clauses) > 0 {
 		base += " WHERE " + strings.Join(clauses, " AND ")
 	}
-	rows, err := r.pool.Query(ctx, base, args...)
+	rows, err := conn(ctx, r.pool).Query(ctx, base, args...)
 	if err != nil {
 		return nil, err
 	}
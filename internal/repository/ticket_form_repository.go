@@ -0,0 +1,72 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/spec-kit/ticket-service/internal/domain"
+)
+
+// TicketFormRepository persists per-department ticket forms.
+type TicketFormRepository interface {
+	Upsert(ctx context.Context, form *domain.TicketForm) error
+	GetByDepartment(ctx context.Context, departmentID string) (*domain.TicketForm, error)
+	DeleteByDepartment(ctx context.Context, departmentID string) error
+}
+
+type ticketFormRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewTicketFormRepository constructs repository.
+func NewTicketFormRepository(pool *pgxpool.Pool) TicketFormRepository {
+	return &ticketFormRepository{pool: pool}
+}
+
+func (r *ticketFormRepository) Upsert(ctx context.Context, form *domain.TicketForm) error {
+	const query = `
+        INSERT INTO ticket_forms (department_id, name, fields, is_active)
+        VALUES ($1,$2,$3,$4)
+        ON CONFLICT (department_id) DO UPDATE SET name=EXCLUDED.name, fields=EXCLUDED.fields,
+            is_active=EXCLUDED.is_active, updated_at=NOW()
+        RETURNING id, created_at, updated_at`
+	return conn(ctx, r.pool).QueryRow(ctx, query,
+		form.DepartmentID,
+		form.Name,
+		form.Fields,
+		form.IsActive,
+	).Scan(&form.ID, &form.CreatedAt, &form.UpdatedAt)
+}
+
+func (r *ticketFormRepository) GetByDepartment(ctx context.Context, departmentID string) (*domain.TicketForm, error) {
+	const query = `
+        SELECT id, department_id, name, fields, is_active, created_at, updated_at
+        FROM ticket_forms WHERE department_id=$1`
+	var form domain.TicketForm
+	if err := conn(ctx, r.pool).QueryRow(ctx, query, departmentID).Scan(
+		&form.ID,
+		&form.DepartmentID,
+		&form.Name,
+		&form.Fields,
+		&form.IsActive,
+		&form.CreatedAt,
+		&form.UpdatedAt,
+	); err != nil {
+		return nil, err
+	}
+	return &form, nil
+}
+
+func (r *ticketFormRepository) DeleteByDepartment(ctx context.Context, departmentID string) error {
+	const query = `DELETE FROM ticket_forms WHERE department_id=$1`
+	cmd, err := conn(ctx, r.pool).Exec(ctx, query, departmentID)
+	if err != nil {
+		return err
+	}
+	if cmd.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+	return nil
+}
@@ -11,7 +11,7 @@ import (
 // TicketHistoryRepository stores audit entries.
 type TicketHistoryRepository interface {
 	Create(ctx context.Context, history *domain.TicketHistory) error
-	ListByTicket(ctx context.Context, ticketID string, limit, offset int) ([]domain.TicketHistory, error)
+	ListByTicket(ctx context.Context, ticketID string, limit int, cursor *Cursor) (Page[domain.TicketHistory], error)
 }
 
 type ticketHistoryRepository struct {
@@ -28,7 +28,7 @@ func (r *ticketHistoryRepository) Create(ctx context.Context, history *domain.Ti
         INSERT INTO ticket_history (ticket_id, changed_by_type, changed_by_id, change_type, old_value, new_value)
         VALUES ($1,$2,$3,$4,$5,$6)
         RETURNING id, created_at`
-	return r.pool.QueryRow(ctx, query,
+	return conn(ctx, r.pool).QueryRow(ctx, query,
 		history.TicketID,
 		history.ChangedByType,
 		history.ChangedByID,
@@ -38,19 +38,24 @@ func (r *ticketHistoryRepository) Create(ctx context.Context, history *domain.Ti
 	).Scan(&history.ID, &history.CreatedAt)
 }
 
-func (r *ticketHistoryRepository) ListByTicket(ctx context.Context, ticketID string, limit, offset int) ([]domain.TicketHistory, error) {
-	if limit <= 0 {
-		limit = 50
+func (r *ticketHistoryRepository) ListByTicket(ctx context.Context, ticketID string, limit int, cursor *Cursor) (Page[domain.TicketHistory], error) {
+	var total int
+	if err := conn(ctx, r.pool).QueryRow(ctx, `SELECT COUNT(*) FROM ticket_history WHERE ticket_id=$1`, ticketID).Scan(&total); err != nil {
+		return Page[domain.TicketHistory]{}, err
 	}
-	if offset < 0 {
-		offset = 0
-	}
-	const queryTemplate = `
+	limit = pageSize(limit)
+	args := []any{ticketID, limit + 1}
+	query := `
         SELECT id, ticket_id, changed_by_type, changed_by_id, change_type, old_value, new_value, created_at
-        FROM ticket_history WHERE ticket_id=$1 ORDER BY created_at DESC LIMIT $2 OFFSET $3`
-	rows, err := r.pool.Query(ctx, queryTemplate, ticketID, limit, offset)
+        FROM ticket_history WHERE ticket_id=$1`
+	if cursor != nil && cursor.ID != "" {
+		args = append(args, cursor.Time, cursor.ID)
+		query += ` AND (created_at, id) < ($3, $4)`
+	}
+	query += ` ORDER BY created_at DESC, id DESC LIMIT $2`
+	rows, err := conn(ctx, r.pool).Query(ctx, query, args...)
 	if err != nil {
-		return nil, err
+		return Page[domain.TicketHistory]{}, err
 	}
 	defer rows.Close()
 
@@ -67,9 +72,14 @@ func (r *ticketHistoryRepository) ListByTicket(ctx context.Context, ticketID str
 			&history.NewValue,
 			&history.CreatedAt,
 		); err != nil {
-			return nil, err
+			return Page[domain.TicketHistory]{}, err
 		}
 		result = append(result, history)
 	}
-	return result, rows.Err()
+	if err := rows.Err(); err != nil {
+		return Page[domain.TicketHistory]{}, err
+	}
+	return keysetPage(result, limit, total, func(history domain.TicketHistory) Cursor {
+		return Cursor{Time: history.CreatedAt, ID: history.ID}
+	}), nil
 }
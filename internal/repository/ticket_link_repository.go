@@ -0,0 +1,147 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/spec-kit/ticket-service/internal/domain"
+)
+
+// TicketLinkRepository persists typed links between tickets.
+type TicketLinkRepository interface {
+	Create(ctx context.Context, link *domain.TicketLink) error
+	GetByID(ctx context.Context, id string) (*domain.TicketLink, error)
+	SetPropagate(ctx context.Context, id string, propagate bool) error
+	Delete(ctx context.Context, id string) error
+	// ListForTicket returns every link touching the ticket, described from its side.
+	ListForTicket(ctx context.Context, ticketID string) ([]domain.LinkedTicket, error)
+	// ParentOf returns the parent ticket ID, if the ticket has one.
+	ParentOf(ctx context.Context, ticketID string) (*string, error)
+}
+
+const ticketLinkColumns = `l.id, l.source_ticket_id, l.target_ticket_id, l.link_type, l.propagate, l.created_by_staff_id, l.created_at`
+
+type ticketLinkRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewTicketLinkRepository constructs repository.
+func NewTicketLinkRepository(pool *pgxpool.Pool) TicketLinkRepository {
+	return &ticketLinkRepository{pool: pool}
+}
+
+func (r *ticketLinkRepository) Create(ctx context.Context, link *domain.TicketLink) error {
+	const query = `
+        INSERT INTO ticket_links (source_ticket_id, target_ticket_id, link_type, propagate, created_by_staff_id)
+        VALUES ($1,$2,$3,$4,$5)
+        RETURNING id, created_at`
+	return conn(ctx, r.pool).QueryRow(ctx, query,
+		link.SourceTicketID,
+		link.TargetTicketID,
+		link.LinkType,
+		link.Propagate,
+		link.CreatedByStaffID,
+	).Scan(&link.ID, &link.CreatedAt)
+}
+
+func (r *ticketLinkRepository) GetByID(ctx context.Context, id string) (*domain.TicketLink, error) {
+	query := `SELECT ` + ticketLinkColumns + ` FROM ticket_links l WHERE l.id=$1`
+	var link domain.TicketLink
+	if err := scanTicketLink(conn(ctx, r.pool).QueryRow(ctx, query, id), &link); err != nil {
+		return nil, err
+	}
+	return &link, nil
+}
+
+func (r *ticketLinkRepository) SetPropagate(ctx context.Context, id string, propagate bool) error {
+	cmd, err := conn(ctx, r.pool).Exec(ctx, `UPDATE ticket_links SET propagate=$1 WHERE id=$2`, propagate, id)
+	if err != nil {
+		return err
+	}
+	if cmd.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+	return nil
+}
+
+func (r *ticketLinkRepository) Delete(ctx context.Context, id string) error {
+	cmd, err := conn(ctx, r.pool).Exec(ctx, `DELETE FROM ticket_links WHERE id=$1`, id)
+	if err != nil {
+		return err
+	}
+	if cmd.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+	return nil
+}
+
+func (r *ticketLinkRepository) ListForTicket(ctx context.Context, ticketID string) ([]domain.LinkedTicket, error) {
+	query := `SELECT ` + ticketLinkColumns + `, t.id, t.external_key, t.title, t.status, t.priority
+        FROM ticket_links l
+        JOIN tickets t ON t.id = CASE WHEN l.source_ticket_id=$1 THEN l.target_ticket_id ELSE l.source_ticket_id END
+        WHERE l.source_ticket_id=$1 OR l.target_ticket_id=$1
+        ORDER BY l.link_type, l.created_at, l.id`
+	rows, err := conn(ctx, r.pool).Query(ctx, query, ticketID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []domain.LinkedTicket
+	for rows.Next() {
+		var linked domain.LinkedTicket
+		link := &linked.Link
+		if err := rows.Scan(
+			&link.ID,
+			&link.SourceTicketID,
+			&link.TargetTicketID,
+			&link.LinkType,
+			&link.Propagate,
+			&link.CreatedByStaffID,
+			&link.CreatedAt,
+			&linked.TicketID,
+			&linked.ExternalKey,
+			&linked.Title,
+			&linked.Status,
+			&linked.Priority,
+		); err != nil {
+			return nil, err
+		}
+		linked.Type = link.LinkType
+		if link.TargetTicketID == ticketID {
+			linked.Type = link.LinkType.Inverse()
+		}
+		result = append(result, linked)
+	}
+	return result, rows.Err()
+}
+
+func (r *ticketLinkRepository) ParentOf(ctx context.Context, ticketID string) (*string, error) {
+	var parentID string
+	err := conn(ctx, r.pool).QueryRow(ctx,
+		`SELECT source_ticket_id FROM ticket_links WHERE target_ticket_id=$1 AND link_type=$2`,
+		ticketID, domain.TicketLinkParentOf,
+	).Scan(&parentID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &parentID, nil
+}
+
+func scanTicketLink(row pgx.Row, link *domain.TicketLink) error {
+	return row.Scan(
+		&link.ID,
+		&link.SourceTicketID,
+		&link.TargetTicketID,
+		&link.LinkType,
+		&link.Propagate,
+		&link.CreatedByStaffID,
+		&link.CreatedAt,
+	)
+}
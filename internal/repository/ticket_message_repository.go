@@ -12,9 +12,20 @@ import (
 // TicketMessageRepository manages ticket thread messages.
 type TicketMessageRepository interface {
 	Create(ctx context.Context, msg *domain.TicketMessage) error
+	GetByID(ctx context.Context, id string) (*domain.TicketMessage, error)
+	// UpdateBody stores a new body along with the edited/redacted markers.
+	UpdateBody(ctx context.Context, msg *domain.TicketMessage) error
 	ListByTicket(ctx context.Context, ticketID string) ([]domain.TicketMessage, error)
+	// ListCopies returns the messages propagated from the given message.
+	ListCopies(ctx context.Context, messageID string) ([]domain.TicketMessage, error)
+	// MoveToTicket reassigns every message (and so its attachments) from one ticket to another.
+	MoveToTicket(ctx context.Context, fromTicketID, toTicketID string) (int, error)
+	// MoveMessages reassigns the listed messages that belong to fromTicketID.
+	MoveMessages(ctx context.Context, fromTicketID, toTicketID string, messageIDs []string) (int, error)
 }
 
+const messageColumns = `id, ticket_id, author_type, author_id, message_type, body, body_format, created_at, edited_at, redacted_at, copied_from_message_id`
+
 type ticketMessageRepository struct {
 	pool *pgxpool.Pool
 }
@@ -26,23 +37,36 @@ func NewTicketMessageRepository(pool *pgxpool.Pool) TicketMessageRepository {
 
 func (r *ticketMessageRepository) Create(ctx context.Context, msg *domain.TicketMessage) error {
 	const query = `
-        INSERT INTO ticket_messages (ticket_id, author_type, author_id, message_type, body)
-        VALUES ($1,$2,$3,$4,$5)
-        RETURNING id, created_at`
-	return r.pool.QueryRow(ctx, query,
+        INSERT INTO ticket_messages (ticket_id, author_type, author_id, message_type, body, body_format, copied_from_message_id)
+        VALUES ($1,$2,$3,$4,$5,COALESCE(NULLIF($6,''),'text'),$7)
+        RETURNING id, body_format, created_at`
+	return conn(ctx, r.pool).QueryRow(ctx, query,
 		msg.TicketID,
 		msg.AuthorType,
 		msg.AuthorID,
 		msg.MessageType,
 		msg.Body,
-	).Scan(&msg.ID, &msg.CreatedAt)
+		string(msg.BodyFormat),
+		msg.CopiedFromID,
+	).Scan(&msg.ID, &msg.BodyFormat, &msg.CreatedAt)
 }
 
 func (r *ticketMessageRepository) ListByTicket(ctx context.Context, ticketID string) ([]domain.TicketMessage, error) {
 	const query = `
-        SELECT id, ticket_id, author_type, author_id, message_type, body, created_at
+        SELECT ` + messageColumns + `
         FROM ticket_messages WHERE ticket_id=$1 ORDER BY created_at ASC`
-	rows, err := r.pool.Query(ctx, query, ticketID)
+	return r.list(ctx, query, ticketID)
+}
+
+func (r *ticketMessageRepository) ListCopies(ctx context.Context, messageID string) ([]domain.TicketMessage, error) {
+	const query = `
+        SELECT ` + messageColumns + `
+        FROM ticket_messages WHERE copied_from_message_id=$1 ORDER BY created_at ASC`
+	return r.list(ctx, query, messageID)
+}
+
+func (r *ticketMessageRepository) list(ctx context.Context, query string, args ...any) ([]domain.TicketMessage, error) {
+	rows, err := conn(ctx, r.pool).Query(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -51,18 +75,67 @@ func (r *ticketMessageRepository) ListByTicket(ctx context.Context, ticketID str
 	var result []domain.TicketMessage
 	for rows.Next() {
 		var msg domain.TicketMessage
-		if err := rows.Scan(
-			&msg.ID,
-			&msg.TicketID,
-			&msg.AuthorType,
-			&msg.AuthorID,
-			&msg.MessageType,
-			&msg.Body,
-			&msg.CreatedAt,
-		); err != nil {
+		if err := scanMessage(rows, &msg); err != nil {
 			return nil, err
 		}
 		result = append(result, msg)
 	}
 	return result, rows.Err()
 }
+
+func (r *ticketMessageRepository) GetByID(ctx context.Context, id string) (*domain.TicketMessage, error) {
+	var msg domain.TicketMessage
+	if err := scanMessage(conn(ctx, r.pool).QueryRow(ctx, `SELECT `+messageColumns+` FROM ticket_messages WHERE id=$1`, id), &msg); err != nil {
+		return nil, err
+	}
+	return &msg, nil
+}
+
+func (r *ticketMessageRepository) UpdateBody(ctx context.Context, msg *domain.TicketMessage) error {
+	cmd, err := conn(ctx, r.pool).Exec(ctx,
+		`UPDATE ticket_messages SET body=$1, edited_at=$2, redacted_at=$3 WHERE id=$4`,
+		msg.Body, msg.EditedAt, msg.RedactedAt, msg.ID,
+	)
+	if err != nil {
+		return err
+	}
+	if cmd.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+	return nil
+}
+
+func (r *ticketMessageRepository) MoveToTicket(ctx context.Context, fromTicketID, toTicketID string) (int, error) {
+	cmd, err := conn(ctx, r.pool).Exec(ctx, `UPDATE ticket_messages SET ticket_id=$1 WHERE ticket_id=$2`, toTicketID, fromTicketID)
+	if err != nil {
+		return 0, err
+	}
+	return int(cmd.RowsAffected()), nil
+}
+
+func (r *ticketMessageRepository) MoveMessages(ctx context.Context, fromTicketID, toTicketID string, messageIDs []string) (int, error) {
+	cmd, err := conn(ctx, r.pool).Exec(ctx,
+		`UPDATE ticket_messages SET ticket_id=$1 WHERE ticket_id=$2 AND id = ANY($3::uuid[])`,
+		toTicketID, fromTicketID, messageIDs,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return int(cmd.RowsAffected()), nil
+}
+
+func scanMessage(row pgx.Row, msg *domain.TicketMessage) error {
+	return row.Scan(
+		&msg.ID,
+		&msg.TicketID,
+		&msg.AuthorType,
+		&msg.AuthorID,
+		&msg.MessageType,
+		&msg.Body,
+		&msg.BodyFormat,
+		&msg.CreatedAt,
+		&msg.EditedAt,
+		&msg.RedactedAt,
+		&msg.CopiedFromID,
+	)
+}
@@ -0,0 +1,126 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/spec-kit/ticket-service/internal/domain"
+)
+
+// TicketParticipantRepository persists ticket watchers and CC participants.
+type TicketParticipantRepository interface {
+	Create(ctx context.Context, participant *domain.TicketParticipant) error
+	GetByID(ctx context.Context, id string) (*domain.TicketParticipant, error)
+	Delete(ctx context.Context, id string) error
+	ListByTicket(ctx context.Context, ticketID string) ([]domain.TicketParticipant, error)
+	// IsUserCC reports whether the user account is copied on the ticket.
+	IsUserCC(ctx context.Context, ticketID, userID string) (bool, error)
+	// CopyToTicket adds the participants of one ticket to another, skipping ones already present.
+	CopyToTicket(ctx context.Context, fromTicketID, toTicketID string) (int, error)
+}
+
+const ticketParticipantColumns = `id, ticket_id, role, staff_id, user_id, email, added_by_type, added_by_id, created_at`
+
+type ticketParticipantRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewTicketParticipantRepository constructs repository.
+func NewTicketParticipantRepository(pool *pgxpool.Pool) TicketParticipantRepository {
+	return &ticketParticipantRepository{pool: pool}
+}
+
+func (r *ticketParticipantRepository) Create(ctx context.Context, participant *domain.TicketParticipant) error {
+	const query = `
+        INSERT INTO ticket_participants (ticket_id, role, staff_id, user_id, email, added_by_type, added_by_id)
+        VALUES ($1,$2,$3,$4,$5,$6,$7)
+        RETURNING id, created_at`
+	return conn(ctx, r.pool).QueryRow(ctx, query,
+		participant.TicketID,
+		participant.Role,
+		participant.StaffID,
+		participant.UserID,
+		participant.Email,
+		participant.AddedByType,
+		participant.AddedByID,
+	).Scan(&participant.ID, &participant.CreatedAt)
+}
+
+func (r *ticketParticipantRepository) GetByID(ctx context.Context, id string) (*domain.TicketParticipant, error) {
+	query := `SELECT ` + ticketParticipantColumns + ` FROM ticket_participants WHERE id=$1`
+	var participant domain.TicketParticipant
+	if err := scanTicketParticipant(conn(ctx, r.pool).QueryRow(ctx, query, id), &participant); err != nil {
+		return nil, err
+	}
+	return &participant, nil
+}
+
+func (r *ticketParticipantRepository) Delete(ctx context.Context, id string) error {
+	cmd, err := conn(ctx, r.pool).Exec(ctx, `DELETE FROM ticket_participants WHERE id=$1`, id)
+	if err != nil {
+		return err
+	}
+	if cmd.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+	return nil
+}
+
+func (r *ticketParticipantRepository) ListByTicket(ctx context.Context, ticketID string) ([]domain.TicketParticipant, error) {
+	query := `SELECT ` + ticketParticipantColumns + ` FROM ticket_participants
+        WHERE ticket_id=$1
+        ORDER BY role, created_at, id`
+	rows, err := conn(ctx, r.pool).Query(ctx, query, ticketID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []domain.TicketParticipant
+	for rows.Next() {
+		var participant domain.TicketParticipant
+		if err := scanTicketParticipant(rows, &participant); err != nil {
+			return nil, err
+		}
+		result = append(result, participant)
+	}
+	return result, rows.Err()
+}
+
+func (r *ticketParticipantRepository) IsUserCC(ctx context.Context, ticketID, userID string) (bool, error) {
+	var exists bool
+	err := conn(ctx, r.pool).QueryRow(ctx,
+		`SELECT EXISTS (SELECT 1 FROM ticket_participants WHERE ticket_id=$1 AND user_id=$2 AND role=$3)`,
+		ticketID, userID, domain.ParticipantRoleCC,
+	).Scan(&exists)
+	return exists, err
+}
+
+func (r *ticketParticipantRepository) CopyToTicket(ctx context.Context, fromTicketID, toTicketID string) (int, error) {
+	const query = `
+        INSERT INTO ticket_participants (ticket_id, role, staff_id, user_id, email, added_by_type, added_by_id)
+        SELECT $2, role, staff_id, user_id, email, added_by_type, added_by_id
+        FROM ticket_participants WHERE ticket_id=$1
+        ON CONFLICT DO NOTHING`
+	cmd, err := conn(ctx, r.pool).Exec(ctx, query, fromTicketID, toTicketID)
+	if err != nil {
+		return 0, err
+	}
+	return int(cmd.RowsAffected()), nil
+}
+
+func scanTicketParticipant(row pgx.Row, participant *domain.TicketParticipant) error {
+	return row.Scan(
+		&participant.ID,
+		&participant.TicketID,
+		&participant.Role,
+		&participant.StaffID,
+		&participant.UserID,
+		&participant.Email,
+		&participant.AddedByType,
+		&participant.AddedByID,
+		&participant.CreatedAt,
+	)
+}
@@ -3,6 +3,7 @@ package repository
 import (
 	"context"
 	"fmt"
+	"html"
 	"strings"
 	"time"
 
@@ -20,13 +21,45 @@ type TicketFilter struct {
 	AssigneeID   *string
 	Statuses     []domain.TicketStatus
 	Priorities   []domain.TicketPriority
-	SearchTerm   *string
+	Search       *TicketSearch
 	CreatedFrom  *time.Time
 	CreatedTo    *time.Time
 	UpdatedFrom  *time.Time
 	UpdatedTo    *time.Time
+	// CustomFields matches tickets whose custom_fields JSON contains every given value.
+	CustomFields map[string]any
+	// TagsAny, TagsAll and TagsNone match tickets carrying any, every or none of the listed tags.
+	TagsAny  []string
+	TagsAll  []string
+	TagsNone []string
+	// Sort overrides the default ordering (relevance for text searches, otherwise most recently updated).
+	Sort   []TicketSort
+	Limit  int
+	Cursor *Cursor
+}
+
+// TicketSearch is a parsed full-text query. Text uses web search syntax (quoted phrases, -negation, OR);
+// field prefixes are split out into the tag, status and priority lists.
+type TicketSearch struct {
+	Text string
+	// IncludeInternal searches internal notes as well as public content.
+	IncludeInternal   bool
+	Tags              []string
+	ExcludeTags       []string
+	Statuses          []string
+	ExcludeStatuses   []string
+	Priorities        []domain.TicketPriority
+	ExcludePriorities []domain.TicketPriority
+}
+
+// IdleTicketFilter selects tickets in a status with no activity since a cutoff.
+type IdleTicketFilter struct {
+	Status         domain.TicketStatus
+	InactiveBefore time.Time
+	// Warned selects tickets whose auto-close warning is current (true) or missing/stale (false); nil ignores warnings.
+	Warned       *bool
+	WarnedBefore *time.Time
 	Limit        int
-	Offset       int
 }
 
 // TicketRepository encapsulates ticket persistence.
@@ -35,10 +68,25 @@ type TicketRepository interface {
 	Update(ctx context.Context, ticket *domain.Ticket) error
 	GetByID(ctx context.Context, id string) (*domain.Ticket, error)
 	GetByExternalKey(ctx context.Context, key string) (*domain.Ticket, error)
-	ListByUser(ctx context.Context, userID string, limit, offset int) ([]domain.Ticket, error)
-	ListWithFilter(ctx context.Context, filter TicketFilter) ([]domain.Ticket, error)
+	ListByUser(ctx context.Context, userID string, limit int, cursor *Cursor) (Page[domain.Ticket], error)
+	ListWithFilter(ctx context.Context, filter TicketFilter) (Page[domain.Ticket], error)
+	Search(ctx context.Context, filter TicketFilter) (Page[domain.TicketSearchHit], error)
+	// Count returns how many tickets match the filter, ignoring sort and paging.
+	Count(ctx context.Context, filter TicketFilter) (int, error)
+	ListIdle(ctx context.Context, filter IdleTicketFilter) ([]domain.Ticket, error)
+	MarkAutoCloseWarned(ctx context.Context, ticketID string, at time.Time) error
+	// CloseIdle writes the ticket's status, workflow status and closed_at only while the row still has
+	// oldStatus and seenUpdatedAt and no activity after inactiveBefore, reporting whether it did.
+	CloseIdle(ctx context.Context, ticket *domain.Ticket, oldStatus domain.TicketStatus, seenUpdatedAt, inactiveBefore time.Time) (bool, error)
+	// RedactDescriptions replaces segments in the description of the ticket and of the tickets merged into it.
+	RedactDescriptions(ctx context.Context, ticketID string, segments []string, replacement string) error
 }
 
+// ticketColumns is the column list matched by scanTicket.
+const ticketColumns = `id, external_key, requester_user_id, department_id, team_id, assignee_staff_id,
+               title, description, status, priority, tags, created_at, updated_at, closed_at, sla_due_at, workflow_status, custom_fields,
+               merged_into_ticket_id`
+
 type ticketRepository struct {
 	pool *pgxpool.Pool
 }
@@ -50,10 +98,10 @@ func NewTicketRepository(pool *pgxpool.Pool) TicketRepository {
 
 func (r *ticketRepository) Create(ctx context.Context, ticket *domain.Ticket) error {
 	const query = `
-        INSERT INTO tickets (external_key, requester_user_id, department_id, team_id, assignee_staff_id, title, description, status, priority, tags)
-        VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10)
+        INSERT INTO tickets (external_key, requester_user_id, department_id, team_id, assignee_staff_id, title, description, status, priority, tags, sla_due_at, workflow_status, custom_fields)
+        VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,COALESCE($13::jsonb, '{}'::jsonb))
         RETURNING id, created_at, updated_at`
-	return r.pool.QueryRow(ctx, query,
+	return conn(ctx, r.pool).QueryRow(ctx, query,
 		ticket.ExternalKey,
 		ticket.RequesterID,
 		ticket.DepartmentID,
@@ -64,15 +112,19 @@ func (r *ticketRepository) Create(ctx context.Context, ticket *domain.Ticket) er
 		ticket.Status,
 		ticket.Priority,
 		ticket.Tags,
+		ticket.SLADueAt,
+		ticket.WorkflowStatus,
+		ticket.CustomFields,
 	).Scan(&ticket.ID, &ticket.CreatedAt, &ticket.UpdatedAt)
 }
 
 func (r *ticketRepository) Update(ctx context.Context, ticket *domain.Ticket) error {
 	const query = `
         UPDATE tickets SET department_id=$1, team_id=$2, assignee_staff_id=$3, title=$4, description=$5,
-            status=$6, priority=$7, tags=$8, closed_at=$9, updated_at=NOW()
-        WHERE id=$10`
-	cmd, err := r.pool.Exec(ctx, query,
+            status=$6, priority=$7, tags=$8, closed_at=$9, sla_due_at=$10, workflow_status=$11,
+            custom_fields=COALESCE($12::jsonb, '{}'::jsonb), merged_into_ticket_id=$13, updated_at=NOW()
+        WHERE id=$14`
+	cmd, err := conn(ctx, r.pool).Exec(ctx, query,
 		ticket.DepartmentID,
 		ticket.TeamID,
 		ticket.AssigneeID,
@@ -82,6 +134,10 @@ func (r *ticketRepository) Update(ctx context.Context, ticket *domain.Ticket) er
 		ticket.Priority,
 		ticket.Tags,
 		ticket.ClosedAt,
+		ticket.SLADueAt,
+		ticket.WorkflowStatus,
+		ticket.CustomFields,
+		ticket.MergedIntoID,
 		ticket.ID,
 	)
 	if err != nil {
@@ -95,56 +151,161 @@ func (r *ticketRepository) Update(ctx context.Context, ticket *domain.Ticket) er
 
 func (r *ticketRepository) GetByID(ctx context.Context, id string) (*domain.Ticket, error) {
 	const query = `
-        SELECT id, external_key, requester_user_id, department_id, team_id, assignee_staff_id,
-               title, description, status, priority, tags, created_at, updated_at, closed_at
+        SELECT ` + ticketColumns + `
         FROM tickets WHERE id=$1`
 	return r.fetchSingle(ctx, query, id)
 }
 
 func (r *ticketRepository) GetByExternalKey(ctx context.Context, key string) (*domain.Ticket, error) {
 	const query = `
-        SELECT id, external_key, requester_user_id, department_id, team_id, assignee_staff_id,
-               title, description, status, priority, tags, created_at, updated_at, closed_at
+        SELECT ` + ticketColumns + `
         FROM tickets WHERE external_key=$1`
 	return r.fetchSingle(ctx, query, key)
 }
 
 func (r *ticketRepository) fetchSingle(ctx context.Context, query string, arg any) (*domain.Ticket, error) {
 	var ticket domain.Ticket
-	if err := r.pool.QueryRow(ctx, query, arg).Scan(
-		&ticket.ID,
-		&ticket.ExternalKey,
-		&ticket.RequesterID,
-		&ticket.DepartmentID,
-		&ticket.TeamID,
-		&ticket.AssigneeID,
-		&ticket.Title,
-		&ticket.Description,
-		&ticket.Status,
-		&ticket.Priority,
-		&ticket.Tags,
-		&ticket.CreatedAt,
-		&ticket.UpdatedAt,
-		&ticket.ClosedAt,
-	); err != nil {
+	if err := scanTicket(conn(ctx, r.pool).QueryRow(ctx, query, arg), &ticket); err != nil {
 		return nil, err
 	}
 	return &ticket, nil
 }
 
-func (r *ticketRepository) ListByUser(ctx context.Context, userID string, limit, offset int) ([]domain.Ticket, error) {
+func (r *ticketRepository) ListByUser(ctx context.Context, userID string, limit int, cursor *Cursor) (Page[domain.Ticket], error) {
 	filter := TicketFilter{
 		RequesterID: &userID,
 		Limit:       limit,
-		Offset:      offset,
+		Cursor:      cursor,
 	}
 	return r.ListWithFilter(ctx, filter)
 }
 
-func (r *ticketRepository) ListWithFilter(ctx context.Context, filter TicketFilter) ([]domain.Ticket, error) {
-	base := `SELECT id, external_key, requester_user_id, department_id, team_id, assignee_staff_id,
-                    title, description, status, priority, tags, created_at, updated_at, closed_at
-             FROM tickets`
+func (r *ticketRepository) ListWithFilter(ctx context.Context, filter TicketFilter) (Page[domain.Ticket], error) {
+	q := buildTicketFilter(filter)
+	total, err := r.count(ctx, q)
+	if err != nil {
+		return Page[domain.Ticket]{}, err
+	}
+	rows, err := conn(ctx, r.pool).Query(ctx, q.pageSQL(ticketColumns), q.pageArgs...)
+	if err != nil {
+		return Page[domain.Ticket]{}, err
+	}
+	defer rows.Close()
+	tickets, err := scanTickets(rows)
+	if err != nil {
+		return Page[domain.Ticket]{}, err
+	}
+	return keysetPage(tickets, q.limit, total, func(t domain.Ticket) Cursor { return q.nextCursor(t) }), nil
+}
+
+// Snippet match delimiters. ts_headline does not escape the text it returns, so matches are marked
+// with control characters and turned into <mark> tags only after the snippet is HTML-escaped.
+const (
+	snippetStartSel = "\x02"
+	snippetStopSel  = "\x03"
+)
+
+// Search runs a filtered listing and also returns relevance and a highlighted snippet per ticket.
+func (r *ticketRepository) Search(ctx context.Context, filter TicketFilter) (Page[domain.TicketSearchHit], error) {
+	q := buildTicketFilter(filter)
+	total, err := r.count(ctx, q)
+	if err != nil {
+		return Page[domain.TicketSearchHit]{}, err
+	}
+	columns := ticketColumns + ", 0::real, ''"
+	if q.tsquery != "" {
+		columns = fmt.Sprintf(`%s, ts_rank_cd(%s, %s), ts_headline('english', %s, %s,
+            'StartSel=%s, StopSel=%s, MaxWords=35, MinWords=15, MaxFragments=2')`,
+			ticketColumns, q.vector, q.tsquery, q.document, q.tsquery, snippetStartSel, snippetStopSel)
+	}
+	rows, err := conn(ctx, r.pool).Query(ctx, q.pageSQL(columns), q.pageArgs...)
+	if err != nil {
+		return Page[domain.TicketSearchHit]{}, err
+	}
+	defer rows.Close()
+
+	var hits []domain.TicketSearchHit
+	for rows.Next() {
+		var hit domain.TicketSearchHit
+		if err := scanTicket(rows, &hit.Ticket, &hit.Rank, &hit.Snippet); err != nil {
+			return Page[domain.TicketSearchHit]{}, err
+		}
+		hit.Snippet = highlightSnippet(hit.Snippet)
+		hits = append(hits, hit)
+	}
+	if err := rows.Err(); err != nil {
+		return Page[domain.TicketSearchHit]{}, err
+	}
+	return keysetPage(hits, q.limit, total, func(h domain.TicketSearchHit) Cursor { return q.nextCursor(h.Ticket) }), nil
+}
+
+func (r *ticketRepository) Count(ctx context.Context, filter TicketFilter) (int, error) {
+	return r.count(ctx, buildTicketFilter(filter))
+}
+
+func (r *ticketRepository) count(ctx context.Context, q ticketQuery) (int, error) {
+	var total int
+	err := conn(ctx, r.pool).QueryRow(ctx, `SELECT COUNT(*) FROM tickets WHERE `+q.where, q.args...).Scan(&total)
+	return total, err
+}
+
+// ticketQuery is a rendered ticket filter. where/args select every match (used for totals);
+// pageWhere/pageArgs additionally resume after the cursor.
+type ticketQuery struct {
+	where     string
+	args      []any
+	pageWhere string
+	pageArgs  []any
+	// tsquery, vector and document are set when the filter includes full-text terms; results are
+	// then ranked and snippets are cut from document.
+	tsquery  string
+	vector   string
+	document string
+	sort     []TicketSort
+	limit    int
+	offset   int
+}
+
+func (q ticketQuery) ranked() bool {
+	return q.tsquery != ""
+}
+
+// offsetPaged reports whether pages resume by offset rather than after the last row's keys. Only
+// relevance ordering, which is not stored on the row, needs an offset.
+func (q ticketQuery) offsetPaged() bool {
+	return q.ranked() && len(q.sort) == 0
+}
+
+func (q ticketQuery) pageSQL(columns string) string {
+	order := "updated_at DESC, id DESC"
+	switch {
+	case len(q.sort) > 0:
+		order = ticketOrderBy(q.sort)
+	case q.ranked():
+		order = fmt.Sprintf("ts_rank_cd(%s, %s) DESC, updated_at DESC, id DESC", q.vector, q.tsquery)
+	}
+	// one extra row tells keysetPage whether another page exists
+	return fmt.Sprintf(`SELECT %s FROM tickets WHERE %s ORDER BY %s LIMIT %d OFFSET %d`,
+		columns, q.pageWhere, order, q.limit+1, q.offset)
+}
+
+func (q ticketQuery) nextCursor(last domain.Ticket) Cursor {
+	if q.offsetPaged() {
+		return Cursor{Offset: q.offset + q.limit}
+	}
+	if len(q.sort) > 0 {
+		keys := make([]*string, 0, len(q.sort))
+		for _, sort := range q.sort {
+			keys = append(keys, ticketSortValue(last, sort.Field))
+		}
+		return Cursor{ID: last.ID, Keys: keys}
+	}
+	return Cursor{Time: last.UpdatedAt, ID: last.ID}
+}
+
+// buildTicketFilter renders the filter into SQL clauses and bound arguments.
+func buildTicketFilter(filter TicketFilter) ticketQuery {
+	q := ticketQuery{limit: pageSize(filter.Limit), sort: filter.Sort}
 	clauses := []string{"1=1"}
 	args := []any{}
 
@@ -196,26 +357,125 @@ func (r *ticketRepository) ListWithFilter(ctx context.Context, filter TicketFilt
 		args = append(args, *filter.UpdatedTo)
 		clauses = append(clauses, fmt.Sprintf("updated_at <= $%d", len(args)))
 	}
-	if filter.SearchTerm != nil && strings.TrimSpace(*filter.SearchTerm) != "" {
-		search := "%" + strings.ToLower(strings.TrimSpace(*filter.SearchTerm)) + "%"
-		args = append(args, search)
-		placeholder := fmt.Sprintf("$%d", len(args))
-		clauses = append(clauses, fmt.Sprintf("(LOWER(title) LIKE %s OR LOWER(description) LIKE %s)", placeholder, placeholder))
+	if len(filter.CustomFields) > 0 {
+		args = append(args, filter.CustomFields)
+		clauses = append(clauses, fmt.Sprintf("custom_fields @> $%d::jsonb", len(args)))
+	}
+	if len(filter.TagsAny) > 0 {
+		args = append(args, filter.TagsAny)
+		clauses = append(clauses, fmt.Sprintf("tags && $%d::text[]", len(args)))
+	}
+	if len(filter.TagsAll) > 0 {
+		args = append(args, filter.TagsAll)
+		clauses = append(clauses, fmt.Sprintf("tags @> $%d::text[]", len(args)))
+	}
+	if len(filter.TagsNone) > 0 {
+		args = append(args, filter.TagsNone)
+		clauses = append(clauses, fmt.Sprintf("NOT (tags && $%d::text[])", len(args)))
 	}
 
-	limit := filter.Limit
-	if limit <= 0 {
-		limit = 20
+	if search := filter.Search; search != nil {
+		if len(search.Tags) > 0 {
+			args = append(args, search.Tags)
+			clauses = append(clauses, fmt.Sprintf("tags @> $%d::text[]", len(args)))
+		}
+		if len(search.ExcludeTags) > 0 {
+			args = append(args, search.ExcludeTags)
+			clauses = append(clauses, fmt.Sprintf("NOT (tags && $%d::text[])", len(args)))
+		}
+		if len(search.Statuses) > 0 {
+			args = append(args, search.Statuses)
+			clauses = append(clauses, fmt.Sprintf("(status::text = ANY($%d::text[]) OR workflow_status = ANY($%d::text[]))", len(args), len(args)))
+		}
+		if len(search.ExcludeStatuses) > 0 {
+			args = append(args, search.ExcludeStatuses)
+			clauses = append(clauses, fmt.Sprintf("NOT (status::text = ANY($%d::text[]) OR COALESCE(workflow_status = ANY($%d::text[]), FALSE))", len(args), len(args)))
+		}
+		if len(search.Priorities) > 0 {
+			args = append(args, prioritiesToStrings(search.Priorities))
+			clauses = append(clauses, fmt.Sprintf("priority::text = ANY($%d::text[])", len(args)))
+		}
+		if len(search.ExcludePriorities) > 0 {
+			args = append(args, prioritiesToStrings(search.ExcludePriorities))
+			clauses = append(clauses, fmt.Sprintf("NOT (priority::text = ANY($%d::text[]))", len(args)))
+		}
+		if text := strings.TrimSpace(search.Text); text != "" {
+			args = append(args, text)
+			q.vector = searchVectorColumn(search)
+			q.document = searchDocument(search)
+			q.tsquery = fmt.Sprintf("websearch_to_tsquery('english', $%d)", len(args))
+			clauses = append(clauses, fmt.Sprintf("%s @@ %s", q.vector, q.tsquery))
+		}
 	}
-	offset := filter.Offset
-	if offset < 0 {
-		offset = 0
+	q.where = strings.Join(clauses, " AND ")
+	q.args = args
+	q.pageWhere, q.pageArgs = q.where, args
+	if cursor := filter.Cursor; cursor != nil {
+		if q.offsetPaged() {
+			q.offset = cursor.Offset
+		} else if cursor.ID != "" && len(q.sort) > 0 {
+			var predicate string
+			predicate, q.pageArgs = ticketKeysetPredicate(q.sort, cursor.Keys, cursor.ID, append([]any{}, args...))
+			q.pageWhere = q.where + " AND " + predicate
+		} else if cursor.ID != "" {
+			q.pageArgs = append(append([]any{}, args...), cursor.Time, cursor.ID)
+			q.pageWhere = fmt.Sprintf("%s AND (updated_at, id) < ($%d, $%d)", q.where, len(q.pageArgs)-1, len(q.pageArgs))
+		}
 	}
+	return q
+}
+
+// searchDocument is the text snippets are cut from. Like ticket_search_document it covers the
+// title, description and replies, plus internal notes when they are searched; HTML bodies are
+// reduced to their text.
+func searchDocument(search *TicketSearch) string {
+	types := "'PUBLIC_REPLY'"
+	if search.IncludeInternal {
+		types += ", 'INTERNAL_NOTE'"
+	}
+	return fmt.Sprintf(`title || ' ' || description || ' ' || COALESCE((
+            SELECT string_agg(CASE WHEN m.body_format = 'html' THEN regexp_replace(m.body, '<[^>]*>', ' ', 'g') ELSE m.body END, ' ' ORDER BY m.created_at)
+            FROM ticket_messages m WHERE m.ticket_id = tickets.id AND m.message_type IN (%s)), '')`, types)
+}
+
+// highlightSnippet escapes a ts_headline result and wraps its matches in <mark> tags.
+func highlightSnippet(raw string) string {
+	escaped := html.EscapeString(raw)
+	escaped = strings.ReplaceAll(escaped, snippetStartSel, "<mark>")
+	return strings.ReplaceAll(escaped, snippetStopSel, "</mark>")
+}
+
+func searchVectorColumn(search *TicketSearch) string {
+	if search.IncludeInternal {
+		return "staff_search_vector"
+	}
+	return "search_vector"
+}
 
-	query := fmt.Sprintf(`%s WHERE %s ORDER BY updated_at DESC LIMIT %d OFFSET %d`,
-		base, strings.Join(clauses, " AND "), limit, offset)
+// lastActivityExpr is the later of the ticket's own update time and its newest message.
+const lastActivityExpr = `GREATEST(updated_at, COALESCE((SELECT MAX(m.created_at) FROM ticket_messages m WHERE m.ticket_id=tickets.id), updated_at))`
 
-	rows, err := r.pool.Query(ctx, query, args...)
+func (r *ticketRepository) ListIdle(ctx context.Context, filter IdleTicketFilter) ([]domain.Ticket, error) {
+	args := []any{filter.Status, filter.InactiveBefore}
+	clauses := []string{"status=$1", lastActivityExpr + " <= $2"}
+	if filter.Warned != nil {
+		if *filter.Warned {
+			clauses = append(clauses, "auto_close_warned_at IS NOT NULL", "auto_close_warned_at >= "+lastActivityExpr)
+		} else {
+			clauses = append(clauses, "(auto_close_warned_at IS NULL OR auto_close_warned_at < "+lastActivityExpr+")")
+		}
+	}
+	if filter.WarnedBefore != nil {
+		args = append(args, *filter.WarnedBefore)
+		clauses = append(clauses, fmt.Sprintf("auto_close_warned_at <= $%d", len(args)))
+	}
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = 100
+	}
+	query := fmt.Sprintf(`SELECT %s FROM tickets WHERE %s ORDER BY updated_at ASC LIMIT %d`,
+		ticketColumns, strings.Join(clauses, " AND "), limit)
+	rows, err := conn(ctx, r.pool).Query(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -223,29 +483,84 @@ func (r *ticketRepository) ListWithFilter(ctx context.Context, filter TicketFilt
 	return scanTickets(rows)
 }
 
+// MarkAutoCloseWarned stamps the warning time without touching updated_at, so it does not count as activity.
+func (r *ticketRepository) MarkAutoCloseWarned(ctx context.Context, ticketID string, at time.Time) error {
+	const query = `UPDATE tickets SET auto_close_warned_at=$1 WHERE id=$2`
+	cmd, err := conn(ctx, r.pool).Exec(ctx, query, at, ticketID)
+	if err != nil {
+		return err
+	}
+	if cmd.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+	return nil
+}
+
+func (r *ticketRepository) CloseIdle(ctx context.Context, ticket *domain.Ticket, oldStatus domain.TicketStatus, seenUpdatedAt, inactiveBefore time.Time) (bool, error) {
+	const query = `
+        UPDATE tickets SET status=$1, workflow_status=$2, closed_at=$3, updated_at=NOW()
+        WHERE id=$4 AND status=$5 AND updated_at=$6 AND ` + lastActivityExpr + ` <= $7`
+	cmd, err := conn(ctx, r.pool).Exec(ctx, query,
+		ticket.Status,
+		ticket.WorkflowStatus,
+		ticket.ClosedAt,
+		ticket.ID,
+		oldStatus,
+		seenUpdatedAt,
+		inactiveBefore,
+	)
+	if err != nil {
+		return false, err
+	}
+	return cmd.RowsAffected() > 0, nil
+}
+
+func (r *ticketRepository) RedactDescriptions(ctx context.Context, ticketID string, segments []string, replacement string) error {
+	for _, segment := range segments {
+		if _, err := conn(ctx, r.pool).Exec(ctx,
+			`UPDATE tickets SET description = replace(description, $2, $3)
+             WHERE (id=$1 OR merged_into_ticket_id=$1) AND strpos(description, $2) > 0`,
+			ticketID, segment, replacement,
+		); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func scanTickets(rows pgx.Rows) ([]domain.Ticket, error) {
 	var result []domain.Ticket
 	for rows.Next() {
 		var ticket domain.Ticket
-		if err := rows.Scan(
-			&ticket.ID,
-			&ticket.ExternalKey,
-			&ticket.RequesterID,
-			&ticket.DepartmentID,
-			&ticket.TeamID,
-			&ticket.AssigneeID,
-			&ticket.Title,
-			&ticket.Description,
-			&ticket.Status,
-			&ticket.Priority,
-			&ticket.Tags,
-			&ticket.CreatedAt,
-			&ticket.UpdatedAt,
-			&ticket.ClosedAt,
-		); err != nil {
+		if err := scanTicket(rows, &ticket); err != nil {
 			return nil, err
 		}
 		result = append(result, ticket)
 	}
 	return result, rows.Err()
 }
+
+// scanTicket reads a row selected with ticketColumns, followed by any extra columns.
+func scanTicket(row pgx.Row, ticket *domain.Ticket, extra ...any) error {
+	dest := []any{
+		&ticket.ID,
+		&ticket.ExternalKey,
+		&ticket.RequesterID,
+		&ticket.DepartmentID,
+		&ticket.TeamID,
+		&ticket.AssigneeID,
+		&ticket.Title,
+		&ticket.Description,
+		&ticket.Status,
+		&ticket.Priority,
+		&ticket.Tags,
+		&ticket.CreatedAt,
+		&ticket.UpdatedAt,
+		&ticket.ClosedAt,
+		&ticket.SLADueAt,
+		&ticket.WorkflowStatus,
+		&ticket.CustomFields,
+		&ticket.MergedIntoID,
+	}
+	return row.Scan(append(dest, extra...)...)
+}
@@ -0,0 +1,225 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spec-kit/ticket-service/internal/domain"
+)
+
+// TicketSortField names a column tickets can be ordered by.
+type TicketSortField string
+
+const (
+	TicketSortPriority    TicketSortField = "priority"
+	TicketSortSLADueAt    TicketSortField = "sla_due_at"
+	TicketSortStatus      TicketSortField = "status"
+	TicketSortCreatedAt   TicketSortField = "created_at"
+	TicketSortUpdatedAt   TicketSortField = "updated_at"
+	TicketSortExternalKey TicketSortField = "external_key"
+)
+
+// maxTicketSortKeys bounds how many keys a single listing may sort by.
+const maxTicketSortKeys = 4
+
+// ErrInvalidSort is returned when a sort parameter names an unknown or repeated field.
+var ErrInvalidSort = errors.New("invalid sort")
+
+// ticketSortExpressions whitelists the SQL each sort field renders to; nothing else reaches ORDER BY.
+var ticketSortExpressions = map[TicketSortField]string{
+	TicketSortPriority:    `CASE priority WHEN 'LOW' THEN 1 WHEN 'MEDIUM' THEN 2 WHEN 'HIGH' THEN 3 WHEN 'URGENT' THEN 4 END`,
+	TicketSortSLADueAt:    "sla_due_at",
+	TicketSortStatus:      "status",
+	TicketSortCreatedAt:   "created_at",
+	TicketSortUpdatedAt:   "updated_at",
+	TicketSortExternalKey: "external_key",
+}
+
+// ticketSortCasts gives the SQL type cursor values are bound as for each sort field.
+var ticketSortCasts = map[TicketSortField]string{
+	TicketSortPriority:    "int",
+	TicketSortSLADueAt:    "timestamptz",
+	TicketSortStatus:      "ticket_status",
+	TicketSortCreatedAt:   "timestamptz",
+	TicketSortUpdatedAt:   "timestamptz",
+	TicketSortExternalKey: "text",
+}
+
+// priorityRanks mirrors the priority CASE expression.
+var priorityRanks = map[domain.TicketPriority]int{
+	domain.TicketPriorityLow:    1,
+	domain.TicketPriorityMedium: 2,
+	domain.TicketPriorityHigh:   3,
+	domain.TicketPriorityUrgent: 4,
+}
+
+// TicketSort is one ordering key; priority sorts by severity, so descending puts URGENT first.
+type TicketSort struct {
+	Field TicketSortField
+	Desc  bool
+}
+
+// ParseTicketSort parses a comma-separated list such as "priority,-created_at"; a leading '-' sorts descending.
+func ParseTicketSort(raw string) ([]TicketSort, error) {
+	var sorts []TicketSort
+	seen := map[TicketSortField]struct{}{}
+	for _, part := range strings.Split(raw, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		sort := TicketSort{}
+		if strings.HasPrefix(part, "-") {
+			sort.Desc = true
+			part = part[1:]
+		} else {
+			part = strings.TrimPrefix(part, "+")
+		}
+		sort.Field = TicketSortField(strings.ToLower(part))
+		if _, ok := ticketSortExpressions[sort.Field]; !ok {
+			return nil, fmt.Errorf("%w: unknown field %q", ErrInvalidSort, part)
+		}
+		if _, dup := seen[sort.Field]; dup {
+			return nil, fmt.Errorf("%w: duplicate field %q", ErrInvalidSort, part)
+		}
+		seen[sort.Field] = struct{}{}
+		sorts = append(sorts, sort)
+	}
+	if len(sorts) > maxTicketSortKeys {
+		return nil, fmt.Errorf("%w: at most %d fields", ErrInvalidSort, maxTicketSortKeys)
+	}
+	return sorts, nil
+}
+
+// TicketSortFields lists the accepted sort field names.
+func TicketSortFields() []string {
+	return []string{
+		string(TicketSortPriority),
+		string(TicketSortSLADueAt),
+		string(TicketSortStatus),
+		string(TicketSortCreatedAt),
+		string(TicketSortUpdatedAt),
+		string(TicketSortExternalKey),
+	}
+}
+
+// ticketOrderBy renders sort keys into an ORDER BY list, ending with id so pages are stable.
+// Tickets without an SLA due time always sort last.
+func ticketOrderBy(sorts []TicketSort) string {
+	parts := make([]string, 0, len(sorts)+1)
+	for _, sort := range sorts {
+		expr, ok := ticketSortExpressions[sort.Field]
+		if !ok {
+			continue
+		}
+		dir := "ASC"
+		if sort.Desc {
+			dir = "DESC"
+		}
+		clause := expr + " " + dir
+		if sort.Field == TicketSortSLADueAt {
+			clause += " NULLS LAST"
+		}
+		parts = append(parts, clause)
+	}
+	parts = append(parts, "id DESC")
+	return strings.Join(parts, ", ")
+}
+
+// CheckTicketCursor returns ErrInvalidCursor when a cursor was issued for a different sort or
+// carries values that cannot be bound for it.
+func CheckTicketCursor(cursor *Cursor, sorts []TicketSort) error {
+	if cursor == nil || (len(sorts) == 0 && len(cursor.Keys) == 0) {
+		return nil
+	}
+	if cursor.ID == "" || len(cursor.Keys) != len(sorts) {
+		return ErrInvalidCursor
+	}
+	for i, sort := range sorts {
+		key := cursor.Keys[i]
+		if key == nil {
+			if sort.Field != TicketSortSLADueAt {
+				return ErrInvalidCursor
+			}
+			continue
+		}
+		var err error
+		switch sort.Field {
+		case TicketSortPriority:
+			_, err = strconv.Atoi(*key)
+		case TicketSortSLADueAt, TicketSortCreatedAt, TicketSortUpdatedAt:
+			_, err = time.Parse(time.RFC3339Nano, *key)
+		case TicketSortStatus:
+			switch domain.TicketStatus(*key) {
+			case domain.TicketStatusOpen, domain.TicketStatusInProgress, domain.TicketStatusPendingUser,
+				domain.TicketStatusResolved, domain.TicketStatusClosed, domain.TicketStatusCancelled:
+			default:
+				err = ErrInvalidCursor
+			}
+		}
+		if err != nil {
+			return ErrInvalidCursor
+		}
+	}
+	return nil
+}
+
+// ticketSortValue renders a ticket's value for a sort field as stored in a cursor; nil is NULL.
+func ticketSortValue(ticket domain.Ticket, field TicketSortField) *string {
+	var value string
+	switch field {
+	case TicketSortPriority:
+		value = strconv.Itoa(priorityRanks[ticket.Priority])
+	case TicketSortSLADueAt:
+		if ticket.SLADueAt == nil {
+			return nil
+		}
+		value = ticket.SLADueAt.Format(time.RFC3339Nano)
+	case TicketSortStatus:
+		value = string(ticket.Status)
+	case TicketSortCreatedAt:
+		value = ticket.CreatedAt.Format(time.RFC3339Nano)
+	case TicketSortUpdatedAt:
+		value = ticket.UpdatedAt.Format(time.RFC3339Nano)
+	case TicketSortExternalKey:
+		value = ticket.ExternalKey
+	}
+	return &value
+}
+
+// ticketKeysetPredicate renders the condition selecting rows that follow the cursor row under
+// ticketOrderBy, binding the cursor values after args. Rows follow when an earlier key ties and
+// the next key is past the cursor value; NULL due times sort last in either direction.
+func ticketKeysetPredicate(sorts []TicketSort, keys []*string, id string, args []any) (string, []any) {
+	var branches, ties []string
+	for i, sort := range sorts {
+		expr := ticketSortExpressions[sort.Field]
+		var key *string
+		if i < len(keys) {
+			key = keys[i]
+		}
+		if key == nil {
+			// only other NULLs can follow a NULL
+			ties = append(ties, expr+" IS NULL")
+			continue
+		}
+		args = append(args, *key)
+		value := fmt.Sprintf("$%d::%s", len(args), ticketSortCasts[sort.Field])
+		op := ">"
+		if sort.Desc {
+			op = "<"
+		}
+		past := fmt.Sprintf("%s %s %s", expr, op, value)
+		if sort.Field == TicketSortSLADueAt {
+			past = fmt.Sprintf("(%s OR %s IS NULL)", past, expr)
+		}
+		branches = append(branches, "("+strings.Join(append(append([]string{}, ties...), past), " AND ")+")")
+		ties = append(ties, fmt.Sprintf("%s = %s", expr, value))
+	}
+	args = append(args, id)
+	branches = append(branches, "("+strings.Join(append(ties, fmt.Sprintf("id < $%d", len(args))), " AND ")+")")
+	return "(" + strings.Join(branches, " OR ") + ")", args
+}
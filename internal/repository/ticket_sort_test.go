@@ -0,0 +1,179 @@
+package repository
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/spec-kit/ticket-service/internal/domain"
+)
+
+func TestParseTicketSort(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  []TicketSort
+	}{
+		{"empty", "", nil},
+		{"single ascending", "priority", []TicketSort{{Field: TicketSortPriority}}},
+		{"descending and explicit ascending", "-created_at,+external_key", []TicketSort{{Field: TicketSortCreatedAt, Desc: true}, {Field: TicketSortExternalKey}}},
+		{"spaces, case and empty parts", " SLA_DUE_AT , ,-Status ", []TicketSort{{Field: TicketSortSLADueAt}, {Field: TicketSortStatus, Desc: true}}},
+		{"four keys", "priority,status,created_at,updated_at", []TicketSort{{Field: TicketSortPriority}, {Field: TicketSortStatus}, {Field: TicketSortCreatedAt}, {Field: TicketSortUpdatedAt}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseTicketSort(tt.input)
+			if err != nil {
+				t.Fatalf("ParseTicketSort(%q) err = %v", tt.input, err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseTicketSort(%q) = %+v, want %+v", tt.input, got, tt.want)
+			}
+		})
+	}
+
+	for _, input := range []string{
+		"title",
+		"priority;DROP TABLE tickets",
+		"--priority",
+		"priority,-priority",
+		"priority,status,created_at,updated_at,external_key",
+	} {
+		if _, err := ParseTicketSort(input); !errors.Is(err, ErrInvalidSort) {
+			t.Errorf("ParseTicketSort(%q) err = %v, want ErrInvalidSort", input, err)
+		}
+	}
+}
+
+func TestTicketOrderBy(t *testing.T) {
+	got := ticketOrderBy([]TicketSort{{Field: TicketSortSLADueAt, Desc: true}, {Field: TicketSortExternalKey}})
+	if want := "sla_due_at DESC NULLS LAST, external_key ASC, id DESC"; got != want {
+		t.Errorf("ticketOrderBy = %q, want %q", got, want)
+	}
+	if got := ticketOrderBy(nil); got != "id DESC" {
+		t.Errorf("ticketOrderBy(nil) = %q", got)
+	}
+}
+
+func TestTicketKeysetPredicate(t *testing.T) {
+	priority := ticketSortExpressions[TicketSortPriority]
+	tests := []struct {
+		name     string
+		sorts    []TicketSort
+		keys     []*string
+		args     []any
+		want     string
+		wantArgs []any
+	}{
+		{
+			name:     "single key after filter args",
+			sorts:    []TicketSort{{Field: TicketSortCreatedAt}},
+			keys:     []*string{ptr("2026-01-02T03:04:05Z")},
+			args:     []any{"dept-1"},
+			want:     "((created_at > $2::timestamptz) OR (created_at = $2::timestamptz AND id < $3))",
+			wantArgs: []any{"dept-1", "2026-01-02T03:04:05Z", "t1"},
+		},
+		{
+			name:     "descending priority by rank",
+			sorts:    []TicketSort{{Field: TicketSortPriority, Desc: true}},
+			keys:     []*string{ptr("3")},
+			want:     "((" + priority + " < $1::int) OR (" + priority + " = $1::int AND id < $2))",
+			wantArgs: []any{"3", "t1"},
+		},
+		{
+			name:  "due time ties then key",
+			sorts: []TicketSort{{Field: TicketSortSLADueAt, Desc: true}, {Field: TicketSortExternalKey}},
+			keys:  []*string{ptr("2026-01-02T03:04:05Z"), ptr("TCK-9")},
+			want: "(((sla_due_at < $1::timestamptz OR sla_due_at IS NULL))" +
+				" OR (sla_due_at = $1::timestamptz AND external_key > $2::text)" +
+				" OR (sla_due_at = $1::timestamptz AND external_key = $2::text AND id < $3))",
+			wantArgs: []any{"2026-01-02T03:04:05Z", "TCK-9", "t1"},
+		},
+		{
+			name:     "null due time only ties with nulls",
+			sorts:    []TicketSort{{Field: TicketSortStatus}, {Field: TicketSortSLADueAt}},
+			keys:     []*string{ptr("OPEN"), nil},
+			want:     "((status > $1::ticket_status) OR (status = $1::ticket_status AND sla_due_at IS NULL AND id < $2))",
+			wantArgs: []any{"OPEN", "t1"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, args := ticketKeysetPredicate(tt.sorts, tt.keys, "t1", tt.args)
+			if got != tt.want {
+				t.Errorf("predicate =\n  %s\nwant\n  %s", got, tt.want)
+			}
+			if !reflect.DeepEqual(args, tt.wantArgs) {
+				t.Errorf("args = %v, want %v", args, tt.wantArgs)
+			}
+		})
+	}
+}
+
+func TestCheckTicketCursor(t *testing.T) {
+	sorts := []TicketSort{{Field: TicketSortPriority}, {Field: TicketSortSLADueAt}, {Field: TicketSortStatus}}
+	when := "2026-01-02T03:04:05.123456Z"
+	tests := []struct {
+		name   string
+		cursor *Cursor
+		sorts  []TicketSort
+		valid  bool
+	}{
+		{"no cursor", nil, sorts, true},
+		{"default sort cursor", &Cursor{ID: "t1", Time: time.Now()}, nil, true},
+		{"matching keys", &Cursor{ID: "t1", Keys: []*string{ptr("2"), ptr(when), ptr("PENDING_USER")}}, sorts, true},
+		{"null due time", &Cursor{ID: "t1", Keys: []*string{ptr("2"), nil, ptr("OPEN")}}, sorts, true},
+		{"missing id", &Cursor{Keys: []*string{ptr("2"), nil, ptr("OPEN")}}, sorts, false},
+		{"issued for another sort", &Cursor{ID: "t1", Keys: []*string{ptr("2")}}, sorts, false},
+		{"keys without a sort", &Cursor{ID: "t1", Keys: []*string{ptr("2")}}, nil, false},
+		{"null outside due time", &Cursor{ID: "t1", Keys: []*string{nil, nil, ptr("OPEN")}}, sorts, false},
+		{"non-numeric priority", &Cursor{ID: "t1", Keys: []*string{ptr("HIGH"), nil, ptr("OPEN")}}, sorts, false},
+		{"bad time", &Cursor{ID: "t1", Keys: []*string{ptr("2"), ptr("yesterday"), ptr("OPEN")}}, sorts, false},
+		{"unknown status", &Cursor{ID: "t1", Keys: []*string{ptr("2"), nil, ptr("BOGUS")}}, sorts, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CheckTicketCursor(tt.cursor, tt.sorts)
+			if tt.valid && err != nil {
+				t.Errorf("CheckTicketCursor err = %v, want nil", err)
+			}
+			if !tt.valid && !errors.Is(err, ErrInvalidCursor) {
+				t.Errorf("CheckTicketCursor err = %v, want ErrInvalidCursor", err)
+			}
+		})
+	}
+}
+
+// TestTicketSortValueRoundTrip checks that cursor values taken from a row pass CheckTicketCursor.
+func TestTicketSortValueRoundTrip(t *testing.T) {
+	due := time.Date(2026, 1, 2, 3, 4, 5, 123456000, time.UTC)
+	ticket := domain.Ticket{
+		ID:          "t1",
+		ExternalKey: "TCK-1",
+		Status:      domain.TicketStatusResolved,
+		Priority:    domain.TicketPriorityUrgent,
+		SLADueAt:    &due,
+		CreatedAt:   due.Add(-time.Hour),
+		UpdatedAt:   due,
+	}
+	var sorts []TicketSort
+	var keys []*string
+	for _, field := range TicketSortFields() {
+		sort := TicketSort{Field: TicketSortField(field)}
+		sorts = append(sorts, sort)
+		keys = append(keys, ticketSortValue(ticket, sort.Field))
+	}
+	if *keys[0] != "4" {
+		t.Errorf("priority value = %q, want rank 4", *keys[0])
+	}
+	if err := CheckTicketCursor(&Cursor{ID: ticket.ID, Keys: keys}, sorts); err != nil {
+		t.Errorf("CheckTicketCursor on row values: %v", err)
+	}
+	ticket.SLADueAt = nil
+	if ticketSortValue(ticket, TicketSortSLADueAt) != nil {
+		t.Error("missing due time should be a NULL key")
+	}
+}
+
+func ptr(s string) *string { return &s }
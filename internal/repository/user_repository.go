@@ -15,6 +15,8 @@ type UserRepository interface {
 	Update(ctx context.Context, user *domain.User) error
 	GetByID(ctx context.Context, id string) (*domain.User, error)
 	GetByEmail(ctx context.Context, email string) (*domain.User, error)
+	// ListByIDs loads many users in one query; unknown IDs are skipped.
+	ListByIDs(ctx context.Context, ids []string) ([]domain.User, error)
 }
 
 type userRepository struct {
@@ -32,7 +34,7 @@ func (r *userRepository) Create(ctx context.Context, user *domain.User) error {
         VALUES ($1, $2, $3, $4)
         RETURNING id, created_at, updated_at`
 
-	return r.pool.QueryRow(ctx, query,
+	return conn(ctx, r.pool).QueryRow(ctx, query,
 		user.Name,
 		user.Email,
 		user.PasswordHash,
@@ -45,7 +47,7 @@ func (r *userRepository) Update(ctx context.Context, user *domain.User) error {
         UPDATE users SET name=$1, email=$2, password_hash=$3, status=$4, updated_at=NOW()
         WHERE id=$5`
 
-	cmd, err := r.pool.Exec(ctx, query,
+	cmd, err := conn(ctx, r.pool).Exec(ctx, query,
 		user.Name,
 		user.Email,
 		user.PasswordHash,
@@ -67,7 +69,7 @@ func (r *userRepository) GetByID(ctx context.Context, id string) (*domain.User,
         FROM users WHERE id=$1`
 
 	var user domain.User
-	if err := r.pool.QueryRow(ctx, query, id).Scan(
+	if err := conn(ctx, r.pool).QueryRow(ctx, query, id).Scan(
 		&user.ID,
 		&user.Name,
 		&user.Email,
@@ -81,13 +83,46 @@ func (r *userRepository) GetByID(ctx context.Context, id string) (*domain.User,
 	return &user, nil
 }
 
+func (r *userRepository) ListByIDs(ctx context.Context, ids []string) ([]domain.User, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	const query = `
+        SELECT id, name, email, password_hash, status, created_at, updated_at
+        FROM users WHERE id = ANY($1::uuid[])`
+
+	rows, err := conn(ctx, r.pool).Query(ctx, query, ids)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []domain.User
+	for rows.Next() {
+		var user domain.User
+		if err := rows.Scan(
+			&user.ID,
+			&user.Name,
+			&user.Email,
+			&user.PasswordHash,
+			&user.Status,
+			&user.CreatedAt,
+			&user.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		result = append(result, user)
+	}
+	return result, rows.Err()
+}
+
 func (r *userRepository) GetByEmail(ctx context.Context, email string) (*domain.User, error) {
 	const query = `
         SELECT id, name, email, password_hash, status, created_at, updated_at
         FROM users WHERE email=$1`
 
 	var user domain.User
-	if err := r.pool.QueryRow(ctx, query, email).Scan(
+	if err := conn(ctx, r.pool).QueryRow(ctx, query, email).Scan(
 		&user.ID,
 		&user.Name,
 		&user.Email,
@@ -0,0 +1,76 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/spec-kit/ticket-service/internal/domain"
+)
+
+// WorkflowRepository persists per-department ticket workflows.
+type WorkflowRepository interface {
+	Upsert(ctx context.Context, workflow *domain.Workflow) error
+	GetByDepartment(ctx context.Context, departmentID string) (*domain.Workflow, error)
+	DeleteByDepartment(ctx context.Context, departmentID string) error
+}
+
+type workflowRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewWorkflowRepository constructs repository.
+func NewWorkflowRepository(pool *pgxpool.Pool) WorkflowRepository {
+	return &workflowRepository{pool: pool}
+}
+
+func (r *workflowRepository) Upsert(ctx context.Context, workflow *domain.Workflow) error {
+	const query = `
+        INSERT INTO ticket_workflows (department_id, name, initial_status, statuses, transitions, is_active)
+        VALUES ($1,$2,$3,$4,$5,$6)
+        ON CONFLICT (department_id) DO UPDATE SET name=EXCLUDED.name, initial_status=EXCLUDED.initial_status,
+            statuses=EXCLUDED.statuses, transitions=EXCLUDED.transitions, is_active=EXCLUDED.is_active, updated_at=NOW()
+        RETURNING id, created_at, updated_at`
+	return conn(ctx, r.pool).QueryRow(ctx, query,
+		workflow.DepartmentID,
+		workflow.Name,
+		workflow.InitialStatus,
+		workflow.Statuses,
+		workflow.Transitions,
+		workflow.IsActive,
+	).Scan(&workflow.ID, &workflow.CreatedAt, &workflow.UpdatedAt)
+}
+
+func (r *workflowRepository) GetByDepartment(ctx context.Context, departmentID string) (*domain.Workflow, error) {
+	const query = `
+        SELECT id, department_id, name, initial_status, statuses, transitions, is_active, created_at, updated_at
+        FROM ticket_workflows WHERE department_id=$1`
+	var workflow domain.Workflow
+	if err := conn(ctx, r.pool).QueryRow(ctx, query, departmentID).Scan(
+		&workflow.ID,
+		&workflow.DepartmentID,
+		&workflow.Name,
+		&workflow.InitialStatus,
+		&workflow.Statuses,
+		&workflow.Transitions,
+		&workflow.IsActive,
+		&workflow.CreatedAt,
+		&workflow.UpdatedAt,
+	); err != nil {
+		return nil, err
+	}
+	return &workflow, nil
+}
+
+func (r *workflowRepository) DeleteByDepartment(ctx context.Context, departmentID string) error {
+	const query = `DELETE FROM ticket_workflows WHERE department_id=$1`
+	cmd, err := conn(ctx, r.pool).Exec(ctx, query, departmentID)
+	if err != nil {
+		return err
+	}
+	if cmd.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+	return nil
+}
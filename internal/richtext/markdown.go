@@ -0,0 +1,124 @@
+package richtext
+
+import (
+	"html"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	headingPattern   = regexp.MustCompile(`^(#{1,6})\s+(.*?)\s*#*\s*$`)
+	rulePattern      = regexp.MustCompile(`^\s{0,3}(-\s*){3,}$|^\s{0,3}(\*\s*){3,}$|^\s{0,3}(_\s*){3,}$`)
+	bulletPattern    = regexp.MustCompile(`^\s{0,3}[-*+]\s+(.*)$`)
+	orderedPattern   = regexp.MustCompile(`^\s{0,3}(\d{1,9})[.)]\s+(.*)$`)
+	codeSpanPattern  = regexp.MustCompile("`([^`]+)`")
+	linkPattern      = regexp.MustCompile(`\[([^\]]+)\]\(([^)\s]+)\)`)
+	strongPattern    = regexp.MustCompile(`\*\*([^*]+)\*\*|__([^_]+)__`)
+	emphasisPattern  = regexp.MustCompile(`\*([^*]+)\*|\b_([^_]+)_\b`)
+	strikePattern    = regexp.MustCompile(`~~([^~]+)~~`)
+	codeTokenPattern = regexp.MustCompile("\x00(\\d+)\x00")
+)
+
+// RenderMarkdown converts the Markdown subset used in ticket replies — paragraphs, headings,
+// lists, block quotes, fenced code, rules, emphasis, code spans and links — to sanitized HTML.
+// Raw HTML in the source is shown as text.
+func RenderMarkdown(source string) string {
+	lines := strings.Split(strings.ReplaceAll(source, "\r\n", "\n"), "\n")
+	return Sanitize(renderBlocks(lines))
+}
+
+func renderBlocks(lines []string) string {
+	var out strings.Builder
+	var paragraph []string
+	flush := func() {
+		if len(paragraph) > 0 {
+			out.WriteString("<p>" + strings.Join(paragraph, "<br>") + "</p>")
+			paragraph = nil
+		}
+	}
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case trimmed == "":
+			flush()
+		case strings.HasPrefix(trimmed, "```"):
+			flush()
+			var code []string
+			for i++; i < len(lines) && !strings.HasPrefix(strings.TrimSpace(lines[i]), "```"); i++ {
+				code = append(code, lines[i])
+			}
+			out.WriteString("<pre><code>" + html.EscapeString(strings.Join(code, "\n")) + "</code></pre>")
+		case headingPattern.MatchString(trimmed):
+			flush()
+			m := headingPattern.FindStringSubmatch(trimmed)
+			level := strconv.Itoa(len(m[1]))
+			out.WriteString("<h" + level + ">" + renderInline(m[2]) + "</h" + level + ">")
+		case rulePattern.MatchString(line):
+			flush()
+			out.WriteString("<hr>")
+		case strings.HasPrefix(trimmed, ">"):
+			flush()
+			var quoted []string
+			for ; i < len(lines) && strings.HasPrefix(strings.TrimSpace(lines[i]), ">"); i++ {
+				inner := strings.TrimPrefix(strings.TrimSpace(lines[i]), ">")
+				quoted = append(quoted, strings.TrimPrefix(inner, " "))
+			}
+			i--
+			out.WriteString("<blockquote>" + renderBlocks(quoted) + "</blockquote>")
+		case bulletPattern.MatchString(line):
+			flush()
+			out.WriteString("<ul>")
+			for ; i < len(lines) && bulletPattern.MatchString(lines[i]); i++ {
+				out.WriteString("<li>" + renderInline(bulletPattern.FindStringSubmatch(lines[i])[1]) + "</li>")
+			}
+			i--
+			out.WriteString("</ul>")
+		case orderedPattern.MatchString(line):
+			flush()
+			start := orderedPattern.FindStringSubmatch(line)[1]
+			if start == "1" {
+				out.WriteString("<ol>")
+			} else {
+				out.WriteString(`<ol start="` + strings.TrimLeft(start, "0") + `">`)
+			}
+			for ; i < len(lines) && orderedPattern.MatchString(lines[i]); i++ {
+				out.WriteString("<li>" + renderInline(orderedPattern.FindStringSubmatch(lines[i])[2]) + "</li>")
+			}
+			i--
+			out.WriteString("</ol>")
+		default:
+			paragraph = append(paragraph, renderInline(trimmed))
+		}
+	}
+	flush()
+	return out.String()
+}
+
+// renderInline escapes a line and applies inline markup; code spans are set aside first so
+// their contents are never formatted.
+func renderInline(text string) string {
+	var spans []string
+	text = strings.ReplaceAll(text, "\x00", "")
+	text = codeSpanPattern.ReplaceAllStringFunc(text, func(match string) string {
+		spans = append(spans, "<code>"+html.EscapeString(match[1:len(match)-1])+"</code>")
+		return "\x00" + strconv.Itoa(len(spans)-1) + "\x00"
+	})
+	text = html.EscapeString(text)
+	text = linkPattern.ReplaceAllStringFunc(text, func(match string) string {
+		m := linkPattern.FindStringSubmatch(match)
+		href, ok := safeURL(html.UnescapeString(m[2]))
+		if !ok {
+			return m[1]
+		}
+		return `<a href="` + html.EscapeString(href) + `">` + m[1] + "</a>"
+	})
+	text = strongPattern.ReplaceAllString(text, "<strong>$1$2</strong>")
+	text = emphasisPattern.ReplaceAllString(text, "<em>$1$2</em>")
+	text = strikePattern.ReplaceAllString(text, "<del>$1</del>")
+	return codeTokenPattern.ReplaceAllStringFunc(text, func(token string) string {
+		index, _ := strconv.Atoi(strings.Trim(token, "\x00"))
+		return spans[index]
+	})
+}
@@ -0,0 +1,30 @@
+package richtext
+
+import "testing"
+
+func TestRenderMarkdown(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"javascript link dropped", "[x](javascript:alert(1))", `<p>x)</p>`},
+		{"encoded tab in link scheme", "[x](java&#x09;script:alert(1))", `<p>x)</p>`},
+		{"https link", "[x](https://x.test/?a=1&b=2)", `<p><a href="https://x.test/?a=1&amp;b=2"` + anchorRel + `>x</a></p>`},
+		{"quote cannot break out of href", `[x](https://x.test/"onmouseover="alert(1))`, `<p><a href="https://x.test/%22onmouseover=%22alert%281"` + anchorRel + `>x</a>)</p>`},
+		{"raw html shown as text", "<script>alert(1)</script>", `<p>&lt;script&gt;alert(1)&lt;/script&gt;</p>`},
+		{"html in quote shown as text", "> quote\n> <i>x</i>", `<blockquote><p>quote<br>&lt;i&gt;x&lt;/i&gt;</p></blockquote>`},
+		{"code span not formatted", "**bold** and `<b>*code*</b>` and _em_", `<p><strong>bold</strong> and <code>&lt;b&gt;*code*&lt;/b&gt;</code> and <em>em</em></p>`},
+		{"fenced code escaped", "```\n<script>\n```", `<pre><code>&lt;script&gt;</code></pre>`},
+		{"unclosed fence", "```\nunclosed <b>", `<pre><code>unclosed &lt;b&gt;</code></pre>`},
+		{"placeholder bytes stripped", "a\x00 0 \x00b", `<p>a 0 b</p>`},
+		{"blocks", "# Title\n\n- one\n- two\n\n3. three\n4. four\n\n~~gone~~", `<h1>Title</h1><ul><li>one</li><li>two</li></ul><ol start="3"><li>three</li><li>four</li></ol><p><del>gone</del></p>`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := RenderMarkdown(tt.input); got != tt.want {
+				t.Errorf("RenderMarkdown(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
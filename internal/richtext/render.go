@@ -0,0 +1,60 @@
+package richtext
+
+import (
+	"html"
+	"strings"
+
+	"github.com/spec-kit/ticket-service/internal/domain"
+)
+
+// Normalize validates the format and prepares a body for storage: text and Markdown are
+// trimmed, HTML is also sanitized. An empty format means text.
+func Normalize(format domain.MessageBodyFormat, body string) (domain.MessageBodyFormat, string, bool) {
+	if format == "" {
+		format = domain.BodyFormatText
+	}
+	if !format.IsValid() {
+		return format, body, false
+	}
+	body = strings.TrimSpace(body)
+	if format == domain.BodyFormatHTML {
+		body = strings.TrimSpace(Sanitize(body))
+	}
+	return format, body, true
+}
+
+// Render returns the safe HTML for a stored body.
+func Render(format domain.MessageBodyFormat, body string) string {
+	switch format {
+	case domain.BodyFormatMarkdown:
+		return RenderMarkdown(body)
+	case domain.BodyFormatHTML:
+		// stored HTML is sanitized on write; sanitizing again guards rows written before that
+		return Sanitize(body)
+	}
+	return renderText(body)
+}
+
+// PlainText returns a body without markup, for previews and notifications.
+func PlainText(format domain.MessageBodyFormat, body string) string {
+	switch format {
+	case domain.BodyFormatMarkdown:
+		return StripTags(RenderMarkdown(body))
+	case domain.BodyFormatHTML:
+		return StripTags(body)
+	}
+	return body
+}
+
+// renderText escapes plain text, turning blank lines into paragraphs and newlines into breaks.
+func renderText(body string) string {
+	var out strings.Builder
+	for _, paragraph := range strings.Split(strings.ReplaceAll(body, "\r\n", "\n"), "\n\n") {
+		paragraph = strings.TrimSpace(paragraph)
+		if paragraph == "" {
+			continue
+		}
+		out.WriteString("<p>" + strings.ReplaceAll(html.EscapeString(paragraph), "\n", "<br>") + "</p>")
+	}
+	return out.String()
+}
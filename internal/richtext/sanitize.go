@@ -0,0 +1,305 @@
+// Package richtext normalizes message bodies written as plain text, Markdown or HTML and
+// renders them to HTML that is safe to embed in agent and customer views.
+package richtext
+
+import (
+	"html"
+	"net/url"
+	"strings"
+)
+
+// allowedTags lists the elements kept by Sanitize; void elements have no closing tag.
+var allowedTags = map[string]bool{
+	"a": true, "b": true, "blockquote": true, "br": true, "code": true, "del": true,
+	"em": true, "h1": true, "h2": true, "h3": true, "h4": true, "h5": true, "h6": true,
+	"hr": true, "i": true, "li": true, "ol": true, "p": true, "pre": true, "s": true,
+	"strong": true, "sub": true, "sup": true, "table": true, "tbody": true, "td": true,
+	"th": true, "thead": true, "tr": true, "u": true, "ul": true,
+}
+
+var voidTags = map[string]bool{"br": true, "hr": true}
+
+// droppedTags have their content removed along with the tag itself.
+var droppedTags = map[string]bool{
+	"script": true, "style": true, "iframe": true, "object": true, "embed": true,
+	"noscript": true, "template": true, "textarea": true, "select": true, "title": true,
+}
+
+// allowedAttrs lists the attributes kept per element; every other attribute is dropped.
+var allowedAttrs = map[string]map[string]bool{
+	"a":  {"href": true, "title": true},
+	"td": {"colspan": true, "rowspan": true},
+	"th": {"colspan": true, "rowspan": true},
+	"ol": {"start": true},
+}
+
+var allowedSchemes = map[string]bool{"http": true, "https": true, "mailto": true}
+
+// Sanitize reduces untrusted HTML to the allowlisted elements and attributes. Unknown tags
+// are removed but their text is kept, text is re-escaped, and open elements are closed so the
+// result is always well formed.
+func Sanitize(input string) string {
+	var out strings.Builder
+	var open []string
+	skip := ""
+	for len(input) > 0 {
+		lt := strings.IndexByte(input, '<')
+		if lt < 0 {
+			if skip == "" {
+				out.WriteString(escapeText(input))
+			}
+			break
+		}
+		if lt > 0 {
+			if skip == "" {
+				out.WriteString(escapeText(input[:lt]))
+			}
+			input = input[lt:]
+			continue
+		}
+		if strings.HasPrefix(input, "<!--") {
+			end := strings.Index(input[4:], "-->")
+			if end < 0 {
+				break
+			}
+			input = input[4+end+3:]
+			continue
+		}
+		end := tagEnd(input)
+		if end < 0 {
+			// a stray '<' that never closes is text
+			if skip == "" {
+				out.WriteString("&lt;")
+			}
+			input = input[1:]
+			continue
+		}
+		raw := input[1:end]
+		input = input[end+1:]
+		name, attrs, closing := parseTag(raw)
+		if name == "" {
+			if skip == "" {
+				out.WriteString(escapeText("<" + raw + ">"))
+			}
+			continue
+		}
+		if skip != "" {
+			if closing && name == skip {
+				skip = ""
+			}
+			continue
+		}
+		if droppedTags[name] {
+			if !closing && !strings.HasSuffix(raw, "/") {
+				skip = name
+			}
+			continue
+		}
+		if !allowedTags[name] {
+			continue
+		}
+		if closing {
+			for i := len(open) - 1; i >= 0; i-- {
+				if open[i] == name {
+					for j := len(open) - 1; j >= i; j-- {
+						out.WriteString("</" + open[j] + ">")
+					}
+					open = open[:i]
+					break
+				}
+			}
+			continue
+		}
+		out.WriteString("<" + name)
+		for _, attr := range attrs {
+			if value, ok := safeAttr(name, attr[0], attr[1]); ok {
+				out.WriteString(" " + attr[0] + `="` + html.EscapeString(value) + `"`)
+			}
+		}
+		if name == "a" {
+			out.WriteString(` rel="nofollow noopener noreferrer" target="_blank"`)
+		}
+		out.WriteString(">")
+		if !voidTags[name] {
+			open = append(open, name)
+		}
+	}
+	for i := len(open) - 1; i >= 0; i-- {
+		out.WriteString("</" + open[i] + ">")
+	}
+	return out.String()
+}
+
+// StripTags returns the text content of HTML, unescaped, for previews and notifications.
+func StripTags(input string) string {
+	var out strings.Builder
+	skip := ""
+	for len(input) > 0 {
+		lt := strings.IndexByte(input, '<')
+		if lt < 0 {
+			if skip == "" {
+				out.WriteString(input)
+			}
+			break
+		}
+		if skip == "" {
+			out.WriteString(input[:lt])
+		}
+		input = input[lt:]
+		if strings.HasPrefix(input, "<!--") {
+			end := strings.Index(input[4:], "-->")
+			if end < 0 {
+				break
+			}
+			input = input[4+end+3:]
+			continue
+		}
+		end := tagEnd(input)
+		if end < 0 {
+			if skip == "" {
+				out.WriteString(input)
+			}
+			break
+		}
+		raw := input[1:end]
+		name, _, closing := parseTag(raw)
+		tag := input[:end+1]
+		input = input[end+1:]
+		switch {
+		case name == "":
+			if skip == "" {
+				out.WriteString(tag)
+			}
+		case skip != "":
+			if closing && name == skip {
+				skip = ""
+			}
+		case droppedTags[name] && !closing:
+			if !strings.HasSuffix(raw, "/") {
+				skip = name
+			}
+		case name == "br" || name == "p" || name == "li" || name == "tr" || (len(name) == 2 && name[0] == 'h'):
+			out.WriteString(" ")
+		}
+	}
+	return strings.Join(strings.Fields(html.UnescapeString(out.String())), " ")
+}
+
+// escapeText re-escapes text so entities survive but nothing can open a tag.
+func escapeText(text string) string {
+	return html.EscapeString(html.UnescapeString(text))
+}
+
+// tagEnd finds the '>' that closes the tag starting at input[0], honouring quoted values.
+func tagEnd(input string) int {
+	var quote byte
+	for i := 1; i < len(input); i++ {
+		c := input[i]
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '>':
+			return i
+		case c == '<':
+			return -1
+		}
+	}
+	return -1
+}
+
+// parseTag splits the inside of a tag into its lower-cased name and attributes.
+func parseTag(raw string) (string, [][2]string, bool) {
+	closing := strings.HasPrefix(raw, "/")
+	raw = strings.TrimPrefix(raw, "/")
+	// like browsers, "< b" is text rather than a tag
+	if raw == "" || !isLetter(raw[0]) {
+		return "", nil, false
+	}
+	raw = strings.TrimSuffix(strings.TrimSpace(raw), "/")
+	i := 0
+	for i < len(raw) && isNameChar(raw[i]) {
+		i++
+	}
+	name := strings.ToLower(raw[:i])
+	if name == "" || !isLetter(name[0]) {
+		return "", nil, false
+	}
+	var attrs [][2]string
+	rest := raw[i:]
+	for {
+		rest = strings.TrimLeft(rest, " \t\r\n/")
+		if rest == "" {
+			break
+		}
+		j := 0
+		for j < len(rest) && !strings.ContainsRune(" \t\r\n=/", rune(rest[j])) {
+			j++
+		}
+		key := strings.ToLower(rest[:j])
+		rest = strings.TrimLeft(rest[j:], " \t\r\n")
+		value := ""
+		if strings.HasPrefix(rest, "=") {
+			rest = strings.TrimLeft(rest[1:], " \t\r\n")
+			if rest != "" && (rest[0] == '"' || rest[0] == '\'') {
+				q := rest[0]
+				k := strings.IndexByte(rest[1:], q)
+				if k < 0 {
+					value, rest = rest[1:], ""
+				} else {
+					value, rest = rest[1:1+k], rest[2+k:]
+				}
+			} else {
+				k := 0
+				for k < len(rest) && !strings.ContainsRune(" \t\r\n", rune(rest[k])) {
+					k++
+				}
+				value, rest = rest[:k], rest[k:]
+			}
+		}
+		if key != "" {
+			attrs = append(attrs, [2]string{key, html.UnescapeString(value)})
+		}
+		if j == 0 && value == "" && len(rest) > 0 {
+			rest = rest[1:]
+		}
+	}
+	return name, attrs, closing
+}
+
+func safeAttr(tag, key, value string) (string, bool) {
+	if !allowedAttrs[tag][key] {
+		return "", false
+	}
+	switch key {
+	case "href":
+		return safeURL(value)
+	case "colspan", "rowspan", "start":
+		value = strings.TrimSpace(value)
+		if value == "" || len(value) > 4 || strings.Trim(value, "0123456789") != "" {
+			return "", false
+		}
+	}
+	return value, true
+}
+
+// safeURL accepts absolute http(s) and mailto links only.
+func safeURL(raw string) (string, bool) {
+	raw = strings.TrimSpace(raw)
+	u, err := url.Parse(raw)
+	if err != nil || !allowedSchemes[strings.ToLower(u.Scheme)] {
+		return "", false
+	}
+	return u.String(), true
+}
+
+func isNameChar(c byte) bool {
+	return isLetter(c) || (c >= '0' && c <= '9') || c == '-'
+}
+
+func isLetter(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
@@ -0,0 +1,73 @@
+package richtext
+
+import "testing"
+
+const anchorRel = ` rel="nofollow noopener noreferrer" target="_blank"`
+
+func TestSanitize(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"javascript href", `<a href="javascript:alert(1)">x</a>`, `<a` + anchorRel + `>x</a>`},
+		{"mixed case scheme with leading space", `<a href=" JaVaScRiPt:alert(1)">x</a>`, `<a` + anchorRel + `>x</a>`},
+		{"tab inside scheme", "<a href=\"java\tscript:alert(1)\">x</a>", `<a` + anchorRel + `>x</a>`},
+		{"encoded tab inside scheme", `<a href="java&#x09;script:alert(1)">x</a>`, `<a` + anchorRel + `>x</a>`},
+		{"leading control char", "<a href=\"\x01javascript:alert(1)\">x</a>", `<a` + anchorRel + `>x</a>`},
+		{"decimal entity scheme", `<a href="&#106;avascript:alert(1)">x</a>`, `<a` + anchorRel + `>x</a>`},
+		{"hex and named entities", `<a href="&#x6A;avascript&colon;alert(1)">x</a>`, `<a` + anchorRel + `>x</a>`},
+		{"data scheme", `<a href="data:text/html,hi">x</a>`, `<a` + anchorRel + `>x</a>`},
+		{"relative link", `<a href="/relative">x</a>`, `<a` + anchorRel + `>x</a>`},
+		{"https link kept", `<a href="https://x.test/?a=1&b=2">x</a>`, `<a href="https://x.test/?a=1&amp;b=2"` + anchorRel + `>x</a>`},
+
+		{"unclosed element is closed", `<b>bold`, `<b>bold</b>`},
+		{"misnested close", `<p><em>x</p>`, `<p><em>x</em></p>`},
+		{"unterminated tag is text", `<b`, `&lt;b`},
+		{"lone angle bracket", `a < b`, `a &lt; b`},
+		{"comment removed", `<p>one<!-- note --> two`, `<p>one two</p>`},
+		{"unclosed comment drops the rest", `keep<!-- <script>alert(1)</script>`, `keep`},
+
+		{"nested script", `<script><script>alert(1)</script>tail</script>`, `tail`},
+		{"script inside style", `<style><script>alert(1)</script></style>after`, `after`},
+		{"markup inside iframe", `<div><iframe src="x"><p>hidden</p></iframe>shown</div>`, `shown`},
+		{"self-closing dropped tag", `<script/>after`, `after`},
+		{"event handler element", `<img src=x onerror=alert(1)>`, ``},
+
+		{"quotes in attribute", `<a href="https://x.test" title='say "hi"'>x</a>`, `<a href="https://x.test" title="say &#34;hi&#34;"` + anchorRel + `>x</a>`},
+		{"bracket in quoted attribute", `<a title="x>y" href="https://x.test">z</a>`, `<a title="x&gt;y" href="https://x.test"` + anchorRel + `>z</a>`},
+		{"markup in quoted attribute", `<a title='a"><script>alert(1)</script>'>t</a>`, `<a title="a&#34;&gt;&lt;script&gt;alert(1)&lt;/script&gt;"` + anchorRel + `>t</a>`},
+		{"unquoted attributes", `<a href=https://x.test onclick=alert(1)>z</a>`, `<a href="https://x.test"` + anchorRel + `>z</a>`},
+		{"attribute without separating space", `<a href="https://x.test"onmouseover="alert(1)">z</a>`, `<a href="https://x.test"` + anchorRel + `>z</a>`},
+		{"numeric attribute checked", `<td colspan="2x" rowspan="3">c</td>`, `<td rowspan="3">c</td>`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Sanitize(tt.input); got != tt.want {
+				t.Errorf("Sanitize(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStripTags(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"block tags become spaces", `<p>hi</p><p>@bob</p>`, `hi @bob`},
+		{"entities unescaped", `<b>a &amp; b</b>`, `a & b`},
+		{"dropped content", `<script>alert(1)</script>text`, `text`},
+		{"self-closing dropped tag", `<script/>after`, `after`},
+		{"unclosed comment", `keep<!-- rest`, `keep`},
+		{"unterminated tag", `a <b`, `a <b`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := StripTags(tt.input); got != tt.want {
+				t.Errorf("StripTags(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,107 @@
+package scan
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"time"
+)
+
+// clamdChunkSize bounds each INSTREAM chunk sent to clamd.
+const clamdChunkSize = 64 << 10
+
+// ClamdScanner streams content to a clamd daemon with the INSTREAM command.
+type ClamdScanner struct {
+	network string
+	address string
+	timeout time.Duration
+}
+
+// NewClamdScanner accepts tcp://host:port, unix:///path or a bare host:port.
+func NewClamdScanner(address string, timeout time.Duration) (*ClamdScanner, error) {
+	network, addr := "tcp", address
+	switch {
+	case strings.HasPrefix(address, "tcp://"):
+		addr = strings.TrimPrefix(address, "tcp://")
+	case strings.HasPrefix(address, "unix://"):
+		network, addr = "unix", strings.TrimPrefix(address, "unix://")
+	}
+	if addr == "" {
+		return nil, errors.New("scan: clamd address required")
+	}
+	return &ClamdScanner{network: network, address: addr, timeout: timeout}, nil
+}
+
+// Scan sends the content in length-prefixed chunks and parses clamd's verdict.
+func (c *ClamdScanner) Scan(ctx context.Context, content io.Reader) (Result, error) {
+	if c.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.timeout)
+		defer cancel()
+	}
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, c.network, c.address)
+	if err != nil {
+		return Result{}, fmt.Errorf("scan: connect clamd: %w", err)
+	}
+	defer conn.Close()
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+
+	writeErr := c.stream(conn, content)
+	// clamd answers before closing when it rejects a stream, e.g. over its size limit
+	reply, readErr := bufio.NewReader(conn).ReadString(0)
+	if readErr != nil && readErr != io.EOF {
+		if writeErr != nil {
+			return Result{}, fmt.Errorf("scan: send to clamd: %w", writeErr)
+		}
+		return Result{}, fmt.Errorf("scan: read clamd reply: %w", readErr)
+	}
+	return parseClamdReply(reply)
+}
+
+func (c *ClamdScanner) stream(conn net.Conn, content io.Reader) error {
+	if _, err := io.WriteString(conn, "zINSTREAM\x00"); err != nil {
+		return err
+	}
+	buf := make([]byte, 4+clamdChunkSize)
+	for {
+		n, err := content.Read(buf[4:])
+		if n > 0 {
+			binary.BigEndian.PutUint32(buf[:4], uint32(n))
+			if _, werr := conn.Write(buf[:4+n]); werr != nil {
+				return werr
+			}
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+	}
+	_, err := conn.Write([]byte{0, 0, 0, 0})
+	return err
+}
+
+// parseClamdReply reads "stream: OK", "stream: <signature> FOUND" or "<reason> ERROR".
+func parseClamdReply(reply string) (Result, error) {
+	reply = strings.TrimSpace(strings.TrimRight(reply, "\x00"))
+	verdict := strings.TrimSpace(strings.TrimPrefix(reply, "stream:"))
+	switch {
+	case verdict == "OK":
+		return Result{}, nil
+	case strings.HasSuffix(verdict, " FOUND"):
+		return Result{Infected: true, Signature: strings.TrimSuffix(verdict, " FOUND")}, nil
+	case reply == "":
+		return Result{}, errors.New("scan: empty clamd reply")
+	default:
+		return Result{}, fmt.Errorf("scan: clamd: %s", reply)
+	}
+}
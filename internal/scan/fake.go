@@ -0,0 +1,41 @@
+package scan
+
+import (
+	"bytes"
+	"context"
+	"io"
+)
+
+// eicarMarker is the start of the EICAR anti-virus test file.
+const eicarMarker = `X5O!P%@AP[4\PZX54(P^)7CC)7}$EICAR-STANDARD-ANTIVIRUS-TEST-FILE!`
+
+// FakeScanner flags content containing any of its markers. It stands in for clamd in tests and
+// local development; by default it only detects the EICAR test file.
+type FakeScanner struct {
+	// Markers maps a byte pattern to the signature reported when it is found.
+	Markers map[string]string
+	// Err, when set, is returned instead of scanning to simulate an unavailable scanner.
+	Err error
+}
+
+// NewFakeScanner returns a scanner that detects the EICAR test file.
+func NewFakeScanner() *FakeScanner {
+	return &FakeScanner{Markers: map[string]string{eicarMarker: "Eicar-Test-Signature"}}
+}
+
+// Scan reads the whole content and looks for the configured markers.
+func (f *FakeScanner) Scan(ctx context.Context, content io.Reader) (Result, error) {
+	if f.Err != nil {
+		return Result{}, f.Err
+	}
+	data, err := io.ReadAll(content)
+	if err != nil {
+		return Result{}, err
+	}
+	for marker, signature := range f.Markers {
+		if bytes.Contains(data, []byte(marker)) {
+			return Result{Infected: true, Signature: signature}, nil
+		}
+	}
+	return Result{}, ctx.Err()
+}
@@ -0,0 +1,36 @@
+// Package scan checks attachment bytes for malware.
+package scan
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/spec-kit/ticket-service/internal/config"
+)
+
+// Result is the outcome of a completed scan; Signature names the malware when Infected.
+type Result struct {
+	Infected  bool
+	Signature string
+}
+
+// Scanner inspects content. An error means the scan did not complete, not that the content is bad.
+type Scanner interface {
+	Scan(ctx context.Context, content io.Reader) (Result, error)
+}
+
+// New builds the scanner selected in config; it returns nil when scanning is disabled.
+func New(cfg config.AttachmentScanConfig) (Scanner, error) {
+	switch strings.ToLower(cfg.Backend) {
+	case "", "none":
+		return nil, nil
+	case "clamd":
+		return NewClamdScanner(cfg.ClamdAddress, cfg.Timeout())
+	case "fake":
+		return NewFakeScanner(), nil
+	default:
+		return nil, fmt.Errorf("scan: unknown backend %q", cfg.Backend)
+	}
+}
@@ -186,10 +186,11 @@ func (s *AssignmentService) AutoAssignTicket(ctx context.Context, ticketID, team
 		Active: ptrBool(true),
 		Limit:  1000,
 	}
-	staffList, err := s.staff.List(ctx, filter)
+	staffPage, err := s.staff.List(ctx, filter)
 	if err != nil {
 		return nil, apperrors.MapError(err)
 	}
+	staffList := staffPage.Items
 	if len(staffList) == 0 {
 		return nil, apperrors.NewConflict("no eligible staff for team", map[string]any{"team_id": teamID})
 	}
@@ -344,5 +345,5 @@ func (s *AssignmentService) publishAssignmentEvent(ctx context.Context, actorID
 		Timestamp: time.Now(),
 		Payload:   payload,
 	}
-	_ = s.dispatcher.Publish(ctx, event)
+	publish(ctx, s.dispatcher, event)
 }
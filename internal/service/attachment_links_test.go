@@ -0,0 +1,73 @@
+package service
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spec-kit/ticket-service/internal/config"
+	apperrors "github.com/spec-kit/ticket-service/pkg/util/errorutil"
+)
+
+func TestAttachmentLinkSignerVerify(t *testing.T) {
+	signer := NewAttachmentLinkSigner(config.AttachmentLinkConfig{BaseURL: "https://files.example", SigningKey: "link-key", TTLSeconds: 300}, "")
+	other := NewAttachmentLinkSigner(config.AttachmentLinkConfig{}, "jwt-secret")
+
+	link, err := url.Parse(signer.Link("att-1", AttachmentVariantThumbnail))
+	if err != nil {
+		t.Fatalf("parse link: %v", err)
+	}
+	if link.Host != "files.example" || link.Path != "/attachments/att-1/thumbnail" {
+		t.Fatalf("link = %s, want the thumbnail path under the base URL", link)
+	}
+	expires, signature := link.Query().Get("expires"), link.Query().Get("signature")
+	past := strconv.FormatInt(time.Now().Add(-time.Minute).Unix(), 10)
+
+	tests := []struct {
+		name      string
+		signer    *AttachmentLinkSigner
+		id        string
+		variant   AttachmentVariant
+		expires   string
+		signature string
+		want      string
+	}{
+		{"valid", signer, "att-1", AttachmentVariantThumbnail, expires, signature, ""},
+		{"other attachment", signer, "att-2", AttachmentVariantThumbnail, expires, signature, "invalid attachment link"},
+		{"other variant", signer, "att-1", AttachmentVariantOriginal, expires, signature, "invalid attachment link"},
+		{"extended expiry", signer, "att-1", AttachmentVariantThumbnail, expires + "0", signature, "invalid attachment link"},
+		{"tampered signature", signer, "att-1", AttachmentVariantThumbnail, expires, strings.ToUpper(signature), "invalid attachment link"},
+		{"missing signature", signer, "att-1", AttachmentVariantThumbnail, expires, "", "invalid attachment link"},
+		{"non-numeric expiry", signer, "att-1", AttachmentVariantThumbnail, "soon", signer.sign("att-1", AttachmentVariantThumbnail, "soon"), "invalid attachment link"},
+		{"other key", other, "att-1", AttachmentVariantThumbnail, expires, signature, "invalid attachment link"},
+		{"expired", signer, "att-1", AttachmentVariantThumbnail, past, signer.sign("att-1", AttachmentVariantThumbnail, past), "attachment link expired"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.signer.Verify(tt.id, tt.variant, tt.expires, tt.signature)
+			if tt.want == "" {
+				if err != nil {
+					t.Fatalf("Verify err = %v, want nil", err)
+				}
+				return
+			}
+			derr := apperrors.ToDomainError(err)
+			if err == nil || derr.Code != "FORBIDDEN" || derr.Message != tt.want {
+				t.Errorf("Verify err = %v, want forbidden %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestAttachmentLinkSignerFallbackKey(t *testing.T) {
+	a := NewAttachmentLinkSigner(config.AttachmentLinkConfig{}, "jwt-secret")
+	b := NewAttachmentLinkSigner(config.AttachmentLinkConfig{}, "jwt-secret")
+	if string(a.key) != string(b.key) {
+		t.Error("derived key is not stable across signers")
+	}
+	if string(a.key) == "jwt-secret" {
+		t.Error("derived key reuses the JWT secret")
+	}
+}
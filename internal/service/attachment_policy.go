@@ -0,0 +1,225 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"mime"
+	"net/http"
+	"path"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/spec-kit/ticket-service/internal/domain"
+	apperrors "github.com/spec-kit/ticket-service/pkg/util/errorutil"
+)
+
+// extensionMimeTypes covers common document types that the system MIME table may not know.
+var extensionMimeTypes = map[string]string{
+	".csv":  "text/csv",
+	".doc":  "application/msword",
+	".docx": "application/vnd.openxmlformats-officedocument.wordprocessingml.document",
+	".json": "application/json",
+	".log":  "text/plain",
+	".md":   "text/markdown",
+	".odp":  "application/vnd.oasis.opendocument.presentation",
+	".ods":  "application/vnd.oasis.opendocument.spreadsheet",
+	".odt":  "application/vnd.oasis.opendocument.text",
+	".ppt":  "application/vnd.ms-powerpoint",
+	".pptx": "application/vnd.openxmlformats-officedocument.presentationml.presentation",
+	".txt":  "text/plain",
+	".xls":  "application/vnd.ms-excel",
+	".xlsx": "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+}
+
+// sniffMimeType detects a file's type from its first bytes. The sniffer only reports generic
+// types for plain text, zip containers and unrecognized binaries; those are narrowed by the file
+// extension when it names a compatible type, so a .docx is not stored as application/zip but a
+// renamed executable never becomes an image.
+func sniffMimeType(head []byte, fileName string) string {
+	sniffed, _, err := mime.ParseMediaType(http.DetectContentType(head))
+	if err != nil {
+		sniffed = "application/octet-stream"
+	}
+	byExt := extensionMimeType(fileName)
+	if byExt == "" {
+		return sniffed
+	}
+	switch sniffed {
+	case "text/plain":
+		if (strings.HasPrefix(byExt, "text/") && byExt != "text/html") || byExt == "application/json" {
+			return byExt
+		}
+	case "application/zip":
+		if strings.HasSuffix(byExt, "+zip") ||
+			strings.HasPrefix(byExt, "application/vnd.openxmlformats-officedocument.") ||
+			strings.HasPrefix(byExt, "application/vnd.oasis.opendocument.") {
+			return byExt
+		}
+	case "application/octet-stream":
+		if !sniffableMimeType(byExt) {
+			return byExt
+		}
+	}
+	return sniffed
+}
+
+// sniffableMimeType reports whether content of this type carries a signature the sniffer
+// recognizes, so an unrecognized result means the content is something else.
+func sniffableMimeType(mimeType string) bool {
+	for _, prefix := range []string{"image/", "audio/", "video/", "text/", "font/"} {
+		if strings.HasPrefix(mimeType, prefix) {
+			return true
+		}
+	}
+	switch mimeType {
+	case "application/pdf", "application/zip", "application/gzip", "application/x-gzip",
+		"application/x-rar-compressed", "application/vnd.rar", "application/json", "application/xml":
+		return true
+	}
+	return false
+}
+
+func extensionMimeType(fileName string) string {
+	ext := fileExtension(fileName)
+	if ext == "" {
+		return ""
+	}
+	if mimeType, ok := extensionMimeTypes[ext]; ok {
+		return mimeType
+	}
+	mediaType, _, err := mime.ParseMediaType(mime.TypeByExtension(ext))
+	if err != nil {
+		return ""
+	}
+	return mediaType
+}
+
+// fileExtension returns the lowercased final extension, ignoring trailing dots and spaces that
+// some platforms strip when saving.
+func fileExtension(fileName string) string {
+	return strings.ToLower(path.Ext(strings.TrimRight(fileName, ". ")))
+}
+
+// normalizeMimePatterns lowercases and de-duplicates MIME allowlist entries, which are exact
+// types or "type/*" wildcards.
+func normalizeMimePatterns(patterns []string) ([]string, error) {
+	result := make([]string, 0, len(patterns))
+	for _, pattern := range patterns {
+		pattern = strings.ToLower(strings.TrimSpace(pattern))
+		if pattern == "" {
+			continue
+		}
+		major, minor, ok := strings.Cut(pattern, "/")
+		if !ok || major == "" || major == "*" || minor == "" || strings.ContainsAny(pattern, " ;") {
+			return nil, apperrors.NewValidationError("invalid MIME type", map[string]any{"mime_type": pattern})
+		}
+		if minor != "*" {
+			if _, _, err := mime.ParseMediaType(pattern); err != nil {
+				return nil, apperrors.NewValidationError("invalid MIME type", map[string]any{"mime_type": pattern})
+			}
+		}
+		if !containsString(result, pattern) {
+			result = append(result, pattern)
+		}
+	}
+	return result, nil
+}
+
+func mimeTypeAllowed(patterns []string, mimeType string) bool {
+	if len(patterns) == 0 {
+		return true
+	}
+	mimeType = strings.ToLower(mimeType)
+	major, _, _ := strings.Cut(mimeType, "/")
+	for _, pattern := range patterns {
+		pattern = strings.ToLower(strings.TrimSpace(pattern))
+		if pattern == mimeType || pattern == major+"/*" {
+			return true
+		}
+	}
+	return false
+}
+
+func extensionBlocked(blocked []string, fileName string) bool {
+	ext := fileExtension(fileName)
+	if ext == "" {
+		return false
+	}
+	for _, candidate := range blocked {
+		candidate = strings.ToLower(strings.TrimSpace(candidate))
+		if !strings.HasPrefix(candidate, ".") {
+			candidate = "." + candidate
+		}
+		if candidate == ext {
+			return true
+		}
+	}
+	return false
+}
+
+// allowedAttachmentTypes returns the department's MIME allowlist, or the configured default
+// when the department has none.
+func (s *TicketService) allowedAttachmentTypes(ctx context.Context, ticket *domain.Ticket) ([]string, error) {
+	if ticket.DepartmentID != "" && s.departments != nil {
+		dept, err := s.departments.GetByID(ctx, ticket.DepartmentID)
+		if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperrors.MapError(err)
+		}
+		if dept != nil && len(dept.AllowedAttachmentTypes) > 0 {
+			return dept.AllowedAttachmentTypes, nil
+		}
+	}
+	return s.policy.AllowedMimeTypes, nil
+}
+
+// checkAttachmentFile applies the per-file rules: size, blocked extensions and allowed types.
+func (s *TicketService) checkAttachmentFile(allowed []string, fileName, mimeType string, size int64) error {
+	if limit := s.policy.MaxFileBytes; limit > 0 && size > limit {
+		return apperrors.NewValidationError("file too large", map[string]any{"file_name": fileName, "max_bytes": limit})
+	}
+	if extensionBlocked(s.policy.BlockedExtensions, fileName) {
+		return apperrors.NewValidationError("file type not allowed", map[string]any{"file_name": fileName, "extension": fileExtension(fileName)})
+	}
+	if !mimeTypeAllowed(allowed, mimeType) {
+		return apperrors.NewValidationError("file type not allowed", map[string]any{"file_name": fileName, "mime_type": mimeType})
+	}
+	return nil
+}
+
+// checkUpload rejects a file before it is stored on the ticket.
+func (s *TicketService) checkUpload(ctx context.Context, ticket *domain.Ticket, fileName, mimeType string, size int64) error {
+	allowed, err := s.allowedAttachmentTypes(ctx, ticket)
+	if err != nil {
+		return err
+	}
+	return s.checkAttachmentFile(allowed, fileName, mimeType, size)
+}
+
+// checkMessageAttachments applies the policy to every attachment of a message and to their
+// combined size. The policy may have changed since upload, so files are checked again.
+func (s *TicketService) checkMessageAttachments(ctx context.Context, ticket *domain.Ticket, attachments []MessageAttachmentInput) error {
+	if len(attachments) == 0 {
+		return nil
+	}
+	allowed, err := s.allowedAttachmentTypes(ctx, ticket)
+	if err != nil {
+		return err
+	}
+	var total int64
+	for _, att := range attachments {
+		if err := s.checkAttachmentFile(allowed, att.FileName, att.MimeType, att.SizeBytes); err != nil {
+			return err
+		}
+		total += att.SizeBytes
+	}
+	return s.checkAttachmentTotal(total)
+}
+
+// checkAttachmentTotal applies the per-message limit to the combined size of attachments.
+func (s *TicketService) checkAttachmentTotal(total int64) error {
+	if limit := s.policy.MaxMessageBytes; limit > 0 && total > limit {
+		return apperrors.NewValidationError("attachments too large", map[string]any{"total_bytes": total, "max_bytes": limit})
+	}
+	return nil
+}
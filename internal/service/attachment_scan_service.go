@@ -0,0 +1,169 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/spec-kit/ticket-service/internal/config"
+	"github.com/spec-kit/ticket-service/internal/domain"
+	"github.com/spec-kit/ticket-service/internal/repository"
+	"github.com/spec-kit/ticket-service/internal/scan"
+	"github.com/spec-kit/ticket-service/internal/storage"
+)
+
+// AttachmentScanService scans uploads in the background. Attachments stay undownloadable until
+// their upload is found clean; infected files are quarantined and reported on the ticket.
+type AttachmentScanService struct {
+	scanner     scan.Scanner
+	storage     storage.Storage
+	uploads     repository.AttachmentUploadRepository
+	attachments repository.AttachmentRepository
+	tickets     *TicketService
+	batchSize   int
+	maxAttempts int
+	wake        chan struct{}
+}
+
+// AttachmentScanDependencies bundles the scanner and stores; a nil Scanner disables scanning.
+type AttachmentScanDependencies struct {
+	Scanner        scan.Scanner
+	Storage        storage.Storage
+	UploadRepo     repository.AttachmentUploadRepository
+	AttachmentRepo repository.AttachmentRepository
+	Tickets        *TicketService
+	Config         config.AttachmentScanConfig
+}
+
+// AttachmentScanResult counts the outcomes of one scan run.
+type AttachmentScanResult struct {
+	Clean    int
+	Infected int
+	Failed   int
+	Retrying int
+}
+
+// NewAttachmentScanService constructs the service.
+func NewAttachmentScanService(deps AttachmentScanDependencies) *AttachmentScanService {
+	batchSize := deps.Config.BatchSize
+	if batchSize <= 0 {
+		batchSize = 20
+	}
+	maxAttempts := deps.Config.MaxAttempts
+	if maxAttempts <= 0 {
+		maxAttempts = 5
+	}
+	return &AttachmentScanService{
+		scanner:     deps.Scanner,
+		storage:     deps.Storage,
+		uploads:     deps.UploadRepo,
+		attachments: deps.AttachmentRepo,
+		tickets:     deps.Tickets,
+		batchSize:   batchSize,
+		maxAttempts: maxAttempts,
+		wake:        make(chan struct{}, 1),
+	}
+}
+
+// Enabled reports whether uploads must be scanned before they can be downloaded.
+func (s *AttachmentScanService) Enabled() bool {
+	return s != nil && s.scanner != nil
+}
+
+// Notify wakes the scan worker after an upload without waiting for it.
+func (s *AttachmentScanService) Notify() {
+	if !s.Enabled() {
+		return
+	}
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Wake is signalled by Notify.
+func (s *AttachmentScanService) Wake() <-chan struct{} {
+	return s.wake
+}
+
+// RunOnce scans a batch of pending uploads, copies the results onto attachment references and
+// reports infected files. Uploads whose scan errors are retried until maxAttempts, then FAILED.
+func (s *AttachmentScanService) RunOnce(ctx context.Context) (AttachmentScanResult, error) {
+	var result AttachmentScanResult
+	if !s.Enabled() {
+		return result, nil
+	}
+	pending, err := s.uploads.ListPendingScan(ctx, s.batchSize)
+	if err != nil {
+		return result, err
+	}
+	var errs []error
+	var infected []domain.AttachmentUpload
+	for i := range pending {
+		upload := &pending[i]
+		verdict, err := s.scanUpload(ctx, upload)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("scan upload %s: %w", upload.ID, err))
+			failed, attemptErr := s.recordAttempt(ctx, upload, errors.Is(err, storage.ErrNotFound))
+			if attemptErr != nil {
+				errs = append(errs, fmt.Errorf("record scan attempt %s: %w", upload.ID, attemptErr))
+			} else if failed {
+				result.Failed++
+			} else {
+				result.Retrying++
+			}
+			continue
+		}
+		status := domain.ScanStatusClean
+		var signature *string
+		if verdict.Infected {
+			status = domain.ScanStatusInfected
+			signature = &verdict.Signature
+		}
+		if err := s.uploads.RecordScanResult(ctx, upload.ID, status, signature); err != nil {
+			errs = append(errs, fmt.Errorf("record scan result %s: %w", upload.ID, err))
+			continue
+		}
+		if verdict.Infected {
+			upload.ScanSignature = signature
+			infected = append(infected, *upload)
+			result.Infected++
+		} else {
+			result.Clean++
+		}
+	}
+	if _, err := s.attachments.SyncScanStatus(ctx); err != nil {
+		errs = append(errs, fmt.Errorf("sync attachment scan status: %w", err))
+	}
+	for i := range infected {
+		if err := s.tickets.reportInfectedUpload(ctx, &infected[i], *infected[i].ScanSignature); err != nil {
+			errs = append(errs, fmt.Errorf("report infected upload %s: %w", infected[i].ID, err))
+		}
+	}
+	return result, errors.Join(errs...)
+}
+
+func (s *AttachmentScanService) scanUpload(ctx context.Context, upload *domain.AttachmentUpload) (scan.Result, error) {
+	content, err := s.storage.Get(ctx, upload.StorageKey)
+	if err != nil {
+		return scan.Result{}, err
+	}
+	defer content.Close()
+	return s.scanner.Scan(ctx, content)
+}
+
+// recordAttempt counts a failed scan and marks the upload FAILED once retries are exhausted or
+// its bytes are gone. It reports whether the upload was marked FAILED.
+func (s *AttachmentScanService) recordAttempt(ctx context.Context, upload *domain.AttachmentUpload, missing bool) (bool, error) {
+	attempts, err := s.uploads.RecordScanAttempt(ctx, upload.ID)
+	if err != nil {
+		return false, err
+	}
+	if !missing && attempts < s.maxAttempts {
+		return false, nil
+	}
+	if err := s.uploads.RecordScanResult(ctx, upload.ID, domain.ScanStatusFailed, nil); err != nil {
+		return false, err
+	}
+	return true, nil
+}
@@ -0,0 +1,330 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/spec-kit/ticket-service/internal/config"
+	"github.com/spec-kit/ticket-service/internal/domain"
+	"github.com/spec-kit/ticket-service/internal/events"
+	"github.com/spec-kit/ticket-service/internal/repository"
+	"github.com/spec-kit/ticket-service/internal/scan"
+	"github.com/spec-kit/ticket-service/internal/storage"
+)
+
+const eicar = `X5O!P%@AP[4\PZX54(P^)7CC)7}$EICAR-STANDARD-ANTIVIRUS-TEST-FILE!$H+H*`
+
+// scanUploads is an in-memory upload store; unimplemented methods panic through the nil interface.
+type scanUploads struct {
+	repository.AttachmentUploadRepository
+	uploads []*domain.AttachmentUpload
+}
+
+func (r *scanUploads) find(id string) *domain.AttachmentUpload {
+	for _, upload := range r.uploads {
+		if upload.ID == id {
+			return upload
+		}
+	}
+	return nil
+}
+
+func (r *scanUploads) ListPendingScan(_ context.Context, limit int) ([]domain.AttachmentUpload, error) {
+	var pending []domain.AttachmentUpload
+	for _, upload := range r.uploads {
+		if upload.ScanStatus == domain.ScanStatusPending && len(pending) < limit {
+			pending = append(pending, *upload)
+		}
+	}
+	return pending, nil
+}
+
+func (r *scanUploads) RecordScanResult(_ context.Context, id string, status domain.AttachmentScanStatus, signature *string) error {
+	upload := r.find(id)
+	if upload == nil {
+		return pgx.ErrNoRows
+	}
+	upload.ScanStatus = status
+	upload.ScanSignature = signature
+	return nil
+}
+
+func (r *scanUploads) RecordScanAttempt(_ context.Context, id string) (int, error) {
+	upload := r.find(id)
+	if upload == nil {
+		return 0, pgx.ErrNoRows
+	}
+	upload.ScanAttempts++
+	return upload.ScanAttempts, nil
+}
+
+// scanAttachments holds attachment references and copies finished upload scans onto them the
+// way the SQL behind SyncScanStatus does.
+type scanAttachments struct {
+	repository.AttachmentRepository
+	uploads *scanUploads
+	refs    []*domain.AttachmentReference
+	created []*domain.AttachmentReference
+	syncs   int
+}
+
+func (r *scanAttachments) SyncScanStatus(context.Context) (int64, error) {
+	r.syncs++
+	var updated int64
+	for _, ref := range r.refs {
+		if ref.ScanStatus != domain.ScanStatusPending {
+			continue
+		}
+		for _, upload := range r.uploads.uploads {
+			if upload.StorageKey == ref.StorageKey && upload.ScanStatus != domain.ScanStatusPending {
+				ref.ScanStatus = upload.ScanStatus
+				updated++
+			}
+		}
+	}
+	return updated, nil
+}
+
+func (r *scanAttachments) Create(_ context.Context, attachment *domain.AttachmentReference) error {
+	r.created = append(r.created, attachment)
+	return nil
+}
+
+type scanTickets struct {
+	repository.TicketRepository
+	tickets map[string]*domain.Ticket
+}
+
+func (r *scanTickets) GetByID(_ context.Context, id string) (*domain.Ticket, error) {
+	ticket, ok := r.tickets[id]
+	if !ok {
+		return nil, pgx.ErrNoRows
+	}
+	return ticket, nil
+}
+
+type scanMessages struct {
+	repository.TicketMessageRepository
+	messages []*domain.TicketMessage
+}
+
+func (r *scanMessages) Create(_ context.Context, msg *domain.TicketMessage) error {
+	msg.ID = fmt.Sprintf("msg-%d", len(r.messages)+1)
+	r.messages = append(r.messages, msg)
+	return nil
+}
+
+type scanFixture struct {
+	service     *AttachmentScanService
+	scanner     *scan.FakeScanner
+	store       storage.Storage
+	uploads     *scanUploads
+	attachments *scanAttachments
+	messages    *scanMessages
+	events      []events.Event
+}
+
+func newScanFixture(t *testing.T, maxAttempts int) *scanFixture {
+	t.Helper()
+	store, err := storage.NewLocalStorage(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	f := &scanFixture{
+		scanner:  scan.NewFakeScanner(),
+		store:    store,
+		uploads:  &scanUploads{},
+		messages: &scanMessages{},
+	}
+	f.attachments = &scanAttachments{uploads: f.uploads}
+	dispatcher := events.NewInMemoryDispatcher()
+	for _, eventType := range []events.EventType{events.EventAttachmentInfected, events.EventTicketMessageAdded} {
+		dispatcher.Subscribe(eventType, func(_ context.Context, event events.Event) error {
+			f.events = append(f.events, event)
+			return nil
+		})
+	}
+	tickets := NewTicketService(TicketDependencies{
+		TicketRepo:     &scanTickets{tickets: map[string]*domain.Ticket{"ticket-1": {ID: "ticket-1", ExternalKey: "TCK-1"}}},
+		MessageRepo:    f.messages,
+		AttachmentRepo: f.attachments,
+		UploadRepo:     f.uploads,
+		Storage:        store,
+		Dispatcher:     dispatcher,
+	})
+	f.service = NewAttachmentScanService(AttachmentScanDependencies{
+		Scanner:        f.scanner,
+		Storage:        store,
+		UploadRepo:     f.uploads,
+		AttachmentRepo: f.attachments,
+		Tickets:        tickets,
+		Config:         config.AttachmentScanConfig{MaxAttempts: maxAttempts},
+	})
+	return f
+}
+
+// addUpload stores content as a pending upload that is already attached to a message.
+func (f *scanFixture) addUpload(t *testing.T, id, content string) *domain.AttachmentUpload {
+	t.Helper()
+	key := "tickets/ticket-1/" + id
+	if content != "" {
+		if err := f.store.Put(context.Background(), key, strings.NewReader(content), int64(len(content)), "text/plain"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	messageID := "reply-" + id
+	upload := &domain.AttachmentUpload{
+		ID:           id,
+		TicketID:     "ticket-1",
+		StorageKey:   key,
+		FileName:     id + ".txt",
+		UploaderType: domain.AuthorTypeUser,
+		UploaderID:   "user-1",
+		MessageID:    &messageID,
+		ScanStatus:   domain.ScanStatusPending,
+	}
+	f.uploads.uploads = append(f.uploads.uploads, upload)
+	f.attachments.refs = append(f.attachments.refs, &domain.AttachmentReference{
+		ID:              "att-" + id,
+		TicketMessageID: messageID,
+		StorageKey:      key,
+		FileName:        upload.FileName,
+		ScanStatus:      domain.ScanStatusPending,
+	})
+	return upload
+}
+
+func (f *scanFixture) refStatus(id string) domain.AttachmentScanStatus {
+	for _, ref := range f.attachments.refs {
+		if ref.ID == "att-"+id {
+			return ref.ScanStatus
+		}
+	}
+	return ""
+}
+
+func TestAttachmentScanRunOnceCleanAndInfected(t *testing.T) {
+	f := newScanFixture(t, 3)
+	clean := f.addUpload(t, "clean", "quarterly report")
+	infected := f.addUpload(t, "infected", "prefix "+eicar)
+
+	result, err := f.service.RunOnce(context.Background())
+	if err != nil {
+		t.Fatalf("RunOnce: %v", err)
+	}
+	if result != (AttachmentScanResult{Clean: 1, Infected: 1}) {
+		t.Errorf("result = %+v", result)
+	}
+	if clean.ScanStatus != domain.ScanStatusClean || clean.ScanSignature != nil {
+		t.Errorf("clean upload = %s %v", clean.ScanStatus, clean.ScanSignature)
+	}
+	if infected.ScanStatus != domain.ScanStatusInfected || infected.ScanSignature == nil || *infected.ScanSignature != "Eicar-Test-Signature" {
+		t.Errorf("infected upload = %s %v", infected.ScanStatus, infected.ScanSignature)
+	}
+
+	if f.attachments.syncs != 1 {
+		t.Errorf("SyncScanStatus called %d times, want 1", f.attachments.syncs)
+	}
+	if got := f.refStatus("clean"); got != domain.ScanStatusClean {
+		t.Errorf("clean reference status = %s", got)
+	}
+	if got := f.refStatus("infected"); got != domain.ScanStatusInfected {
+		t.Errorf("infected reference status = %s", got)
+	}
+
+	if len(f.messages.messages) != 1 {
+		t.Fatalf("notes = %d, want 1", len(f.messages.messages))
+	}
+	note := f.messages.messages[0]
+	if note.TicketID != "ticket-1" || note.MessageType != domain.MessageTypeInternalNote || note.AuthorType != domain.AuthorTypeSystem {
+		t.Errorf("note = %+v", note)
+	}
+	if !strings.Contains(note.Body, "infected.txt") || !strings.Contains(note.Body, "Eicar-Test-Signature") {
+		t.Errorf("note body = %q", note.Body)
+	}
+	if len(f.attachments.created) != 0 {
+		t.Errorf("note carries %d attachments", len(f.attachments.created))
+	}
+
+	var payload *events.AttachmentInfectedPayload
+	for _, event := range f.events {
+		if event.Type == events.EventAttachmentInfected {
+			p := event.Payload.(events.AttachmentInfectedPayload)
+			payload = &p
+		}
+	}
+	if payload == nil {
+		t.Fatal("no attachment_infected event")
+	}
+	if payload.UploadID != "infected" || payload.Signature != "Eicar-Test-Signature" || payload.NoteMessageID != note.ID ||
+		payload.UploaderID != "user-1" || payload.MessageID == nil || *payload.MessageID != "reply-infected" {
+		t.Errorf("payload = %+v", payload)
+	}
+
+	// nothing is left pending, so a second run does no work
+	if result, err := f.service.RunOnce(context.Background()); err != nil || result != (AttachmentScanResult{}) {
+		t.Errorf("second run = %+v, %v", result, err)
+	}
+}
+
+func TestAttachmentScanRunOnceRetriesThenFails(t *testing.T) {
+	f := newScanFixture(t, 2)
+	upload := f.addUpload(t, "flaky", "hello")
+	f.scanner.Err = errors.New("clamd unavailable")
+
+	result, err := f.service.RunOnce(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "clamd unavailable") {
+		t.Errorf("first run err = %v, want the scanner error", err)
+	}
+	if result != (AttachmentScanResult{Retrying: 1}) {
+		t.Errorf("first run = %+v", result)
+	}
+	if upload.ScanStatus != domain.ScanStatusPending || upload.ScanAttempts != 1 {
+		t.Errorf("after first run upload = %s after %d attempts", upload.ScanStatus, upload.ScanAttempts)
+	}
+	if got := f.refStatus("flaky"); got != domain.ScanStatusPending {
+		t.Errorf("reference status after retry = %s", got)
+	}
+
+	result, _ = f.service.RunOnce(context.Background())
+	if result != (AttachmentScanResult{Failed: 1}) {
+		t.Errorf("second run = %+v", result)
+	}
+	if upload.ScanStatus != domain.ScanStatusFailed || upload.ScanAttempts != 2 {
+		t.Errorf("after second run upload = %s after %d attempts", upload.ScanStatus, upload.ScanAttempts)
+	}
+	if got := f.refStatus("flaky"); got != domain.ScanStatusFailed {
+		t.Errorf("reference status after failure = %s", got)
+	}
+	if len(f.messages.messages) != 0 || len(f.events) != 0 {
+		t.Errorf("failed scan reported %d notes and %d events", len(f.messages.messages), len(f.events))
+	}
+}
+
+func TestAttachmentScanRunOnceMissingObjectFailsAtOnce(t *testing.T) {
+	f := newScanFixture(t, 5)
+	upload := f.addUpload(t, "missing", "")
+
+	result, err := f.service.RunOnce(context.Background())
+	if !errors.Is(err, storage.ErrNotFound) {
+		t.Errorf("err = %v, want storage.ErrNotFound", err)
+	}
+	if result != (AttachmentScanResult{Failed: 1}) {
+		t.Errorf("result = %+v", result)
+	}
+	if upload.ScanStatus != domain.ScanStatusFailed || upload.ScanAttempts != 1 {
+		t.Errorf("upload = %s after %d attempts", upload.ScanStatus, upload.ScanAttempts)
+	}
+}
+
+func TestAttachmentScanRunOnceDisabled(t *testing.T) {
+	s := NewAttachmentScanService(AttachmentScanDependencies{})
+	if result, err := s.RunOnce(context.Background()); err != nil || result != (AttachmentScanResult{}) {
+		t.Errorf("RunOnce without a scanner = %+v, %v", result, err)
+	}
+}
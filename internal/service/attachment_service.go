@@ -0,0 +1,288 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"strings"
+	"unicode"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/spec-kit/ticket-service/internal/domain"
+	"github.com/spec-kit/ticket-service/internal/repository"
+	"github.com/spec-kit/ticket-service/internal/storage"
+	"github.com/spec-kit/ticket-service/internal/thumbnail"
+	apperrors "github.com/spec-kit/ticket-service/pkg/util/errorutil"
+)
+
+const (
+	// sniffLength is how much of a file content sniffing looks at.
+	sniffLength = 512
+	// thumbnailSize bounds the width and height of rendered thumbnails.
+	thumbnailSize = 320
+)
+
+// AttachmentService stores uploaded files and serves them to callers who can see them.
+type AttachmentService struct {
+	storage storage.Storage
+	uploads repository.AttachmentUploadRepository
+	tickets *TicketService
+	scans   *AttachmentScanService
+	links   *AttachmentLinkSigner
+}
+
+// AttachmentDependencies bundles what uploads and downloads need; Tickets enforces visibility
+// and the attachment policy, Scans queues new uploads for malware scanning and Links verifies
+// signed download links.
+type AttachmentDependencies struct {
+	Storage    storage.Storage
+	UploadRepo repository.AttachmentUploadRepository
+	Tickets    *TicketService
+	Scans      *AttachmentScanService
+	Links      *AttachmentLinkSigner
+}
+
+// UploadFile is one file from a multipart upload. Its type is sniffed from the content, so the
+// client's declared Content-Type is not needed.
+type UploadFile struct {
+	FileName string
+	Size     int64
+	Content  io.Reader
+}
+
+// AttachmentDownload is an attachment's metadata with its content; the caller closes Content.
+type AttachmentDownload struct {
+	Attachment *domain.AttachmentReference
+	Content    io.ReadCloser
+}
+
+// NewAttachmentService constructs the service.
+func NewAttachmentService(deps AttachmentDependencies) *AttachmentService {
+	return &AttachmentService{
+		storage: deps.Storage,
+		uploads: deps.UploadRepo,
+		tickets: deps.Tickets,
+		scans:   deps.Scans,
+		links:   deps.Links,
+	}
+}
+
+// UploadForUser stores a file on a ticket the user can read. The returned storage key is then
+// referenced when posting a message.
+func (s *AttachmentService) UploadForUser(ctx context.Context, userID, ticketID string, file UploadFile) (*domain.AttachmentUpload, error) {
+	ticket, err := s.tickets.ticketForUser(ctx, userID, ticketID)
+	if err != nil {
+		return nil, err
+	}
+	return s.upload(ctx, ticket, domain.AuthorTypeUser, userID, file)
+}
+
+// UploadForStaff stores a file on a ticket the staff member can access.
+func (s *AttachmentService) UploadForStaff(ctx context.Context, staff *domain.StaffMember, ticketID string, file UploadFile) (*domain.AttachmentUpload, error) {
+	ticket, err := s.tickets.ticketForStaff(ctx, staff, ticketID)
+	if err != nil {
+		return nil, err
+	}
+	return s.upload(ctx, ticket, domain.AuthorTypeStaff, staff.ID, file)
+}
+
+// CheckUploadTotal rejects an upload request whose files together exceed what one message may
+// carry.
+func (s *AttachmentService) CheckUploadTotal(total int64) error {
+	return s.tickets.checkAttachmentTotal(total)
+}
+
+// DownloadForUser opens an attachment on a ticket the user can read. Attachments on internal
+// notes are reported as not found.
+func (s *AttachmentService) DownloadForUser(ctx context.Context, userID, ticketID, attachmentID string) (*AttachmentDownload, error) {
+	ticket, err := s.tickets.ticketForUser(ctx, userID, ticketID)
+	if err != nil {
+		return nil, err
+	}
+	attachment, _, err := s.tickets.attachmentOnTicket(ctx, ticket.ID, attachmentID, false)
+	if err != nil {
+		return nil, err
+	}
+	return s.open(ctx, attachment)
+}
+
+// DownloadForStaff opens an attachment on a ticket the staff member can access.
+func (s *AttachmentService) DownloadForStaff(ctx context.Context, staff *domain.StaffMember, ticketID, attachmentID string) (*AttachmentDownload, error) {
+	ticket, err := s.tickets.ticketForStaff(ctx, staff, ticketID)
+	if err != nil {
+		return nil, err
+	}
+	attachment, _, err := s.tickets.attachmentOnTicket(ctx, ticket.ID, attachmentID, true)
+	if err != nil {
+		return nil, err
+	}
+	return s.open(ctx, attachment)
+}
+
+// DownloadLinked opens an attachment through a signed link, without a bearer token.
+func (s *AttachmentService) DownloadLinked(ctx context.Context, attachmentID, expires, signature string) (*AttachmentDownload, error) {
+	attachment, err := s.linkedAttachment(ctx, attachmentID, AttachmentVariantOriginal, expires, signature)
+	if err != nil {
+		return nil, err
+	}
+	return s.open(ctx, attachment)
+}
+
+// ThumbnailLinked serves a thumbnail of an image attachment through a signed link. Thumbnails are
+// rendered on first request and cached next to the original.
+func (s *AttachmentService) ThumbnailLinked(ctx context.Context, attachmentID, expires, signature string) (*AttachmentDownload, error) {
+	attachment, err := s.linkedAttachment(ctx, attachmentID, AttachmentVariantThumbnail, expires, signature)
+	if err != nil {
+		return nil, err
+	}
+	notFound := apperrors.NewNotFound("thumbnail", map[string]any{"attachment_id": attachment.ID})
+	if !thumbnail.Supported(attachment.MimeType) {
+		return nil, notFound
+	}
+	if err := checkScanStatus(attachment); err != nil {
+		return nil, err
+	}
+	key := thumbnailKey(attachment.StorageKey)
+	data, err := s.readObject(ctx, key)
+	if errors.Is(err, storage.ErrNotFound) {
+		data, err = s.renderThumbnail(ctx, attachment, key)
+		if errors.Is(err, thumbnail.ErrUnsupported) || errors.Is(err, storage.ErrNotFound) {
+			return nil, notFound
+		}
+	}
+	if err != nil {
+		return nil, apperrors.NewInternalError(err)
+	}
+	thumb := *attachment
+	thumb.FileName = "thumbnail-" + attachment.FileName
+	thumb.MimeType = http.DetectContentType(data)
+	thumb.SizeBytes = int64(len(data))
+	return &AttachmentDownload{Attachment: &thumb, Content: io.NopCloser(bytes.NewReader(data))}, nil
+}
+
+func (s *AttachmentService) linkedAttachment(ctx context.Context, attachmentID string, variant AttachmentVariant, expires, signature string) (*domain.AttachmentReference, error) {
+	if s.links == nil {
+		return nil, apperrors.NewNotFound("attachment", map[string]any{"attachment_id": attachmentID})
+	}
+	if err := s.links.Verify(attachmentID, variant, expires, signature); err != nil {
+		return nil, err
+	}
+	attachment, err := s.tickets.attachments.GetByID(ctx, attachmentID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperrors.NewNotFound("attachment", map[string]any{"attachment_id": attachmentID})
+		}
+		return nil, apperrors.MapError(err)
+	}
+	return attachment, nil
+}
+
+func (s *AttachmentService) renderThumbnail(ctx context.Context, attachment *domain.AttachmentReference, key string) ([]byte, error) {
+	original, err := s.storage.Get(ctx, attachment.StorageKey)
+	if err != nil {
+		return nil, err
+	}
+	defer original.Close()
+	data, contentType, err := thumbnail.Generate(original, thumbnailSize)
+	if err != nil {
+		return nil, err
+	}
+	// caching is best effort; the next request renders again if this fails
+	_ = s.storage.Put(ctx, key, bytes.NewReader(data), int64(len(data)), contentType)
+	return data, nil
+}
+
+func (s *AttachmentService) readObject(ctx context.Context, key string) ([]byte, error) {
+	content, err := s.storage.Get(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	defer content.Close()
+	return io.ReadAll(content)
+}
+
+func (s *AttachmentService) upload(ctx context.Context, ticket *domain.Ticket, uploaderType domain.MessageAuthorType, uploaderID string, file UploadFile) (*domain.AttachmentUpload, error) {
+	if file.Size <= 0 {
+		return nil, apperrors.NewValidationError("file is empty", nil)
+	}
+	head := make([]byte, sniffLength)
+	n, err := io.ReadFull(file.Content, head)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) && !errors.Is(err, io.EOF) {
+		return nil, apperrors.NewInternalError(err)
+	}
+	head = head[:n]
+	upload := &domain.AttachmentUpload{
+		TicketID:     ticket.ID,
+		StorageKey:   fmt.Sprintf("tickets/%s/%s", ticket.ID, uuid.NewString()),
+		FileName:     cleanFileName(file.FileName),
+		MimeType:     sniffMimeType(head, file.FileName),
+		SizeBytes:    file.Size,
+		UploaderType: uploaderType,
+		UploaderID:   uploaderID,
+		ScanStatus:   domain.ScanStatusClean,
+	}
+	if s.scans.Enabled() {
+		upload.ScanStatus = domain.ScanStatusPending
+	}
+	if err := s.tickets.checkUpload(ctx, ticket, upload.FileName, upload.MimeType, upload.SizeBytes); err != nil {
+		return nil, err
+	}
+	content := io.MultiReader(bytes.NewReader(head), file.Content)
+	if err := s.storage.Put(ctx, upload.StorageKey, io.LimitReader(content, file.Size), file.Size, upload.MimeType); err != nil {
+		return nil, apperrors.NewInternalError(err)
+	}
+	if err := s.uploads.Create(ctx, upload); err != nil {
+		_ = s.storage.Delete(ctx, upload.StorageKey)
+		return nil, apperrors.MapError(err)
+	}
+	s.scans.Notify()
+	return upload, nil
+}
+
+func (s *AttachmentService) open(ctx context.Context, attachment *domain.AttachmentReference) (*AttachmentDownload, error) {
+	if err := checkScanStatus(attachment); err != nil {
+		return nil, err
+	}
+	content, err := s.storage.Get(ctx, attachment.StorageKey)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return nil, apperrors.NewNotFound("attachment", map[string]any{"attachment_id": attachment.ID})
+		}
+		return nil, apperrors.NewInternalError(err)
+	}
+	return &AttachmentDownload{Attachment: attachment, Content: content}, nil
+}
+
+// checkScanStatus lets through only attachments whose malware scan came back clean.
+func checkScanStatus(attachment *domain.AttachmentReference) error {
+	switch attachment.ScanStatus {
+	case domain.ScanStatusClean:
+		return nil
+	case domain.ScanStatusPending:
+		return apperrors.NewConflict("attachment is still being scanned", map[string]any{"attachment_id": attachment.ID})
+	default:
+		return apperrors.NewForbidden("attachment is quarantined")
+	}
+}
+
+// cleanFileName keeps the base name of a client file name without control characters.
+func cleanFileName(name string) string {
+	name = path.Base(strings.ReplaceAll(name, "\\", "/"))
+	name = strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) {
+			return -1
+		}
+		return r
+	}, name)
+	name = strings.TrimSpace(name)
+	if name == "" || name == "." || name == "/" {
+		name = "attachment"
+	}
+	return truncate(name, 255)
+}
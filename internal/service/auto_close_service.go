@@ -0,0 +1,217 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/spec-kit/ticket-service/internal/config"
+	"github.com/spec-kit/ticket-service/internal/domain"
+	"github.com/spec-kit/ticket-service/internal/events"
+	"github.com/spec-kit/ticket-service/internal/repository"
+)
+
+// AutoCloseService closes resolved and pending-user tickets that have gone idle.
+type AutoCloseService struct {
+	tickets    repository.TicketRepository
+	messages   repository.TicketMessageRepository
+	history    repository.TicketHistoryRepository
+	workflows  repository.WorkflowRepository
+	tx         repository.Transactor
+	dispatcher events.Dispatcher
+	cfg        config.AutoCloseConfig
+}
+
+// AutoCloseDependencies bundles repositories for the auto-close service.
+type AutoCloseDependencies struct {
+	TicketRepo   repository.TicketRepository
+	MessageRepo  repository.TicketMessageRepository
+	HistoryRepo  repository.TicketHistoryRepository
+	WorkflowRepo repository.WorkflowRepository
+	Transactor   repository.Transactor
+	Dispatcher   events.Dispatcher
+	Config       config.AutoCloseConfig
+}
+
+// AutoCloseResult summarizes a single run.
+type AutoCloseResult struct {
+	Warned int
+	Closed int
+}
+
+// NewAutoCloseService constructs the service.
+func NewAutoCloseService(deps AutoCloseDependencies) *AutoCloseService {
+	return &AutoCloseService{
+		tickets:    deps.TicketRepo,
+		messages:   deps.MessageRepo,
+		history:    deps.HistoryRepo,
+		workflows:  deps.WorkflowRepo,
+		tx:         deps.Transactor,
+		dispatcher: deps.Dispatcher,
+		cfg:        deps.Config,
+	}
+}
+
+// RunOnce warns requesters about tickets nearing their idle limit and closes those past it.
+func (s *AutoCloseService) RunOnce(ctx context.Context, now time.Time) (AutoCloseResult, error) {
+	var result AutoCloseResult
+	var errs []error
+	policies := map[domain.TicketStatus]int{
+		domain.TicketStatusResolved:    s.cfg.ResolvedHours,
+		domain.TicketStatusPendingUser: s.cfg.PendingUserHours,
+	}
+	for status, hours := range policies {
+		if hours <= 0 {
+			continue
+		}
+		warned, closed, err := s.runPolicy(ctx, now, status, time.Duration(hours)*time.Hour)
+		result.Warned += warned
+		result.Closed += closed
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return result, errors.Join(errs...)
+}
+
+func (s *AutoCloseService) runPolicy(ctx context.Context, now time.Time, status domain.TicketStatus, idle time.Duration) (int, int, error) {
+	lead := time.Duration(s.cfg.WarningHours) * time.Hour
+	if lead > idle {
+		lead = idle
+	}
+	var errs []error
+	warned := 0
+	closeFilter := repository.IdleTicketFilter{
+		Status:         status,
+		InactiveBefore: now.Add(-idle),
+		Limit:          s.cfg.BatchSize,
+	}
+	if lead > 0 {
+		pending, err := s.tickets.ListIdle(ctx, repository.IdleTicketFilter{
+			Status:         status,
+			InactiveBefore: now.Add(-(idle - lead)),
+			Warned:         ptrBool(false),
+			Limit:          s.cfg.BatchSize,
+		})
+		if err != nil {
+			return 0, 0, err
+		}
+		for i := range pending {
+			if err := s.warn(ctx, &pending[i], now, now.Add(lead)); err != nil {
+				errs = append(errs, fmt.Errorf("warn ticket %s: %w", pending[i].ID, err))
+				continue
+			}
+			warned++
+		}
+		warnedBefore := now.Add(-lead)
+		closeFilter.Warned = ptrBool(true)
+		closeFilter.WarnedBefore = &warnedBefore
+	}
+
+	idleTickets, err := s.tickets.ListIdle(ctx, closeFilter)
+	if err != nil {
+		return warned, 0, errors.Join(append(errs, err)...)
+	}
+	closed := 0
+	for i := range idleTickets {
+		ok, err := s.close(ctx, &idleTickets[i], idle, closeFilter.InactiveBefore)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("close ticket %s: %w", idleTickets[i].ID, err))
+			continue
+		}
+		if ok {
+			closed++
+		}
+	}
+	return warned, closed, errors.Join(errs...)
+}
+
+func (s *AutoCloseService) warn(ctx context.Context, ticket *domain.Ticket, now, closeAt time.Time) error {
+	if err := s.tickets.MarkAutoCloseWarned(ctx, ticket.ID, now); err != nil {
+		return err
+	}
+	s.publishEvent(ctx, events.EventTicketAutoClosePending, ticket.ID, events.TicketAutoClosePendingPayload{
+		Status:  ticket.Status,
+		CloseAt: closeAt,
+	})
+	return nil
+}
+
+// close moves an idle ticket to CLOSED with its system message, history and event in one
+// transaction. The ticket was listed outside it, so it is only closed while it still has the listed
+// status and activity; a ticket that changed since, such as by a requester reply, is skipped.
+func (s *AutoCloseService) close(ctx context.Context, ticket *domain.Ticket, idle time.Duration, inactiveBefore time.Time) (bool, error) {
+	closed := false
+	err := withinTx(ctx, s.tx, func(ctx context.Context) error {
+		now := time.Now()
+		oldStatus := ticket.Status
+		oldKey := ticket.WorkflowStatus
+		newKey, err := workflowKeyFor(ctx, s.workflows, ticket, domain.TicketStatusClosed)
+		if err != nil {
+			return err
+		}
+		ticket.Status = domain.TicketStatusClosed
+		ticket.WorkflowStatus = newKey
+		ticket.ClosedAt = &now
+		updated, err := s.tickets.CloseIdle(ctx, ticket, oldStatus, ticket.UpdatedAt, inactiveBefore)
+		if err != nil || !updated {
+			return err
+		}
+		msg := &domain.TicketMessage{
+			TicketID:    ticket.ID,
+			AuthorType:  domain.AuthorTypeSystem,
+			MessageType: domain.MessageTypeSystemEvent,
+			Body:        fmt.Sprintf("Ticket closed automatically after %d hours without activity while %s.", int(idle.Hours()), oldStatus),
+		}
+		if err := s.messages.Create(ctx, msg); err != nil {
+			return err
+		}
+		entry := &domain.TicketHistory{
+			TicketID:      ticket.ID,
+			ChangedByType: domain.AuthorTypeSystem,
+			ChangeType:    domain.ChangeTypeStatus,
+			OldValue: map[string]any{
+				"status": oldStatus,
+			},
+			NewValue: map[string]any{
+				"status":  ticket.Status,
+				"comment": "auto_closed",
+			},
+		}
+		if oldKey != nil || newKey != nil {
+			entry.OldValue["workflow_status"] = oldKey
+			entry.NewValue["workflow_status"] = newKey
+		}
+		if err := s.history.Create(ctx, entry); err != nil {
+			return err
+		}
+		s.publishEvent(ctx, events.EventTicketStatusChanged, ticket.ID, events.TicketStatusChangedPayload{
+			OldStatus: oldStatus,
+			NewStatus: ticket.Status,
+			Comment:   "auto_closed",
+		})
+		closed = true
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+	return closed, nil
+}
+
+func (s *AutoCloseService) publishEvent(ctx context.Context, eventType events.EventType, ticketID string, payload any) {
+	if s.dispatcher == nil {
+		return
+	}
+	publish(ctx, s.dispatcher, events.Event{
+		ID:        uuid.NewString(),
+		Type:      eventType,
+		TicketID:  ticketID,
+		Actor:     systemActor(),
+		Timestamp: time.Now(),
+		Payload:   payload,
+	})
+}
@@ -0,0 +1,132 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/spec-kit/ticket-service/internal/config"
+	"github.com/spec-kit/ticket-service/internal/domain"
+	"github.com/spec-kit/ticket-service/internal/events"
+	"github.com/spec-kit/ticket-service/internal/repository"
+)
+
+// autoCloseTickets serves one warning batch and one closing batch; CloseIdle reports false for
+// stale tickets the way the conditional UPDATE does when a ticket changed after it was listed.
+type autoCloseTickets struct {
+	repository.TicketRepository
+	toWarn  []domain.Ticket
+	toClose []domain.Ticket
+	stale   map[string]bool
+	warned  []string
+	closes  []string
+	filters []repository.IdleTicketFilter
+}
+
+func (r *autoCloseTickets) ListIdle(_ context.Context, filter repository.IdleTicketFilter) ([]domain.Ticket, error) {
+	r.filters = append(r.filters, filter)
+	if filter.Warned != nil && !*filter.Warned {
+		return r.toWarn, nil
+	}
+	return r.toClose, nil
+}
+
+func (r *autoCloseTickets) MarkAutoCloseWarned(_ context.Context, ticketID string, _ time.Time) error {
+	r.warned = append(r.warned, ticketID)
+	return nil
+}
+
+func (r *autoCloseTickets) CloseIdle(_ context.Context, ticket *domain.Ticket, oldStatus domain.TicketStatus, _, _ time.Time) (bool, error) {
+	if ticket.Status != domain.TicketStatusClosed || oldStatus != domain.TicketStatusResolved {
+		return false, nil
+	}
+	r.closes = append(r.closes, ticket.ID)
+	return !r.stale[ticket.ID], nil
+}
+
+type autoCloseHistory struct {
+	repository.TicketHistoryRepository
+	entries []*domain.TicketHistory
+}
+
+func (r *autoCloseHistory) Create(_ context.Context, entry *domain.TicketHistory) error {
+	r.entries = append(r.entries, entry)
+	return nil
+}
+
+// countingTransactor runs fn directly and counts the transactions opened.
+type countingTransactor struct{ count int }
+
+func (t *countingTransactor) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	t.count++
+	return fn(ctx)
+}
+
+func TestAutoCloseRunOnce(t *testing.T) {
+	now := time.Date(2026, 6, 1, 9, 0, 0, 0, time.UTC)
+	tickets := &autoCloseTickets{
+		toWarn: []domain.Ticket{{ID: "warn-1", Status: domain.TicketStatusResolved}},
+		toClose: []domain.Ticket{
+			{ID: "close-1", Status: domain.TicketStatusResolved},
+			{ID: "stale-1", Status: domain.TicketStatusResolved},
+		},
+		stale: map[string]bool{"stale-1": true},
+	}
+	messages := &scanMessages{}
+	history := &autoCloseHistory{}
+	tx := &countingTransactor{}
+	var published []events.Event
+	dispatcher := events.NewInMemoryDispatcher()
+	for _, eventType := range []events.EventType{events.EventTicketAutoClosePending, events.EventTicketStatusChanged} {
+		dispatcher.Subscribe(eventType, func(_ context.Context, event events.Event) error {
+			published = append(published, event)
+			return nil
+		})
+	}
+	service := NewAutoCloseService(AutoCloseDependencies{
+		TicketRepo:  tickets,
+		MessageRepo: messages,
+		HistoryRepo: history,
+		Transactor:  tx,
+		Dispatcher:  dispatcher,
+		Config:      config.AutoCloseConfig{BatchSize: 50, ResolvedHours: 48, WarningHours: 24},
+	})
+
+	result, err := service.RunOnce(context.Background(), now)
+	if err != nil {
+		t.Fatalf("RunOnce err = %v", err)
+	}
+	if result != (AutoCloseResult{Warned: 1, Closed: 1}) {
+		t.Errorf("result = %+v, want 1 warned and 1 closed", result)
+	}
+
+	if len(tickets.filters) != 2 {
+		t.Fatalf("ListIdle calls = %d, want 2", len(tickets.filters))
+	}
+	if got, want := tickets.filters[0].InactiveBefore, now.Add(-24*time.Hour); !got.Equal(want) {
+		t.Errorf("warning cutoff = %v, want %v", got, want)
+	}
+	closeFilter := tickets.filters[1]
+	if !closeFilter.InactiveBefore.Equal(now.Add(-48*time.Hour)) || closeFilter.Warned == nil || !*closeFilter.Warned ||
+		closeFilter.WarnedBefore == nil || !closeFilter.WarnedBefore.Equal(now.Add(-24*time.Hour)) {
+		t.Errorf("close filter = %+v, want tickets idle 48h and warned at least 24h ago", closeFilter)
+	}
+
+	if len(tickets.warned) != 1 || tickets.warned[0] != "warn-1" {
+		t.Errorf("warned = %v, want [warn-1]", tickets.warned)
+	}
+	if len(tickets.closes) != 2 || tx.count != 2 {
+		t.Errorf("close attempts = %v in %d transactions, want both tickets each in its own", tickets.closes, tx.count)
+	}
+	if len(messages.messages) != 1 || messages.messages[0].TicketID != "close-1" || messages.messages[0].MessageType != domain.MessageTypeSystemEvent {
+		t.Errorf("messages = %+v, want one system event on close-1", messages.messages)
+	}
+	if len(history.entries) != 1 || history.entries[0].TicketID != "close-1" || history.entries[0].NewValue["comment"] != "auto_closed" {
+		t.Errorf("history = %+v, want one auto_closed entry on close-1", history.entries)
+	}
+	if len(published) != 2 ||
+		published[0].Type != events.EventTicketAutoClosePending || published[0].TicketID != "warn-1" ||
+		published[1].Type != events.EventTicketStatusChanged || published[1].TicketID != "close-1" {
+		t.Errorf("events = %+v, want the warning for warn-1 and the status change for close-1", published)
+	}
+}
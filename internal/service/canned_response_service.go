@@ -0,0 +1,177 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/spec-kit/ticket-service/internal/domain"
+	"github.com/spec-kit/ticket-service/internal/repository"
+	apperrors "github.com/spec-kit/ticket-service/pkg/util/errorutil"
+)
+
+const (
+	maxReplyTemplateNameLength = 120
+	maxReplyTemplateBodyLength = 20000
+)
+
+// CannedResponseService manages personal and team-shared canned responses.
+type CannedResponseService struct {
+	responses repository.CannedResponseRepository
+	teams     repository.TeamRepository
+	tickets   *TicketService
+}
+
+// CannedResponseDependencies bundles what canned responses need; Tickets renders placeholders.
+type CannedResponseDependencies struct {
+	CannedResponseRepo repository.CannedResponseRepository
+	TeamRepo           repository.TeamRepository
+	Tickets            *TicketService
+}
+
+// CannedResponseInput describes a canned response create/update payload.
+type CannedResponseInput struct {
+	Name   string
+	TeamID *string
+	Body   string
+}
+
+// NewCannedResponseService constructs the service.
+func NewCannedResponseService(deps CannedResponseDependencies) *CannedResponseService {
+	return &CannedResponseService{
+		responses: deps.CannedResponseRepo,
+		teams:     deps.TeamRepo,
+		tickets:   deps.Tickets,
+	}
+}
+
+// ListResponses returns the staff member's own responses and those shared with their team,
+// optionally narrowed to names containing query.
+func (s *CannedResponseService) ListResponses(ctx context.Context, staff *domain.StaffMember, query string) ([]domain.CannedResponse, error) {
+	if staff == nil {
+		return nil, apperrors.NewUnauthorized("staff required")
+	}
+	responses, err := s.responses.ListVisible(ctx, staff.ID, staff.TeamID)
+	if err != nil {
+		return nil, apperrors.MapError(err)
+	}
+	query = strings.ToLower(strings.TrimSpace(query))
+	result := make([]domain.CannedResponse, 0, len(responses))
+	for _, response := range responses {
+		if query == "" || strings.Contains(strings.ToLower(response.Name), query) {
+			result = append(result, response)
+		}
+	}
+	return result, nil
+}
+
+// GetResponse fetches a response visible to the staff member.
+func (s *CannedResponseService) GetResponse(ctx context.Context, staff *domain.StaffMember, id string) (*domain.CannedResponse, error) {
+	if staff == nil {
+		return nil, apperrors.NewUnauthorized("staff required")
+	}
+	response, err := s.responses.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperrors.NewNotFound("canned_response", map[string]any{"response_id": id})
+		}
+		return nil, apperrors.MapError(err)
+	}
+	if !sharedVisible(staff, response.OwnerStaffID, response.TeamID) {
+		return nil, apperrors.NewNotFound("canned_response", map[string]any{"response_id": id})
+	}
+	return response, nil
+}
+
+// CreateResponse stores a new response owned by the staff member.
+func (s *CannedResponseService) CreateResponse(ctx context.Context, staff *domain.StaffMember, input CannedResponseInput) (*domain.CannedResponse, error) {
+	if staff == nil {
+		return nil, apperrors.NewUnauthorized("staff required")
+	}
+	response := &domain.CannedResponse{OwnerStaffID: staff.ID}
+	if err := s.applyInput(ctx, staff, response, input); err != nil {
+		return nil, err
+	}
+	if err := s.responses.Create(ctx, response); err != nil {
+		return nil, apperrors.MapError(err)
+	}
+	return response, nil
+}
+
+// UpdateResponse replaces a response; only the owner or an admin may change it.
+func (s *CannedResponseService) UpdateResponse(ctx context.Context, staff *domain.StaffMember, id string, input CannedResponseInput) (*domain.CannedResponse, error) {
+	response, err := s.GetResponse(ctx, staff, id)
+	if err != nil {
+		return nil, err
+	}
+	if response.OwnerStaffID != staff.ID && staff.Role != domain.StaffRoleAdmin {
+		return nil, apperrors.NewForbidden("only the owner can change this response")
+	}
+	if err := s.applyInput(ctx, staff, response, input); err != nil {
+		return nil, err
+	}
+	if err := s.responses.Update(ctx, response); err != nil {
+		return nil, apperrors.MapError(err)
+	}
+	return response, nil
+}
+
+// DeleteResponse removes a response; only the owner or an admin may delete it.
+func (s *CannedResponseService) DeleteResponse(ctx context.Context, staff *domain.StaffMember, id string) error {
+	response, err := s.GetResponse(ctx, staff, id)
+	if err != nil {
+		return err
+	}
+	if response.OwnerStaffID != staff.ID && staff.Role != domain.StaffRoleAdmin {
+		return apperrors.NewForbidden("only the owner can delete this response")
+	}
+	if err := s.responses.Delete(ctx, response.ID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return apperrors.NewNotFound("canned_response", map[string]any{"response_id": id})
+		}
+		return apperrors.MapError(err)
+	}
+	return nil
+}
+
+// RenderResponse fills a response's placeholders for a ticket.
+func (s *CannedResponseService) RenderResponse(ctx context.Context, staff *domain.StaffMember, ticketID, id string) (*domain.CannedResponse, string, error) {
+	response, err := s.GetResponse(ctx, staff, id)
+	if err != nil {
+		return nil, "", err
+	}
+	body, err := s.tickets.RenderReply(ctx, staff, ticketID, response.Body)
+	if err != nil {
+		return nil, "", err
+	}
+	return response, body, nil
+}
+
+func (s *CannedResponseService) applyInput(ctx context.Context, staff *domain.StaffMember, response *domain.CannedResponse, input CannedResponseInput) error {
+	name := strings.TrimSpace(input.Name)
+	if name == "" {
+		return apperrors.NewValidationError("name required", nil)
+	}
+	if len(name) > maxReplyTemplateNameLength {
+		return apperrors.NewValidationError("name too long", map[string]any{"max_length": maxReplyTemplateNameLength})
+	}
+	body := strings.TrimSpace(input.Body)
+	if body == "" {
+		return apperrors.NewValidationError("body required", nil)
+	}
+	if len(body) > maxReplyTemplateBodyLength {
+		return apperrors.NewValidationError("body too long", map[string]any{"max_length": maxReplyTemplateBodyLength})
+	}
+	if err := validateReplyTemplate(body); err != nil {
+		return err
+	}
+	if err := checkTeamShare(ctx, s.teams, staff, input.TeamID); err != nil {
+		return err
+	}
+	response.Name = name
+	response.TeamID = input.TeamID
+	response.Body = body
+	return nil
+}
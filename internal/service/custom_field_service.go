@@ -0,0 +1,333 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/spec-kit/ticket-service/internal/domain"
+	"github.com/spec-kit/ticket-service/internal/repository"
+	apperrors "github.com/spec-kit/ticket-service/pkg/util/errorutil"
+)
+
+var customFieldKeyPattern = regexp.MustCompile(`^[a-z][a-z0-9_]{0,63}$`)
+
+// CustomFieldService manages admin-defined ticket fields.
+type CustomFieldService struct {
+	fields      repository.CustomFieldRepository
+	departments repository.DepartmentRepository
+}
+
+// CustomFieldDependencies bundles repositories for custom field management.
+type CustomFieldDependencies struct {
+	CustomFieldRepo repository.CustomFieldRepository
+	DepartmentRepo  repository.DepartmentRepository
+}
+
+// CustomFieldInput describes field create/update payloads; Key and Type are fixed after creation.
+type CustomFieldInput struct {
+	Key          string
+	Label        string
+	Type         domain.CustomFieldType
+	Options      []string
+	Required     bool
+	DepartmentID *string
+	IsActive     *bool
+}
+
+// NewCustomFieldService constructs the service.
+func NewCustomFieldService(deps CustomFieldDependencies) *CustomFieldService {
+	return &CustomFieldService{
+		fields:      deps.CustomFieldRepo,
+		departments: deps.DepartmentRepo,
+	}
+}
+
+// CreateField stores a new custom field definition.
+func (s *CustomFieldService) CreateField(ctx context.Context, actor *domain.StaffMember, input CustomFieldInput) (*domain.CustomFieldDefinition, error) {
+	if err := requireAdmin(actor); err != nil {
+		return nil, err
+	}
+	key := strings.TrimSpace(input.Key)
+	if !customFieldKeyPattern.MatchString(key) {
+		return nil, apperrors.NewValidationError("key must be lower snake case", map[string]any{"key": input.Key})
+	}
+	switch input.Type {
+	case domain.CustomFieldTypeText, domain.CustomFieldTypeNumber, domain.CustomFieldTypeDate,
+		domain.CustomFieldTypeEnum, domain.CustomFieldTypeMultiSelect:
+	default:
+		return nil, apperrors.NewValidationError("invalid field type", map[string]any{"type": input.Type})
+	}
+	field := &domain.CustomFieldDefinition{Key: key, Type: input.Type, IsActive: true}
+	if err := s.applyFieldInput(ctx, field, input); err != nil {
+		return nil, err
+	}
+	if err := s.fields.Create(ctx, field); err != nil {
+		return nil, apperrors.MapError(err)
+	}
+	return field, nil
+}
+
+// ListFields returns field definitions, optionally limited to those applying to a department.
+func (s *CustomFieldService) ListFields(ctx context.Context, actor *domain.StaffMember, departmentID *string, includeInactive bool) ([]domain.CustomFieldDefinition, error) {
+	if err := requireAdmin(actor); err != nil {
+		return nil, err
+	}
+	return s.fields.List(ctx, repository.CustomFieldFilter{DepartmentID: departmentID, IncludeInactive: includeInactive})
+}
+
+// UpdateField replaces the mutable settings of a field.
+func (s *CustomFieldService) UpdateField(ctx context.Context, actor *domain.StaffMember, fieldID string, input CustomFieldInput) (*domain.CustomFieldDefinition, error) {
+	if err := requireAdmin(actor); err != nil {
+		return nil, err
+	}
+	field, err := s.fields.GetByID(ctx, fieldID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperrors.NewNotFound("custom field", map[string]any{"field_id": fieldID})
+		}
+		return nil, apperrors.MapError(err)
+	}
+	if input.Key != "" && input.Key != field.Key {
+		return nil, apperrors.NewValidationError("key cannot be changed", map[string]any{"key": field.Key})
+	}
+	if input.Type != "" && input.Type != field.Type {
+		return nil, apperrors.NewValidationError("type cannot be changed", map[string]any{"type": field.Type})
+	}
+	if err := s.applyFieldInput(ctx, field, input); err != nil {
+		return nil, err
+	}
+	if err := s.fields.Update(ctx, field); err != nil {
+		return nil, apperrors.MapError(err)
+	}
+	return field, nil
+}
+
+func (s *CustomFieldService) applyFieldInput(ctx context.Context, field *domain.CustomFieldDefinition, input CustomFieldInput) error {
+	label := strings.TrimSpace(input.Label)
+	if label == "" {
+		return apperrors.NewValidationError("label required", nil)
+	}
+	options := []string{}
+	if field.Type == domain.CustomFieldTypeEnum || field.Type == domain.CustomFieldTypeMultiSelect {
+		seen := map[string]struct{}{}
+		for _, option := range input.Options {
+			option = strings.TrimSpace(option)
+			if option == "" {
+				continue
+			}
+			if _, dup := seen[option]; dup {
+				continue
+			}
+			seen[option] = struct{}{}
+			options = append(options, option)
+		}
+		if len(options) == 0 {
+			return apperrors.NewValidationError("options required for this field type", map[string]any{"type": field.Type})
+		}
+	}
+	if input.DepartmentID != nil {
+		if _, err := s.departments.GetByID(ctx, *input.DepartmentID); err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return apperrors.NewNotFound("department", map[string]any{"department_id": *input.DepartmentID})
+			}
+			return apperrors.MapError(err)
+		}
+	}
+	field.Label = label
+	field.Options = options
+	field.Required = input.Required
+	field.DepartmentID = input.DepartmentID
+	if input.IsActive != nil {
+		field.IsActive = *input.IsActive
+	}
+	return nil
+}
+
+// applyCustomFieldChanges validates changes against the definitions and merges them into current.
+// A nil value clears the field; required fields cannot be cleared. Fields that are not changed
+// are left alone, so making a field required later does not block edits to older tickets.
+func applyCustomFieldChanges(defs []domain.CustomFieldDefinition, current, changes map[string]any) (map[string]any, error) {
+	byKey := make(map[string]domain.CustomFieldDefinition, len(defs))
+	for _, def := range defs {
+		byKey[def.Key] = def
+	}
+	result := make(map[string]any, len(current)+len(changes))
+	for key, value := range current {
+		result[key] = value
+	}
+	for key, raw := range changes {
+		def, ok := byKey[key]
+		if !ok {
+			return nil, apperrors.NewValidationError("unknown custom field", map[string]any{"field": key})
+		}
+		value, present, err := normalizeCustomFieldValue(def, raw)
+		if err != nil {
+			return nil, err
+		}
+		if present {
+			result[key] = value
+		} else if def.Required {
+			return nil, apperrors.NewValidationError("required custom field cannot be cleared", map[string]any{"field": key})
+		} else {
+			delete(result, key)
+		}
+	}
+	return result, nil
+}
+
+// checkRequiredCustomFields rejects a new ticket that lacks a required field.
+func checkRequiredCustomFields(defs []domain.CustomFieldDefinition, values map[string]any) error {
+	missing := []string{}
+	for _, def := range defs {
+		if _, ok := values[def.Key]; def.Required && !ok {
+			missing = append(missing, def.Key)
+		}
+	}
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		return apperrors.NewValidationError("required custom fields missing", map[string]any{"fields": missing})
+	}
+	return nil
+}
+
+// normalizeCustomFieldValue converts raw input into the stored representation; present is false for empty values.
+func normalizeCustomFieldValue(def domain.CustomFieldDefinition, raw any) (any, bool, error) {
+	if raw == nil {
+		return nil, false, nil
+	}
+	invalid := apperrors.NewValidationError("invalid custom field value", map[string]any{"field": def.Key, "type": def.Type})
+	switch def.Type {
+	case domain.CustomFieldTypeText:
+		value, ok := raw.(string)
+		if !ok {
+			return nil, false, invalid
+		}
+		value = strings.TrimSpace(value)
+		return value, value != "", nil
+	case domain.CustomFieldTypeNumber:
+		var number float64
+		switch value := raw.(type) {
+		case float64:
+			number = value
+		case int:
+			number = float64(value)
+		case string:
+			if strings.TrimSpace(value) == "" {
+				return nil, false, nil
+			}
+			parsed, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+			if err != nil {
+				return nil, false, invalid
+			}
+			number = parsed
+		default:
+			return nil, false, invalid
+		}
+		// ParseFloat accepts NaN and Inf, which JSON cannot store
+		if math.IsNaN(number) || math.IsInf(number, 0) {
+			return nil, false, invalid
+		}
+		return number, true, nil
+	case domain.CustomFieldTypeDate:
+		value, ok := raw.(string)
+		if !ok {
+			return nil, false, invalid
+		}
+		value = strings.TrimSpace(value)
+		if value == "" {
+			return nil, false, nil
+		}
+		if parsed, err := time.Parse(domain.CustomFieldDateLayout, value); err == nil {
+			return parsed.Format(domain.CustomFieldDateLayout), true, nil
+		}
+		if parsed, err := time.Parse(time.RFC3339, value); err == nil {
+			return parsed.UTC().Format(domain.CustomFieldDateLayout), true, nil
+		}
+		return nil, false, invalid
+	case domain.CustomFieldTypeEnum:
+		value, ok := raw.(string)
+		if !ok {
+			return nil, false, invalid
+		}
+		if value == "" {
+			return nil, false, nil
+		}
+		if !containsString(def.Options, value) {
+			return nil, false, apperrors.NewValidationError("value not in field options", map[string]any{"field": def.Key, "value": value})
+		}
+		return value, true, nil
+	case domain.CustomFieldTypeMultiSelect:
+		var items []string
+		switch value := raw.(type) {
+		case []string:
+			items = value
+		case []any:
+			for _, item := range value {
+				str, ok := item.(string)
+				if !ok {
+					return nil, false, invalid
+				}
+				items = append(items, str)
+			}
+		default:
+			return nil, false, invalid
+		}
+		selected := []string{}
+		for _, item := range items {
+			if !containsString(def.Options, item) {
+				return nil, false, apperrors.NewValidationError("value not in field options", map[string]any{"field": def.Key, "value": item})
+			}
+			if !containsString(selected, item) {
+				selected = append(selected, item)
+			}
+		}
+		return selected, len(selected) > 0, nil
+	default:
+		return nil, false, invalid
+	}
+}
+
+// customFieldFilterValues converts query-string filters into a JSON containment document.
+// A multi-select filter matches tickets that selected the given option.
+func customFieldFilterValues(defs []domain.CustomFieldDefinition, raw map[string]string) (map[string]any, error) {
+	byKey := make(map[string]domain.CustomFieldDefinition, len(defs))
+	for _, def := range defs {
+		byKey[def.Key] = def
+	}
+	result := make(map[string]any, len(raw))
+	for key, value := range raw {
+		def, ok := byKey[key]
+		if !ok {
+			return nil, apperrors.NewValidationError("unknown custom field", map[string]any{"field": key})
+		}
+		var input any = value
+		if def.Type == domain.CustomFieldTypeMultiSelect {
+			input = []string{value}
+		}
+		normalized, present, err := normalizeCustomFieldValue(def, input)
+		if err != nil {
+			return nil, err
+		}
+		if present {
+			result[key] = normalized
+		}
+	}
+	return result, nil
+}
+
+func containsString(values []string, target string) bool {
+	for _, value := range values {
+		if value == target {
+			return true
+		}
+	}
+	return false
+}
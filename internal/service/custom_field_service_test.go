@@ -0,0 +1,74 @@
+package service
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/spec-kit/ticket-service/internal/domain"
+	apperrors "github.com/spec-kit/ticket-service/pkg/util/errorutil"
+)
+
+func TestNormalizeCustomFieldValue(t *testing.T) {
+	text := domain.CustomFieldDefinition{Key: "note", Type: domain.CustomFieldTypeText}
+	number := domain.CustomFieldDefinition{Key: "amount", Type: domain.CustomFieldTypeNumber}
+	date := domain.CustomFieldDefinition{Key: "due", Type: domain.CustomFieldTypeDate}
+	enum := domain.CustomFieldDefinition{Key: "plan", Type: domain.CustomFieldTypeEnum, Options: []string{"free", "pro"}}
+	multi := domain.CustomFieldDefinition{Key: "os", Type: domain.CustomFieldTypeMultiSelect, Options: []string{"linux", "mac", "windows"}}
+
+	tests := []struct {
+		name    string
+		def     domain.CustomFieldDefinition
+		raw     any
+		want    any
+		present bool
+		wantErr string
+	}{
+		{"nil is absent", text, nil, nil, false, ""},
+		{"text trimmed", text, "  hello ", "hello", true, ""},
+		{"blank text", text, "   ", "", false, ""},
+		{"text not a string", text, 42.0, nil, false, "invalid custom field value"},
+		{"number from json", number, 12.5, 12.5, true, ""},
+		{"number from int", number, 3, 3.0, true, ""},
+		{"number from string", number, " -4.25 ", -4.25, true, ""},
+		{"empty number string", number, "", nil, false, ""},
+		{"number not numeric", number, "twelve", nil, false, "invalid custom field value"},
+		{"number NaN", number, "NaN", nil, false, "invalid custom field value"},
+		{"number Inf", number, "Inf", nil, false, "invalid custom field value"},
+		{"number +Inf", number, "+Inf", nil, false, "invalid custom field value"},
+		{"number NaN float", number, math.NaN(), nil, false, "invalid custom field value"},
+		{"number bool", number, true, nil, false, "invalid custom field value"},
+		{"date", date, "2026-03-04", "2026-03-04", true, ""},
+		{"date from RFC 3339 in UTC", date, "2026-03-04T23:30:00-02:00", "2026-03-05", true, ""},
+		{"empty date", date, " ", nil, false, ""},
+		{"bad date", date, "04/03/2026", nil, false, "invalid custom field value"},
+		{"enum option", enum, "pro", "pro", true, ""},
+		{"empty enum", enum, "", nil, false, ""},
+		{"enum not an option", enum, "gold", nil, false, "value not in field options"},
+		{"multi-select from json", multi, []any{"mac", "linux", "mac"}, []string{"mac", "linux"}, true, ""},
+		{"multi-select strings", multi, []string{"windows"}, []string{"windows"}, true, ""},
+		{"empty multi-select", multi, []any{}, []string{}, false, ""},
+		{"multi-select not an option", multi, []any{"linux", "bsd"}, nil, false, "value not in field options"},
+		{"multi-select non-string item", multi, []any{"linux", 1.0}, nil, false, "invalid custom field value"},
+		{"multi-select not a list", multi, "linux", nil, false, "invalid custom field value"},
+		{"unknown type", domain.CustomFieldDefinition{Key: "x", Type: "BLOB"}, "x", nil, false, "invalid custom field value"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, present, err := normalizeCustomFieldValue(tt.def, tt.raw)
+			if tt.wantErr != "" {
+				derr := apperrors.ToDomainError(err)
+				if err == nil || derr.Code != "VALIDATION_FAILED" || derr.Message != tt.wantErr {
+					t.Fatalf("normalizeCustomFieldValue(%v) err = %v, want validation error %q", tt.raw, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("normalizeCustomFieldValue(%v) err = %v", tt.raw, err)
+			}
+			if !reflect.DeepEqual(got, tt.want) || present != tt.present {
+				t.Errorf("normalizeCustomFieldValue(%v) = %#v, %v, want %#v, %v", tt.raw, got, present, tt.want, tt.present)
+			}
+		})
+	}
+}
@@ -0,0 +1,361 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/spec-kit/ticket-service/internal/config"
+	"github.com/spec-kit/ticket-service/internal/domain"
+	"github.com/spec-kit/ticket-service/internal/events"
+	"github.com/spec-kit/ticket-service/internal/repository"
+	apperrors "github.com/spec-kit/ticket-service/pkg/util/errorutil"
+)
+
+// EscalationService manages escalation rules and applies them to idle tickets.
+type EscalationService struct {
+	rules       repository.EscalationRepository
+	tickets     repository.TicketRepository
+	staff       repository.StaffRepository
+	departments repository.DepartmentRepository
+	history     repository.TicketHistoryRepository
+	tx          repository.Transactor
+	dispatcher  events.Dispatcher
+	sla         config.SLAConfig
+	batchSize   int
+}
+
+// EscalationDependencies bundles repositories for the escalation service.
+type EscalationDependencies struct {
+	EscalationRepo repository.EscalationRepository
+	TicketRepo     repository.TicketRepository
+	StaffRepo      repository.StaffRepository
+	DepartmentRepo repository.DepartmentRepository
+	HistoryRepo    repository.TicketHistoryRepository
+	Transactor     repository.Transactor
+	Dispatcher     events.Dispatcher
+	SLA            config.SLAConfig
+	BatchSize      int
+}
+
+// EscalationRuleInput describes rule create/update payloads.
+type EscalationRuleInput struct {
+	Name             string
+	DepartmentID     *string
+	Priorities       []domain.TicketPriority
+	Condition        domain.EscalationCondition
+	ThresholdMinutes int
+	Action           domain.EscalationAction
+	IsActive         *bool
+}
+
+// NewEscalationService constructs the service.
+func NewEscalationService(deps EscalationDependencies) *EscalationService {
+	return &EscalationService{
+		rules:       deps.EscalationRepo,
+		tickets:     deps.TicketRepo,
+		staff:       deps.StaffRepo,
+		departments: deps.DepartmentRepo,
+		history:     deps.HistoryRepo,
+		tx:          deps.Transactor,
+		dispatcher:  deps.Dispatcher,
+		sla:         deps.SLA,
+		batchSize:   deps.BatchSize,
+	}
+}
+
+// CreateRule stores a new escalation rule.
+func (s *EscalationService) CreateRule(ctx context.Context, actor *domain.StaffMember, input EscalationRuleInput) (*domain.EscalationRule, error) {
+	if err := requireAdmin(actor); err != nil {
+		return nil, err
+	}
+	rule := &domain.EscalationRule{IsActive: true}
+	if err := s.applyRuleInput(ctx, rule, input); err != nil {
+		return nil, err
+	}
+	if err := s.rules.CreateRule(ctx, rule); err != nil {
+		return nil, apperrors.MapError(err)
+	}
+	return rule, nil
+}
+
+// ListRules returns escalation rules (optionally inactive).
+func (s *EscalationService) ListRules(ctx context.Context, actor *domain.StaffMember, includeInactive bool) ([]domain.EscalationRule, error) {
+	if err := requireAdmin(actor); err != nil {
+		return nil, err
+	}
+	return s.rules.ListRules(ctx, includeInactive)
+}
+
+// UpdateRule replaces rule settings.
+func (s *EscalationService) UpdateRule(ctx context.Context, actor *domain.StaffMember, ruleID string, input EscalationRuleInput) (*domain.EscalationRule, error) {
+	if err := requireAdmin(actor); err != nil {
+		return nil, err
+	}
+	rule, err := s.rules.GetRule(ctx, ruleID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperrors.NewNotFound("escalation rule", map[string]any{"rule_id": ruleID})
+		}
+		return nil, apperrors.MapError(err)
+	}
+	if err := s.applyRuleInput(ctx, rule, input); err != nil {
+		return nil, err
+	}
+	if err := s.rules.UpdateRule(ctx, rule); err != nil {
+		return nil, apperrors.MapError(err)
+	}
+	return rule, nil
+}
+
+func (s *EscalationService) applyRuleInput(ctx context.Context, rule *domain.EscalationRule, input EscalationRuleInput) error {
+	name := strings.TrimSpace(input.Name)
+	if name == "" {
+		return apperrors.NewValidationError("name required", nil)
+	}
+	if input.ThresholdMinutes <= 0 {
+		return apperrors.NewValidationError("threshold_minutes must be positive", nil)
+	}
+	switch input.Condition {
+	case domain.EscalationConditionUnassigned, domain.EscalationConditionNoStaffReply, domain.EscalationConditionSLABreach:
+	default:
+		return apperrors.NewValidationError("invalid condition", map[string]any{"condition": input.Condition})
+	}
+	switch input.Action {
+	case domain.EscalationActionBumpPriority, domain.EscalationActionAssignTeamLead, domain.EscalationActionNotifyManager:
+	default:
+		return apperrors.NewValidationError("invalid action", map[string]any{"action": input.Action})
+	}
+	for _, p := range input.Priorities {
+		switch p {
+		case domain.TicketPriorityLow, domain.TicketPriorityMedium, domain.TicketPriorityHigh, domain.TicketPriorityUrgent:
+		default:
+			return apperrors.NewValidationError("invalid priority", map[string]any{"priority": p})
+		}
+	}
+	if input.DepartmentID != nil {
+		if _, err := s.departments.GetByID(ctx, *input.DepartmentID); err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return apperrors.NewNotFound("department", map[string]any{"department_id": *input.DepartmentID})
+			}
+			return apperrors.MapError(err)
+		}
+	}
+	rule.Name = name
+	rule.DepartmentID = input.DepartmentID
+	rule.Priorities = input.Priorities
+	rule.Condition = input.Condition
+	rule.ThresholdMinutes = input.ThresholdMinutes
+	rule.Action = input.Action
+	if input.IsActive != nil {
+		rule.IsActive = *input.IsActive
+	}
+	return nil
+}
+
+// RunOnce evaluates every active rule against current tickets and returns how many escalations fired.
+func (s *EscalationService) RunOnce(ctx context.Context, now time.Time) (int, error) {
+	rules, err := s.rules.ListRules(ctx, false)
+	if err != nil {
+		return 0, err
+	}
+	fired := 0
+	var errs []error
+	for i := range rules {
+		rule := &rules[i]
+		candidates, err := s.rules.ListCandidates(ctx, rule, now, s.batchSize)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("rule %s: %w", rule.ID, err))
+			continue
+		}
+		for j := range candidates {
+			ok, err := s.fire(ctx, rule, candidates[j].ID, now)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("rule %s ticket %s: %w", rule.ID, candidates[j].ID, err))
+				continue
+			}
+			if ok {
+				fired++
+			}
+		}
+	}
+	return fired, errors.Join(errs...)
+}
+
+// fire claims the rule/ticket pair and applies the rule's action in one transaction, so a failed
+// action releases the claim without leaving any of its changes behind. The candidate scan ran
+// outside the transaction, so the ticket is re-read under a row lock: a ticket that stopped
+// qualifying is skipped, and the action never overwrites changes made since the scan.
+func (s *EscalationService) fire(ctx context.Context, rule *domain.EscalationRule, ticketID string, now time.Time) (bool, error) {
+	fired := false
+	err := withinTx(ctx, s.tx, func(ctx context.Context) error {
+		ticket, err := s.rules.LockCandidate(ctx, rule, ticketID, now)
+		if err != nil || ticket == nil {
+			return err
+		}
+		claimed, err := s.rules.RecordFiring(ctx, rule.ID, ticket.ID)
+		if err != nil || !claimed {
+			return err
+		}
+		managerID, err := s.applyAction(ctx, rule, ticket)
+		if err != nil {
+			return err
+		}
+		s.publishEvent(ctx, events.EventTicketEscalated, ticket.ID, events.TicketEscalatedPayload{
+			RuleID:          rule.ID,
+			RuleName:        rule.Name,
+			Condition:       rule.Condition,
+			Action:          rule.Action,
+			Priority:        ticket.Priority,
+			AssigneeStaffID: ticket.AssigneeID,
+			ManagerStaffID:  managerID,
+		})
+		fired = true
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+	return fired, nil
+}
+
+// applyAction performs the rule's action. For NOTIFY_MANAGER it returns the staff member to
+// notify, or nil when the department has no lead or admin.
+func (s *EscalationService) applyAction(ctx context.Context, rule *domain.EscalationRule, ticket *domain.Ticket) (*string, error) {
+	switch rule.Action {
+	case domain.EscalationActionBumpPriority:
+		return nil, s.bumpPriority(ctx, rule, ticket)
+	case domain.EscalationActionAssignTeamLead:
+		return nil, s.assignTeamLead(ctx, rule, ticket)
+	case domain.EscalationActionNotifyManager:
+		manager, err := s.findManager(ctx, ticket)
+		if err != nil || manager == nil {
+			return nil, err
+		}
+		return &manager.ID, nil
+	default:
+		return nil, fmt.Errorf("unsupported escalation action %q", rule.Action)
+	}
+}
+
+func (s *EscalationService) bumpPriority(ctx context.Context, rule *domain.EscalationRule, ticket *domain.Ticket) error {
+	oldPriority := ticket.Priority
+	newPriority := nextPriority(oldPriority)
+	if newPriority == oldPriority {
+		return nil
+	}
+	ticket.Priority = newPriority
+	ticket.SLADueAt = slaDueAt(s.sla, ticket.CreatedAt, newPriority)
+	if err := s.tickets.Update(ctx, ticket); err != nil {
+		return err
+	}
+	if err := s.history.Create(ctx, &domain.TicketHistory{
+		TicketID:      ticket.ID,
+		ChangedByType: domain.AuthorTypeSystem,
+		ChangeType:    domain.ChangeTypePriority,
+		OldValue: map[string]any{
+			"priority": oldPriority,
+		},
+		NewValue: map[string]any{
+			"priority":           newPriority,
+			"escalation_rule_id": rule.ID,
+		},
+	}); err != nil {
+		return err
+	}
+	s.publishEvent(ctx, events.EventTicketPriorityChanged, ticket.ID, events.TicketPriorityChangedPayload{
+		OldPriority: oldPriority,
+		NewPriority: newPriority,
+	})
+	return nil
+}
+
+func (s *EscalationService) assignTeamLead(ctx context.Context, rule *domain.EscalationRule, ticket *domain.Ticket) error {
+	lead, err := s.findTeamLead(ctx, ticket)
+	if err != nil {
+		return err
+	}
+	if lead == nil || (ticket.AssigneeID != nil && *ticket.AssigneeID == lead.ID) {
+		return nil
+	}
+	oldAssignee := ticket.AssigneeID
+	ticket.AssigneeID = &lead.ID
+	if err := s.tickets.Update(ctx, ticket); err != nil {
+		return err
+	}
+	if err := s.history.Create(ctx, &domain.TicketHistory{
+		TicketID:      ticket.ID,
+		ChangedByType: domain.AuthorTypeSystem,
+		ChangeType:    domain.ChangeTypeAssignee,
+		OldValue: map[string]any{
+			"assignee_staff_id": oldAssignee,
+		},
+		NewValue: map[string]any{
+			"assignee_staff_id":  ticket.AssigneeID,
+			"escalation_rule_id": rule.ID,
+		},
+	}); err != nil {
+		return err
+	}
+	s.publishEvent(ctx, events.EventTicketAssigned, ticket.ID, events.TicketAssignedPayload{
+		AssigneeStaffID: ticket.AssigneeID,
+		TeamID:          ticket.TeamID,
+	})
+	return nil
+}
+
+// findTeamLead prefers an active lead of the ticket's team, falling back to one in its department.
+func (s *EscalationService) findTeamLead(ctx context.Context, ticket *domain.Ticket) (*domain.StaffMember, error) {
+	role := domain.StaffRoleTeamLead
+	filters := []repository.StaffFilter{}
+	if ticket.TeamID != nil {
+		filters = append(filters, repository.StaffFilter{Role: &role, TeamID: ticket.TeamID, Active: ptrBool(true), Limit: 1})
+	}
+	filters = append(filters, repository.StaffFilter{Role: &role, DepartmentID: &ticket.DepartmentID, Active: ptrBool(true), Limit: 1})
+	for _, filter := range filters {
+		leads, err := s.staff.List(ctx, filter)
+		if err != nil {
+			return nil, err
+		}
+		if len(leads.Items) > 0 {
+			return &leads.Items[0], nil
+		}
+	}
+	return nil, nil
+}
+
+// findManager resolves who NOTIFY_MANAGER alerts: the ticket's team lead, else any active admin.
+func (s *EscalationService) findManager(ctx context.Context, ticket *domain.Ticket) (*domain.StaffMember, error) {
+	lead, err := s.findTeamLead(ctx, ticket)
+	if err != nil || lead != nil {
+		return lead, err
+	}
+	role := domain.StaffRoleAdmin
+	admins, err := s.staff.List(ctx, repository.StaffFilter{Role: &role, Active: ptrBool(true), Limit: 1})
+	if err != nil {
+		return nil, err
+	}
+	if len(admins.Items) > 0 {
+		return &admins.Items[0], nil
+	}
+	return nil, nil
+}
+
+func (s *EscalationService) publishEvent(ctx context.Context, eventType events.EventType, ticketID string, payload any) {
+	if s.dispatcher == nil {
+		return
+	}
+	publish(ctx, s.dispatcher, events.Event{
+		ID:        uuid.NewString(),
+		Type:      eventType,
+		TicketID:  ticketID,
+		Actor:     systemActor(),
+		Timestamp: time.Now(),
+		Payload:   payload,
+	})
+}
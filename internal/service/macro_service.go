@@ -0,0 +1,385 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/spec-kit/ticket-service/internal/domain"
+	"github.com/spec-kit/ticket-service/internal/repository"
+	apperrors "github.com/spec-kit/ticket-service/pkg/util/errorutil"
+)
+
+// MacroService manages macros and applies them to tickets.
+type MacroService struct {
+	macros      repository.MacroRepository
+	teams       repository.TeamRepository
+	staff       repository.StaffRepository
+	tags        repository.TagRepository
+	workflows   repository.WorkflowRepository
+	tickets     *TicketService
+	assignments *AssignmentService
+	tx          repository.Transactor
+}
+
+// MacroDependencies bundles what macros need; field changes go through the ticket and assignment services.
+type MacroDependencies struct {
+	MacroRepo    repository.MacroRepository
+	TeamRepo     repository.TeamRepository
+	StaffRepo    repository.StaffRepository
+	TagRepo      repository.TagRepository
+	WorkflowRepo repository.WorkflowRepository
+	Tickets      *TicketService
+	Assignments  *AssignmentService
+	Transactor   repository.Transactor
+}
+
+// MacroInput describes a macro create/update payload.
+type MacroInput struct {
+	Name      string
+	TeamID    *string
+	ReplyBody string
+	ReplyType domain.TicketMessageType
+	Actions   domain.MacroActions
+}
+
+// MacroResult is the ticket after a macro ran and the reply it posted, if any.
+type MacroResult struct {
+	Ticket  *domain.Ticket
+	Message *domain.TicketMessage
+}
+
+// NewMacroService constructs the service.
+func NewMacroService(deps MacroDependencies) *MacroService {
+	return &MacroService{
+		macros:      deps.MacroRepo,
+		teams:       deps.TeamRepo,
+		staff:       deps.StaffRepo,
+		tags:        deps.TagRepo,
+		workflows:   deps.WorkflowRepo,
+		tickets:     deps.Tickets,
+		assignments: deps.Assignments,
+		tx:          deps.Transactor,
+	}
+}
+
+// ListMacros returns the staff member's own macros and those shared with their team.
+func (s *MacroService) ListMacros(ctx context.Context, staff *domain.StaffMember) ([]domain.Macro, error) {
+	if staff == nil {
+		return nil, apperrors.NewUnauthorized("staff required")
+	}
+	macros, err := s.macros.ListVisible(ctx, staff.ID, staff.TeamID)
+	if err != nil {
+		return nil, apperrors.MapError(err)
+	}
+	return macros, nil
+}
+
+// GetMacro fetches a macro visible to the staff member.
+func (s *MacroService) GetMacro(ctx context.Context, staff *domain.StaffMember, id string) (*domain.Macro, error) {
+	if staff == nil {
+		return nil, apperrors.NewUnauthorized("staff required")
+	}
+	macro, err := s.macros.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperrors.NewNotFound("macro", map[string]any{"macro_id": id})
+		}
+		return nil, apperrors.MapError(err)
+	}
+	if !sharedVisible(staff, macro.OwnerStaffID, macro.TeamID) {
+		return nil, apperrors.NewNotFound("macro", map[string]any{"macro_id": id})
+	}
+	return macro, nil
+}
+
+// CreateMacro stores a new macro owned by the staff member.
+func (s *MacroService) CreateMacro(ctx context.Context, staff *domain.StaffMember, input MacroInput) (*domain.Macro, error) {
+	if staff == nil {
+		return nil, apperrors.NewUnauthorized("staff required")
+	}
+	macro := &domain.Macro{OwnerStaffID: staff.ID}
+	if err := s.applyInput(ctx, staff, macro, input); err != nil {
+		return nil, err
+	}
+	if err := s.macros.Create(ctx, macro); err != nil {
+		return nil, apperrors.MapError(err)
+	}
+	return macro, nil
+}
+
+// UpdateMacro replaces a macro; only the owner or an admin may change it.
+func (s *MacroService) UpdateMacro(ctx context.Context, staff *domain.StaffMember, id string, input MacroInput) (*domain.Macro, error) {
+	macro, err := s.GetMacro(ctx, staff, id)
+	if err != nil {
+		return nil, err
+	}
+	if macro.OwnerStaffID != staff.ID && staff.Role != domain.StaffRoleAdmin {
+		return nil, apperrors.NewForbidden("only the owner can change this macro")
+	}
+	if err := s.applyInput(ctx, staff, macro, input); err != nil {
+		return nil, err
+	}
+	if err := s.macros.Update(ctx, macro); err != nil {
+		return nil, apperrors.MapError(err)
+	}
+	return macro, nil
+}
+
+// DeleteMacro removes a macro; only the owner or an admin may delete it.
+func (s *MacroService) DeleteMacro(ctx context.Context, staff *domain.StaffMember, id string) error {
+	macro, err := s.GetMacro(ctx, staff, id)
+	if err != nil {
+		return err
+	}
+	if macro.OwnerStaffID != staff.ID && staff.Role != domain.StaffRoleAdmin {
+		return apperrors.NewForbidden("only the owner can delete this macro")
+	}
+	if err := s.macros.Delete(ctx, macro.ID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return apperrors.NewNotFound("macro", map[string]any{"macro_id": id})
+		}
+		return apperrors.MapError(err)
+	}
+	return nil
+}
+
+// ApplyMacro runs a macro against a ticket. Every action is checked before anything changes,
+// and the changes run in one transaction, so a macro that cannot fully apply leaves the ticket
+// untouched. Changes go through the regular ticket operations in order — assignee, priority,
+// tags, status, reply — and produce the same history, system messages and events as doing them
+// by hand; the events are published once the transaction commits.
+func (s *MacroService) ApplyMacro(ctx context.Context, staff *domain.StaffMember, ticketID, macroID string) (*MacroResult, error) {
+	macro, err := s.GetMacro(ctx, staff, macroID)
+	if err != nil {
+		return nil, err
+	}
+	ticket, err := s.tickets.ticketForStaff(ctx, staff, ticketID)
+	if err != nil {
+		return nil, err
+	}
+	actions := macro.Actions
+	comment := "macro:" + macro.Name
+
+	assignee, err := s.checkAssignee(ctx, staff, ticket, actions.AssigneeStaffID)
+	if err != nil {
+		return nil, err
+	}
+	if actions.Priority != nil && !validMacroPriority(*actions.Priority) {
+		return nil, apperrors.NewValidationError("invalid priority", map[string]any{"priority": *actions.Priority})
+	}
+	added, err := resolveCatalogTags(ctx, s.tags, actions.AddTags)
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range added {
+		if containsString(normalizeTagNames(actions.RemoveTags), name) {
+			return nil, apperrors.NewValidationError("tag cannot be both added and removed", map[string]any{"tag": name})
+		}
+	}
+	changeStatus, err := s.checkStatus(ctx, ticket, actions.Status, comment)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &MacroResult{}
+	err = withinTx(ctx, s.tx, func(ctx context.Context) error {
+		var err error
+		result.Ticket, result.Message, err = s.apply(ctx, staff, ticket, macro, assignee, added, changeStatus, comment)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// apply makes the checked changes of a macro.
+func (s *MacroService) apply(ctx context.Context, staff *domain.StaffMember, ticket *domain.Ticket, macro *domain.Macro, assignee string, added []string, changeStatus bool, comment string) (*domain.Ticket, *domain.TicketMessage, error) {
+	actions := macro.Actions
+	var err error
+	if assignee != "" {
+		if assignee == staff.ID {
+			_, err = s.assignments.SelfAssignTicket(ctx, staff, ticket.ID)
+		} else {
+			_, err = s.assignments.AssignTicketToStaff(ctx, staff, ticket.ID, assignee)
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+	if actions.Priority != nil && *actions.Priority != ticket.Priority {
+		if _, err := s.tickets.UpdatePriority(ctx, staff, ticket.ID, *actions.Priority); err != nil {
+			return nil, nil, err
+		}
+	}
+	if len(added) > 0 || len(actions.RemoveTags) > 0 {
+		if _, err := s.tickets.UpdateTags(ctx, staff, ticket.ID, added, actions.RemoveTags); err != nil {
+			return nil, nil, err
+		}
+	}
+	if changeStatus {
+		if _, err := s.tickets.UpdateStatus(ctx, staff, ticket.ID, *actions.Status, comment); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if ticket, err = s.tickets.ticketForStaff(ctx, staff, ticket.ID); err != nil {
+		return nil, nil, err
+	}
+	if strings.TrimSpace(macro.ReplyBody) == "" {
+		return ticket, nil, nil
+	}
+	values, err := s.tickets.replyTemplateValues(ctx, staff, ticket)
+	if err != nil {
+		return nil, nil, err
+	}
+	body := renderReplyTemplate(macro.ReplyBody, values)
+	msg, err := s.tickets.postMacroReply(ctx, staff, ticket, macro.ReplyType, body, actions.Status != nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	return ticket, msg, nil
+}
+
+// checkAssignee resolves the macro's assignee and checks the staff member may assign them.
+// An empty result means the assignee is unchanged.
+func (s *MacroService) checkAssignee(ctx context.Context, staff *domain.StaffMember, ticket *domain.Ticket, assigneeID *string) (string, error) {
+	if assigneeID == nil {
+		return "", nil
+	}
+	target := *assigneeID
+	if target == domain.MacroAssigneeSelf {
+		target = staff.ID
+	}
+	if ticket.AssigneeID != nil && *ticket.AssigneeID == target {
+		return "", nil
+	}
+	if target == staff.ID {
+		return target, nil
+	}
+	if err := requireAssignPriv(staff); err != nil {
+		return "", err
+	}
+	member, err := s.staff.GetByID(ctx, target)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", apperrors.NewNotFound("staff", map[string]any{"staff_id": target})
+		}
+		return "", apperrors.MapError(err)
+	}
+	if !member.Active {
+		return "", apperrors.NewConflict("assignee inactive", map[string]any{"staff_id": target})
+	}
+	return target, nil
+}
+
+// checkStatus validates the macro's status change against the ticket's workflow and reports
+// whether a change is needed.
+func (s *MacroService) checkStatus(ctx context.Context, ticket *domain.Ticket, status *domain.TicketStatus, comment string) (bool, error) {
+	if status == nil {
+		return false, nil
+	}
+	workflow, err := activeWorkflow(ctx, s.workflows, ticket.DepartmentID)
+	if err != nil {
+		return false, err
+	}
+	if workflow != nil {
+		if currentWorkflowKey(workflow, ticket) == string(*status) {
+			return false, nil
+		}
+		if _, err := checkWorkflowTransition(workflow, ticket, string(*status), comment); err != nil {
+			return false, err
+		}
+		return true, nil
+	}
+	if ticket.Status == *status {
+		return false, nil
+	}
+	if !isValidTransition(ticket.Status, *status) {
+		return false, apperrors.NewConflict("invalid status transition", map[string]any{"from": ticket.Status, "to": *status})
+	}
+	return true, nil
+}
+
+func (s *MacroService) applyInput(ctx context.Context, staff *domain.StaffMember, macro *domain.Macro, input MacroInput) error {
+	name := strings.TrimSpace(input.Name)
+	if name == "" {
+		return apperrors.NewValidationError("name required", nil)
+	}
+	if len(name) > maxReplyTemplateNameLength {
+		return apperrors.NewValidationError("name too long", map[string]any{"max_length": maxReplyTemplateNameLength})
+	}
+	body := strings.TrimSpace(input.ReplyBody)
+	if len(body) > maxReplyTemplateBodyLength {
+		return apperrors.NewValidationError("reply_body too long", map[string]any{"max_length": maxReplyTemplateBodyLength})
+	}
+	if err := validateReplyTemplate(body); err != nil {
+		return err
+	}
+	replyType := input.ReplyType
+	if replyType == "" {
+		replyType = domain.MessageTypePublicReply
+	}
+	if replyType != domain.MessageTypePublicReply && replyType != domain.MessageTypeInternalNote {
+		return apperrors.NewValidationError("invalid reply_type", map[string]any{"reply_type": replyType})
+	}
+
+	actions := input.Actions
+	if actions.Status != nil && strings.TrimSpace(string(*actions.Status)) == "" {
+		actions.Status = nil
+	}
+	if actions.Priority != nil && !validMacroPriority(*actions.Priority) {
+		return apperrors.NewValidationError("invalid priority", map[string]any{"priority": *actions.Priority})
+	}
+	added, err := resolveCatalogTags(ctx, s.tags, actions.AddTags)
+	if err != nil {
+		return err
+	}
+	actions.AddTags = added
+	actions.RemoveTags = normalizeTagNames(actions.RemoveTags)
+	for _, name := range actions.AddTags {
+		if containsString(actions.RemoveTags, name) {
+			return apperrors.NewValidationError("tag cannot be both added and removed", map[string]any{"tag": name})
+		}
+	}
+	if actions.AssigneeStaffID != nil {
+		switch id := strings.TrimSpace(*actions.AssigneeStaffID); id {
+		case "":
+			actions.AssigneeStaffID = nil
+		case domain.MacroAssigneeSelf:
+		default:
+			if _, err := s.staff.GetByID(ctx, id); err != nil {
+				if errors.Is(err, pgx.ErrNoRows) {
+					return apperrors.NewNotFound("staff", map[string]any{"staff_id": id})
+				}
+				return apperrors.MapError(err)
+			}
+			actions.AssigneeStaffID = &id
+		}
+	}
+	if body == "" && actions.Status == nil && actions.Priority == nil && len(actions.AddTags) == 0 &&
+		len(actions.RemoveTags) == 0 && actions.AssigneeStaffID == nil {
+		return apperrors.NewValidationError("macro needs a reply or at least one action", nil)
+	}
+	if err := checkTeamShare(ctx, s.teams, staff, input.TeamID); err != nil {
+		return err
+	}
+
+	macro.Name = name
+	macro.TeamID = input.TeamID
+	macro.ReplyBody = body
+	macro.ReplyType = replyType
+	macro.Actions = actions
+	return nil
+}
+
+func validMacroPriority(priority domain.TicketPriority) bool {
+	switch priority {
+	case domain.TicketPriorityLow, domain.TicketPriorityMedium, domain.TicketPriorityHigh, domain.TicketPriorityUrgent:
+		return true
+	}
+	return false
+}
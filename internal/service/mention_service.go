@@ -0,0 +1,77 @@
+package service
+
+import (
+	"context"
+
+	"github.com/spec-kit/ticket-service/internal/domain"
+	"github.com/spec-kit/ticket-service/internal/repository"
+	"github.com/spec-kit/ticket-service/internal/richtext"
+	apperrors "github.com/spec-kit/ticket-service/pkg/util/errorutil"
+)
+
+// MentionService serves a staff member's @mention inbox.
+type MentionService struct {
+	mentions repository.StaffMentionRepository
+}
+
+// MentionDependencies bundles repositories for the mention inbox.
+type MentionDependencies struct {
+	MentionRepo repository.StaffMentionRepository
+}
+
+// MentionFilter describes inbox listing options.
+type MentionFilter struct {
+	UnreadOnly bool
+	Limit      int
+	Cursor     string
+}
+
+// NewMentionService constructs the service.
+func NewMentionService(deps MentionDependencies) *MentionService {
+	return &MentionService{mentions: deps.MentionRepo}
+}
+
+// ListMentions returns the staff member's mentions, newest first.
+func (s *MentionService) ListMentions(ctx context.Context, staff *domain.StaffMember, filter MentionFilter) (repository.Page[domain.StaffMention], error) {
+	if staff == nil {
+		return repository.Page[domain.StaffMention]{}, apperrors.NewUnauthorized("staff required")
+	}
+	cursor, err := decodeCursor(filter.Cursor)
+	if err != nil {
+		return repository.Page[domain.StaffMention]{}, err
+	}
+	page, err := s.mentions.ListForStaff(ctx, repository.StaffMentionFilter{
+		StaffID:    staff.ID,
+		UnreadOnly: filter.UnreadOnly,
+		Limit:      repository.ClampPageSize(filter.Limit),
+		Cursor:     cursor,
+	})
+	if err != nil {
+		return repository.Page[domain.StaffMention]{}, apperrors.MapError(err)
+	}
+	for i := range page.Items {
+		mention := &page.Items[i]
+		mention.BodyPreview = stringPreview(richtext.PlainText(mention.BodyFormat, mention.BodyPreview), 200)
+	}
+	return page, nil
+}
+
+// MarkMentions sets the read state of the listed mentions, or of every mention when all is set.
+func (s *MentionService) MarkMentions(ctx context.Context, staff *domain.StaffMember, ids []string, all, read bool) (int, error) {
+	if staff == nil {
+		return 0, apperrors.NewUnauthorized("staff required")
+	}
+	if all {
+		ids = nil
+	} else {
+		ids = normalizeIDs(ids)
+		if len(ids) == 0 {
+			return 0, apperrors.NewValidationError("mention_ids or all required", nil)
+		}
+	}
+	updated, err := s.mentions.SetRead(ctx, staff.ID, ids, read)
+	if err != nil {
+		return 0, apperrors.MapError(err)
+	}
+	return updated, nil
+}
@@ -7,22 +7,51 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/spec-kit/ticket-service/internal/config"
+	"github.com/spec-kit/ticket-service/internal/domain"
 	"github.com/spec-kit/ticket-service/internal/events"
+	"github.com/spec-kit/ticket-service/internal/repository"
 )
 
 // NotificationService handles emitting notifications for domain events.
 type NotificationService struct {
-	dispatcher events.Dispatcher
-	logger     *zap.Logger
-	cfg        config.NotificationConfig
+	dispatcher   events.Dispatcher
+	logger       *zap.Logger
+	cfg          config.NotificationConfig
+	tickets      repository.TicketRepository
+	participants repository.TicketParticipantRepository
+	users        repository.UserRepository
+	staff        repository.StaffRepository
+}
+
+// NotificationDependencies bundles what the notification service needs to resolve recipients.
+type NotificationDependencies struct {
+	Dispatcher      events.Dispatcher
+	Logger          *zap.Logger
+	Config          config.NotificationConfig
+	TicketRepo      repository.TicketRepository
+	ParticipantRepo repository.TicketParticipantRepository
+	UserRepo        repository.UserRepository
+	StaffRepo       repository.StaffRepository
+}
+
+// notificationRecipient is one person an event fans out to.
+type notificationRecipient struct {
+	subject domain.SubjectType
+	// id is empty for external CC addresses.
+	id    string
+	email string
 }
 
 // NewNotificationService creates the service.
-func NewNotificationService(dispatcher events.Dispatcher, logger *zap.Logger, cfg config.NotificationConfig) *NotificationService {
+func NewNotificationService(deps NotificationDependencies) *NotificationService {
 	return &NotificationService{
-		dispatcher: dispatcher,
-		logger:     logger,
-		cfg:        cfg,
+		dispatcher:   deps.Dispatcher,
+		logger:       deps.Logger,
+		cfg:          deps.Config,
+		tickets:      deps.TicketRepo,
+		participants: deps.ParticipantRepo,
+		users:        deps.UserRepo,
+		staff:        deps.StaffRepo,
 	}
 }
 
@@ -35,6 +64,11 @@ func (n *NotificationService) RegisterHandlers() {
 	n.dispatcher.Subscribe(events.EventTicketStatusChanged, n.handleTicketStatusChanged)
 	n.dispatcher.Subscribe(events.EventTicketAssigned, n.handleTicketAssigned)
 	n.dispatcher.Subscribe(events.EventTicketMessageAdded, n.handleTicketMessageAdded)
+	n.dispatcher.Subscribe(events.EventTicketEscalated, n.handleTicketEscalated)
+	n.dispatcher.Subscribe(events.EventTicketAutoClosePending, n.handleTicketAutoClosePending)
+	n.dispatcher.Subscribe(events.EventTicketMerged, n.handleTicketMerged)
+	n.dispatcher.Subscribe(events.EventTicketFollowUpOpened, n.handleTicketFollowUpOpened)
+	n.dispatcher.Subscribe(events.EventTicketStaffMentioned, n.handleTicketStaffMentioned)
 }
 
 func (n *NotificationService) handleTicketCreated(ctx context.Context, event events.Event) error {
@@ -62,16 +96,152 @@ func (n *NotificationService) handleTicketMessageAdded(ctx context.Context, even
 	return nil
 }
 
+// handleTicketEscalated alerts the assignee and, for NOTIFY_MANAGER rules, the manager.
+// Escalations are internal, so requesters, CCs and watchers are never emailed.
+func (n *NotificationService) handleTicketEscalated(ctx context.Context, event events.Event) error {
+	n.logger.Info("TicketEscalated", zap.String("ticket_id", event.TicketID), zap.Any("payload", event.Payload))
+	n.sendWebhookNotificationStub(ctx, event)
+	payload, ok := event.Payload.(events.TicketEscalatedPayload)
+	if !ok || n.staff == nil || strings.TrimSpace(n.cfg.EmailFrom) == "" {
+		return nil
+	}
+	seen := map[string]bool{}
+	for _, id := range []*string{payload.AssigneeStaffID, payload.ManagerStaffID} {
+		if id == nil || seen[*id] {
+			continue
+		}
+		seen[*id] = true
+		member, err := n.staff.GetByID(ctx, *id)
+		if err != nil {
+			return err
+		}
+		if member.Active {
+			n.emailRecipientStub(event, notificationRecipient{subject: domain.SubjectTypeStaff, id: member.ID, email: member.Email})
+		}
+	}
+	return nil
+}
+
+func (n *NotificationService) handleTicketAutoClosePending(ctx context.Context, event events.Event) error {
+	n.logger.Info("TicketAutoClosePending", zap.String("ticket_id", event.TicketID), zap.Any("payload", event.Payload))
+	n.sendEmailNotificationStub(ctx, event)
+	return nil
+}
+
+func (n *NotificationService) handleTicketMerged(ctx context.Context, event events.Event) error {
+	n.logger.Info("TicketMerged", zap.String("ticket_id", event.TicketID), zap.Any("payload", event.Payload))
+	n.sendEmailNotificationStub(ctx, event)
+	n.sendWebhookNotificationStub(ctx, event)
+	return nil
+}
+
+func (n *NotificationService) handleTicketFollowUpOpened(ctx context.Context, event events.Event) error {
+	n.logger.Info("TicketFollowUpOpened", zap.String("ticket_id", event.TicketID), zap.Any("payload", event.Payload))
+	n.sendWebhookNotificationStub(ctx, event)
+	return nil
+}
+
+// handleTicketStaffMentioned notifies only the mentioned staff member.
+func (n *NotificationService) handleTicketStaffMentioned(ctx context.Context, event events.Event) error {
+	n.logger.Info("TicketStaffMentioned", zap.String("ticket_id", event.TicketID), zap.Any("payload", event.Payload))
+	payload, ok := event.Payload.(events.TicketStaffMentionedPayload)
+	if !ok || n.staff == nil || strings.TrimSpace(n.cfg.EmailFrom) == "" {
+		return nil
+	}
+	member, err := n.staff.GetByID(ctx, payload.MentionedStaffID)
+	if err != nil {
+		return err
+	}
+	n.emailRecipientStub(event, notificationRecipient{subject: domain.SubjectTypeStaff, id: member.ID, email: member.Email})
+	return nil
+}
+
+// sendEmailNotificationStub fans an event out to the requester, assignee, watchers and CCs,
+// skipping whoever caused it. Internal notes only reach staff.
 func (n *NotificationService) sendEmailNotificationStub(ctx context.Context, event events.Event) {
 	if strings.TrimSpace(n.cfg.EmailFrom) == "" {
 		return
 	}
+	for _, recipient := range n.recipients(ctx, event) {
+		n.emailRecipientStub(event, recipient)
+	}
+}
+
+func (n *NotificationService) emailRecipientStub(event events.Event, recipient notificationRecipient) {
 	n.logger.Debug("sendEmailNotificationStub",
 		zap.String("from", n.cfg.EmailFrom),
+		zap.String("to", recipient.email),
+		zap.String("recipient_type", string(recipient.subject)),
 		zap.String("ticket_id", event.TicketID),
 		zap.String("event_type", string(event.Type)))
 }
 
+func (n *NotificationService) recipients(ctx context.Context, event events.Event) []notificationRecipient {
+	if n.tickets == nil {
+		return nil
+	}
+	ticket, err := n.tickets.GetByID(ctx, event.TicketID)
+	if err != nil {
+		n.logger.Warn("notification recipients: load ticket", zap.String("ticket_id", event.TicketID), zap.Error(err))
+		return nil
+	}
+	staffOnly := false
+	if payload, ok := event.Payload.(events.TicketMessageAddedPayload); ok {
+		staffOnly = payload.MessageType != domain.MessageTypePublicReply
+	}
+
+	var result []notificationRecipient
+	seen := map[string]bool{}
+	add := func(subject domain.SubjectType, id, email string) {
+		if email == "" || seen[strings.ToLower(email)] {
+			return
+		}
+		if staffOnly && subject != domain.SubjectTypeStaff {
+			return
+		}
+		if id != "" && ((subject == domain.SubjectTypeUser && event.Actor.UserID != nil && *event.Actor.UserID == id) ||
+			(subject == domain.SubjectTypeStaff && event.Actor.StaffID != nil && *event.Actor.StaffID == id)) {
+			return
+		}
+		seen[strings.ToLower(email)] = true
+		result = append(result, notificationRecipient{subject: subject, id: id, email: email})
+	}
+	addUser := func(id string) {
+		if user, err := n.users.GetByID(ctx, id); err == nil {
+			add(domain.SubjectTypeUser, user.ID, user.Email)
+		}
+	}
+	addStaff := func(id string) {
+		if member, err := n.staff.GetByID(ctx, id); err == nil && member.Active {
+			add(domain.SubjectTypeStaff, member.ID, member.Email)
+		}
+	}
+
+	addUser(ticket.RequesterID)
+	if ticket.AssigneeID != nil {
+		addStaff(*ticket.AssigneeID)
+	}
+	if n.participants == nil {
+		return result
+	}
+	participants, err := n.participants.ListByTicket(ctx, ticket.ID)
+	if err != nil {
+		n.logger.Warn("notification recipients: load participants", zap.String("ticket_id", ticket.ID), zap.Error(err))
+		return result
+	}
+	for _, participant := range participants {
+		switch {
+		case participant.StaffID != nil:
+			addStaff(*participant.StaffID)
+		case participant.UserID != nil:
+			addUser(*participant.UserID)
+		case participant.Email != nil:
+			add(domain.SubjectTypeUser, "", *participant.Email)
+		}
+	}
+	return result
+}
+
 func (n *NotificationService) sendWebhookNotificationStub(ctx context.Context, event events.Event) {
 	if strings.TrimSpace(n.cfg.WebhookURL) == "" {
 		return
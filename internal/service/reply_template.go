@@ -0,0 +1,88 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/spec-kit/ticket-service/internal/domain"
+	apperrors "github.com/spec-kit/ticket-service/pkg/util/errorutil"
+)
+
+var placeholderPattern = regexp.MustCompile(`\{\{\s*([a-z_]+\.[a-z_]+)\s*\}\}`)
+
+// replyPlaceholders lists the {{placeholders}} canned responses and macros may use.
+var replyPlaceholders = []string{
+	"requester.name",
+	"requester.email",
+	"ticket.external_key",
+	"ticket.title",
+	"ticket.status",
+	"ticket.priority",
+	"staff.name",
+	"staff.email",
+}
+
+// validateReplyTemplate rejects placeholders that would never be filled in.
+func validateReplyTemplate(body string) error {
+	for _, match := range placeholderPattern.FindAllStringSubmatch(body, -1) {
+		if !containsString(replyPlaceholders, match[1]) {
+			return apperrors.NewValidationError("unknown placeholder", map[string]any{
+				"placeholder": match[0],
+				"allowed":     replyPlaceholders,
+			})
+		}
+	}
+	return nil
+}
+
+// renderReplyTemplate substitutes placeholder values into a template body.
+func renderReplyTemplate(body string, values map[string]string) string {
+	return placeholderPattern.ReplaceAllStringFunc(body, func(token string) string {
+		key := placeholderPattern.FindStringSubmatch(token)[1]
+		if value, ok := values[key]; ok {
+			return value
+		}
+		return token
+	})
+}
+
+// replyTemplateValues gathers placeholder values for a ticket answered by the staff member.
+func (s *TicketService) replyTemplateValues(ctx context.Context, staff *domain.StaffMember, ticket *domain.Ticket) (map[string]string, error) {
+	values := map[string]string{
+		"ticket.external_key": ticket.ExternalKey,
+		"ticket.title":        ticket.Title,
+		"ticket.status":       string(ticket.Status),
+		"ticket.priority":     string(ticket.Priority),
+		"staff.name":          staff.Name,
+		"staff.email":         staff.Email,
+	}
+	if ticket.WorkflowStatus != nil {
+		values["ticket.status"] = *ticket.WorkflowStatus
+	}
+	requester, err := s.users.GetByID(ctx, ticket.RequesterID)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return nil, apperrors.MapError(err)
+	}
+	if requester != nil {
+		values["requester.name"] = strings.TrimSpace(requester.Name)
+		values["requester.email"] = requester.Email
+	}
+	return values, nil
+}
+
+// RenderReply fills a template body for a ticket the staff member can access.
+func (s *TicketService) RenderReply(ctx context.Context, staff *domain.StaffMember, ticketID, body string) (string, error) {
+	ticket, err := s.ticketForStaff(ctx, staff, ticketID)
+	if err != nil {
+		return "", err
+	}
+	values, err := s.replyTemplateValues(ctx, staff, ticket)
+	if err != nil {
+		return "", err
+	}
+	return renderReplyTemplate(body, values), nil
+}
@@ -0,0 +1,256 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/spec-kit/ticket-service/internal/domain"
+	"github.com/spec-kit/ticket-service/internal/repository"
+	apperrors "github.com/spec-kit/ticket-service/pkg/util/errorutil"
+)
+
+const maxSavedViewNameLength = 120
+
+// SavedViewService manages staff saved views and shared team queues.
+type SavedViewService struct {
+	views   repository.SavedViewRepository
+	teams   repository.TeamRepository
+	tickets *TicketService
+}
+
+// SavedViewDependencies bundles what saved views need; Tickets runs view filters for counts.
+type SavedViewDependencies struct {
+	SavedViewRepo repository.SavedViewRepository
+	TeamRepo      repository.TeamRepository
+	Tickets       *TicketService
+}
+
+// SavedViewInput describes a view create/update payload.
+type SavedViewInput struct {
+	Name   string
+	TeamID *string
+	Filter domain.SavedViewFilter
+	Sort   string
+}
+
+// SavedViewCount is the number of tickets currently matching a view.
+type SavedViewCount struct {
+	View  domain.SavedView
+	Count int
+}
+
+// NewSavedViewService constructs the service.
+func NewSavedViewService(deps SavedViewDependencies) *SavedViewService {
+	return &SavedViewService{
+		views:   deps.SavedViewRepo,
+		teams:   deps.TeamRepo,
+		tickets: deps.Tickets,
+	}
+}
+
+// ListViews returns the staff member's personal views followed by views shared with their team.
+func (s *SavedViewService) ListViews(ctx context.Context, staff *domain.StaffMember) ([]domain.SavedView, error) {
+	if staff == nil {
+		return nil, apperrors.NewUnauthorized("staff required")
+	}
+	views, err := s.views.ListVisible(ctx, staff.ID, staff.TeamID)
+	if err != nil {
+		return nil, apperrors.MapError(err)
+	}
+	return views, nil
+}
+
+// GetView fetches a view visible to the staff member.
+func (s *SavedViewService) GetView(ctx context.Context, staff *domain.StaffMember, id string) (*domain.SavedView, error) {
+	if staff == nil {
+		return nil, apperrors.NewUnauthorized("staff required")
+	}
+	view, err := s.views.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperrors.NewNotFound("saved_view", map[string]any{"view_id": id})
+		}
+		return nil, apperrors.MapError(err)
+	}
+	if !savedViewVisible(staff, view) {
+		return nil, apperrors.NewNotFound("saved_view", map[string]any{"view_id": id})
+	}
+	return view, nil
+}
+
+// CreateView stores a new view owned by the staff member.
+func (s *SavedViewService) CreateView(ctx context.Context, staff *domain.StaffMember, input SavedViewInput) (*domain.SavedView, error) {
+	if staff == nil {
+		return nil, apperrors.NewUnauthorized("staff required")
+	}
+	view := &domain.SavedView{OwnerStaffID: staff.ID}
+	if err := s.applyInput(ctx, staff, view, input); err != nil {
+		return nil, err
+	}
+	if err := s.views.Create(ctx, view); err != nil {
+		return nil, apperrors.MapError(err)
+	}
+	return view, nil
+}
+
+// UpdateView replaces a view's definition; only the owner or an admin may change it.
+func (s *SavedViewService) UpdateView(ctx context.Context, staff *domain.StaffMember, id string, input SavedViewInput) (*domain.SavedView, error) {
+	view, err := s.GetView(ctx, staff, id)
+	if err != nil {
+		return nil, err
+	}
+	if !savedViewEditable(staff, view) {
+		return nil, apperrors.NewForbidden("only the owner can change this view")
+	}
+	if err := s.applyInput(ctx, staff, view, input); err != nil {
+		return nil, err
+	}
+	if err := s.views.Update(ctx, view); err != nil {
+		return nil, apperrors.MapError(err)
+	}
+	return view, nil
+}
+
+// DeleteView removes a view; only the owner or an admin may delete it.
+func (s *SavedViewService) DeleteView(ctx context.Context, staff *domain.StaffMember, id string) error {
+	view, err := s.GetView(ctx, staff, id)
+	if err != nil {
+		return err
+	}
+	if !savedViewEditable(staff, view) {
+		return apperrors.NewForbidden("only the owner can delete this view")
+	}
+	if err := s.views.Delete(ctx, view.ID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return apperrors.NewNotFound("saved_view", map[string]any{"view_id": id})
+		}
+		return apperrors.MapError(err)
+	}
+	return nil
+}
+
+// ViewFilter resolves a view into a staff ticket filter for the staff member running it.
+func (s *SavedViewService) ViewFilter(ctx context.Context, staff *domain.StaffMember, id string) (TicketStaffFilter, error) {
+	view, err := s.GetView(ctx, staff, id)
+	if err != nil {
+		return TicketStaffFilter{}, err
+	}
+	return savedViewTicketFilter(view, staff), nil
+}
+
+// ViewCounts returns every visible view with the number of tickets it currently matches.
+func (s *SavedViewService) ViewCounts(ctx context.Context, staff *domain.StaffMember) ([]SavedViewCount, error) {
+	views, err := s.ListViews(ctx, staff)
+	if err != nil {
+		return nil, err
+	}
+	counts := make([]SavedViewCount, 0, len(views))
+	for _, view := range views {
+		count, err := s.tickets.CountStaffTickets(ctx, staff, savedViewTicketFilter(&view, staff))
+		if err != nil {
+			return nil, err
+		}
+		counts = append(counts, SavedViewCount{View: view, Count: count})
+	}
+	return counts, nil
+}
+
+func (s *SavedViewService) applyInput(ctx context.Context, staff *domain.StaffMember, view *domain.SavedView, input SavedViewInput) error {
+	name := strings.TrimSpace(input.Name)
+	if name == "" {
+		return apperrors.NewValidationError("name required", nil)
+	}
+	if len(name) > maxSavedViewNameLength {
+		return apperrors.NewValidationError("name too long", map[string]any{"max_length": maxSavedViewNameLength})
+	}
+	if _, err := parseTicketSort(input.Sort); err != nil {
+		return err
+	}
+	if input.Filter.AssignedToMe && input.Filter.AssigneeID != nil {
+		return apperrors.NewValidationError("assigned_to_me and assignee_staff_id are mutually exclusive", nil)
+	}
+	for _, status := range input.Filter.Statuses {
+		switch status {
+		case domain.TicketStatusOpen, domain.TicketStatusInProgress, domain.TicketStatusPendingUser,
+			domain.TicketStatusResolved, domain.TicketStatusClosed, domain.TicketStatusCancelled:
+		default:
+			return apperrors.NewValidationError("invalid status", map[string]any{"status": status})
+		}
+	}
+	for _, p := range input.Filter.Priorities {
+		switch p {
+		case domain.TicketPriorityLow, domain.TicketPriorityMedium, domain.TicketPriorityHigh, domain.TicketPriorityUrgent:
+		default:
+			return apperrors.NewValidationError("invalid priority", map[string]any{"priority": p})
+		}
+	}
+	if err := checkTeamShare(ctx, s.teams, staff, input.TeamID); err != nil {
+		return err
+	}
+	view.Name = name
+	view.TeamID = input.TeamID
+	view.Filter = input.Filter
+	view.Sort = strings.TrimSpace(input.Sort)
+	return nil
+}
+
+func savedViewVisible(staff *domain.StaffMember, view *domain.SavedView) bool {
+	return sharedVisible(staff, view.OwnerStaffID, view.TeamID)
+}
+
+func savedViewEditable(staff *domain.StaffMember, view *domain.SavedView) bool {
+	return view.OwnerStaffID == staff.ID || staff.Role == domain.StaffRoleAdmin
+}
+
+// savedViewTicketFilter expands a stored filter, binding "assigned to me" to the staff member running it.
+func savedViewTicketFilter(view *domain.SavedView, staff *domain.StaffMember) TicketStaffFilter {
+	filter := TicketStaffFilter{
+		DepartmentID: view.Filter.DepartmentID,
+		TeamID:       view.Filter.TeamID,
+		AssigneeID:   view.Filter.AssigneeID,
+		Statuses:     view.Filter.Statuses,
+		Priorities:   view.Filter.Priorities,
+		SearchTerm:   view.Filter.Search,
+		CreatedFrom:  view.Filter.CreatedFrom,
+		CreatedTo:    view.Filter.CreatedTo,
+		UpdatedFrom:  view.Filter.UpdatedFrom,
+		UpdatedTo:    view.Filter.UpdatedTo,
+		CustomFields: view.Filter.CustomFields,
+		TagsAny:      view.Filter.TagsAny,
+		TagsAll:      view.Filter.TagsAll,
+		TagsNone:     view.Filter.TagsNone,
+		Sort:         view.Sort,
+	}
+	if view.Filter.AssignedToMe {
+		filter.AssigneeID = &staff.ID
+	}
+	return filter
+}
+
+// checkTeamShare allows sharing with the staff member's own team, or any existing team for admins.
+func checkTeamShare(ctx context.Context, teams repository.TeamRepository, staff *domain.StaffMember, teamID *string) error {
+	if teamID == nil {
+		return nil
+	}
+	if staff.Role != domain.StaffRoleAdmin && (staff.TeamID == nil || *staff.TeamID != *teamID) {
+		return apperrors.NewForbidden("can only share with your own team")
+	}
+	if _, err := teams.GetByID(ctx, *teamID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return apperrors.NewNotFound("team", map[string]any{"team_id": *teamID})
+		}
+		return apperrors.MapError(err)
+	}
+	return nil
+}
+
+// sharedVisible reports whether an owned, optionally team-shared item is visible to the staff member.
+func sharedVisible(staff *domain.StaffMember, ownerID string, teamID *string) bool {
+	if ownerID == staff.ID {
+		return true
+	}
+	return teamID != nil && staff.TeamID != nil && *teamID == *staff.TeamID
+}
@@ -0,0 +1,78 @@
+package service
+
+import (
+	"strings"
+
+	"github.com/spec-kit/ticket-service/internal/domain"
+	"github.com/spec-kit/ticket-service/internal/repository"
+)
+
+// parseSearchQuery splits a search box query into full-text terms and field filters.
+// Supported syntax: bare words, "quoted phrases", -negation, OR, and the prefixes
+// tag:, status: and priority: (each negatable, e.g. -tag:billing).
+func parseSearchQuery(raw string, includeInternal bool) *repository.TicketSearch {
+	search := &repository.TicketSearch{IncludeInternal: includeInternal}
+	terms := []string{}
+	for _, token := range tokenizeSearch(raw) {
+		negated := strings.HasPrefix(token, "-")
+		body := strings.TrimPrefix(token, "-")
+		field, value, ok := strings.Cut(body, ":")
+		value = strings.Trim(value, `"`)
+		if !ok || value == "" {
+			terms = append(terms, token)
+			continue
+		}
+		switch strings.ToLower(field) {
+		case "tag":
+			tag := domain.NormalizeTagName(value)
+			if negated {
+				search.ExcludeTags = append(search.ExcludeTags, tag)
+			} else {
+				search.Tags = append(search.Tags, tag)
+			}
+		case "status":
+			status := strings.ToUpper(value)
+			if negated {
+				search.ExcludeStatuses = append(search.ExcludeStatuses, status)
+			} else {
+				search.Statuses = append(search.Statuses, status)
+			}
+		case "priority":
+			priority := domain.TicketPriority(strings.ToUpper(value))
+			if negated {
+				search.ExcludePriorities = append(search.ExcludePriorities, priority)
+			} else {
+				search.Priorities = append(search.Priorities, priority)
+			}
+		default:
+			terms = append(terms, token)
+		}
+	}
+	search.Text = strings.Join(terms, " ")
+	return search
+}
+
+// tokenizeSearch splits on whitespace while keeping double-quoted sections together.
+func tokenizeSearch(raw string) []string {
+	tokens := []string{}
+	var current strings.Builder
+	quoted := false
+	for _, r := range raw {
+		switch {
+		case r == '"':
+			quoted = !quoted
+			current.WriteRune(r)
+		case !quoted && (r == ' ' || r == '\t' || r == '\n'):
+			if current.Len() > 0 {
+				tokens = append(tokens, current.String())
+				current.Reset()
+			}
+		default:
+			current.WriteRune(r)
+		}
+	}
+	if current.Len() > 0 {
+		tokens = append(tokens, current.String())
+	}
+	return tokens
+}
@@ -0,0 +1,46 @@
+package service
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/spec-kit/ticket-service/internal/domain"
+	"github.com/spec-kit/ticket-service/internal/repository"
+)
+
+func TestParseSearchQuery(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  repository.TicketSearch
+	}{
+		{"bare words", "printer  jam", repository.TicketSearch{Text: "printer jam"}},
+		{"phrase, negation and OR kept as text", `"paper jam" -toner OR fuser`, repository.TicketSearch{Text: `"paper jam" -toner OR fuser`}},
+		{"field filters", "refund tag:Billing status:open priority:urgent", repository.TicketSearch{
+			Text:       "refund",
+			Tags:       []string{"billing"},
+			Statuses:   []string{"OPEN"},
+			Priorities: []domain.TicketPriority{domain.TicketPriorityUrgent},
+		}},
+		{"negated filters", "-tag:spam -status:closed -priority:low", repository.TicketSearch{
+			ExcludeTags:       []string{"spam"},
+			ExcludeStatuses:   []string{"CLOSED"},
+			ExcludePriorities: []domain.TicketPriority{domain.TicketPriorityLow},
+		}},
+		{"quoted tag value", `tag:"Needs Review" login`, repository.TicketSearch{Text: "login", Tags: []string{"needs-review"}}},
+		{"unknown prefix is text", "from:alice error:500", repository.TicketSearch{Text: "from:alice error:500"}},
+		{"empty value is text", "tag: status:", repository.TicketSearch{Text: "tag: status:"}},
+		{"unterminated quote", `"open ended tag:x`, repository.TicketSearch{Text: `"open ended tag:x`}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := parseSearchQuery(tt.input, false)
+			if !reflect.DeepEqual(*got, tt.want) {
+				t.Errorf("parseSearchQuery(%q) = %+v, want %+v", tt.input, *got, tt.want)
+			}
+		})
+	}
+	if got := parseSearchQuery("x", true); !got.IncludeInternal {
+		t.Error("IncludeInternal not carried through")
+	}
+}
@@ -0,0 +1,42 @@
+package service
+
+import (
+	"time"
+
+	"github.com/spec-kit/ticket-service/internal/config"
+	"github.com/spec-kit/ticket-service/internal/domain"
+)
+
+// slaDueAt returns the resolution deadline for a ticket opened at from, or nil when no target is configured.
+func slaDueAt(cfg config.SLAConfig, from time.Time, priority domain.TicketPriority) *time.Time {
+	var minutes int
+	switch priority {
+	case domain.TicketPriorityLow:
+		minutes = cfg.LowMinutes
+	case domain.TicketPriorityMedium:
+		minutes = cfg.MediumMinutes
+	case domain.TicketPriorityHigh:
+		minutes = cfg.HighMinutes
+	case domain.TicketPriorityUrgent:
+		minutes = cfg.UrgentMinutes
+	}
+	if minutes <= 0 {
+		return nil
+	}
+	due := from.Add(time.Duration(minutes) * time.Minute)
+	return &due
+}
+
+// nextPriority returns the priority one step more severe, or the input when already at the top.
+func nextPriority(priority domain.TicketPriority) domain.TicketPriority {
+	switch priority {
+	case domain.TicketPriorityLow:
+		return domain.TicketPriorityMedium
+	case domain.TicketPriorityMedium:
+		return domain.TicketPriorityHigh
+	case domain.TicketPriorityHigh:
+		return domain.TicketPriorityUrgent
+	default:
+		return priority
+	}
+}
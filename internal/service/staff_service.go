@@ -27,7 +27,7 @@ type StaffListFilters struct {
 	DepartmentID *string
 	Active       *bool
 	Limit        int
-	Offset       int
+	Cursor       string
 }
 
 // TeamListFilters define query params for teams.
@@ -61,14 +61,19 @@ func requireAdmin(actor *domain.StaffMember) error {
 }
 
 // CreateDepartment creates a new department.
-func (s *StaffService) CreateDepartment(ctx context.Context, actor *domain.StaffMember, name, description string) (*domain.Department, error) {
+func (s *StaffService) CreateDepartment(ctx context.Context, actor *domain.StaffMember, name, description string, allowedAttachmentTypes []string) (*domain.Department, error) {
 	if err := requireAdmin(actor); err != nil {
 		return nil, err
 	}
+	allowedAttachmentTypes, err := normalizeMimePatterns(allowedAttachmentTypes)
+	if err != nil {
+		return nil, err
+	}
 	dept := &domain.Department{
-		Name:        name,
-		Description: description,
-		IsActive:    true,
+		Name:                   name,
+		Description:            description,
+		IsActive:               true,
+		AllowedAttachmentTypes: allowedAttachmentTypes,
 	}
 	if err := s.departments.Create(ctx, dept); err != nil {
 		return nil, apperrors.MapError(err)
@@ -97,6 +102,11 @@ func (s *StaffService) UpdateDepartment(ctx context.Context, actor *domain.Staff
 	if err := requireAdmin(actor); err != nil {
 		return nil, err
 	}
+	allowedAttachmentTypes, err := normalizeMimePatterns(dept.AllowedAttachmentTypes)
+	if err != nil {
+		return nil, err
+	}
+	dept.AllowedAttachmentTypes = allowedAttachmentTypes
 	if err := s.departments.Update(ctx, dept); err != nil {
 		return nil, apperrors.MapError(err)
 	}
@@ -205,17 +215,21 @@ func (s *StaffService) CreateStaffMember(ctx context.Context, actor *domain.Staf
 }
 
 // ListStaffMembers lists staff with filters.
-func (s *StaffService) ListStaffMembers(ctx context.Context, actor *domain.StaffMember, filters StaffListFilters) ([]domain.StaffMember, error) {
+func (s *StaffService) ListStaffMembers(ctx context.Context, actor *domain.StaffMember, filters StaffListFilters) (repository.Page[domain.StaffMember], error) {
 	if err := requireAdmin(actor); err != nil {
-		return nil, err
+		return repository.Page[domain.StaffMember]{}, err
+	}
+	cursor, err := decodeCursor(filters.Cursor)
+	if err != nil {
+		return repository.Page[domain.StaffMember]{}, err
 	}
 	repoFilter := repository.StaffFilter{
 		Role:         filters.Role,
 		TeamID:       filters.TeamID,
 		DepartmentID: filters.DepartmentID,
 		Active:       filters.Active,
-		Limit:        filters.Limit,
-		Offset:       filters.Offset,
+		Limit:        repository.ClampPageSize(filters.Limit),
+		Cursor:       cursor,
 	}
 	return s.staff.List(ctx, repoFilter)
 }
@@ -0,0 +1,206 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/spec-kit/ticket-service/internal/domain"
+	"github.com/spec-kit/ticket-service/internal/repository"
+	apperrors "github.com/spec-kit/ticket-service/pkg/util/errorutil"
+)
+
+var tagColorPattern = regexp.MustCompile(`^#[0-9a-fA-F]{6}$`)
+
+// TagService manages the tag catalog.
+type TagService struct {
+	tags repository.TagRepository
+}
+
+// TagDependencies bundles repositories for tag management.
+type TagDependencies struct {
+	TagRepo repository.TagRepository
+}
+
+// TagInput describes tag create/update payloads.
+type TagInput struct {
+	Name        string
+	Color       *string
+	Description string
+	Deprecated  *bool
+}
+
+// NewTagService constructs the service.
+func NewTagService(deps TagDependencies) *TagService {
+	return &TagService{tags: deps.TagRepo}
+}
+
+// ListTags returns catalog tags for staff pickers.
+func (s *TagService) ListTags(ctx context.Context, filter repository.TagFilter) ([]domain.Tag, error) {
+	filter.Prefix = domain.NormalizeTagName(filter.Prefix)
+	tags, err := s.tags.List(ctx, filter)
+	if err != nil {
+		return nil, apperrors.MapError(err)
+	}
+	return tags, nil
+}
+
+// CreateTag adds a tag to the catalog.
+func (s *TagService) CreateTag(ctx context.Context, actor *domain.StaffMember, input TagInput) (*domain.Tag, error) {
+	if err := requireAdmin(actor); err != nil {
+		return nil, err
+	}
+	tag := &domain.Tag{}
+	if err := applyTagInput(tag, input); err != nil {
+		return nil, err
+	}
+	if err := s.tags.Create(ctx, tag); err != nil {
+		return nil, apperrors.MapError(err)
+	}
+	return tag, nil
+}
+
+// UpdateTag renames, recolors or deprecates a tag; renames carry over to tagged tickets.
+func (s *TagService) UpdateTag(ctx context.Context, actor *domain.StaffMember, id string, input TagInput) (*domain.Tag, error) {
+	if err := requireAdmin(actor); err != nil {
+		return nil, err
+	}
+	tag, err := s.getTag(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := applyTagInput(tag, input); err != nil {
+		return nil, err
+	}
+	if err := s.tags.Update(ctx, tag); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperrors.NewNotFound("tag", map[string]any{"tag_id": id})
+		}
+		return nil, apperrors.MapError(err)
+	}
+	return tag, nil
+}
+
+// MergeTag folds the source tag into the target and removes the source from the catalog.
+func (s *TagService) MergeTag(ctx context.Context, actor *domain.StaffMember, sourceID, targetID string) (*domain.Tag, int, error) {
+	if err := requireAdmin(actor); err != nil {
+		return nil, 0, err
+	}
+	if sourceID == targetID {
+		return nil, 0, apperrors.NewValidationError("cannot merge a tag into itself", map[string]any{"tag_id": sourceID})
+	}
+	source, err := s.getTag(ctx, sourceID)
+	if err != nil {
+		return nil, 0, err
+	}
+	target, err := s.getTag(ctx, targetID)
+	if err != nil {
+		return nil, 0, err
+	}
+	retagged, err := s.tags.Merge(ctx, source, target)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, 0, apperrors.NewNotFound("tag", map[string]any{"tag_id": sourceID})
+		}
+		return nil, 0, apperrors.MapError(err)
+	}
+	return target, retagged, nil
+}
+
+// TagUsage reports per-tag ticket counts.
+func (s *TagService) TagUsage(ctx context.Context, actor *domain.StaffMember, filter repository.TagUsageFilter) ([]domain.TagUsage, error) {
+	if actor == nil {
+		return nil, apperrors.NewUnauthorized("staff required")
+	}
+	if actor.Role != domain.StaffRoleAdmin {
+		if actor.DepartmentID != nil {
+			filter.DepartmentID = actor.DepartmentID
+		}
+		if actor.TeamID != nil {
+			filter.TeamID = actor.TeamID
+		}
+	}
+	usage, err := s.tags.Usage(ctx, filter)
+	if err != nil {
+		return nil, apperrors.MapError(err)
+	}
+	return usage, nil
+}
+
+func (s *TagService) getTag(ctx context.Context, id string) (*domain.Tag, error) {
+	tag, err := s.tags.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperrors.NewNotFound("tag", map[string]any{"tag_id": id})
+		}
+		return nil, apperrors.MapError(err)
+	}
+	return tag, nil
+}
+
+func applyTagInput(tag *domain.Tag, input TagInput) error {
+	name := domain.NormalizeTagName(input.Name)
+	if !domain.IsValidTagName(name) {
+		return apperrors.NewValidationError("tag name must be 1-50 lowercase letters, digits, '-' or '_'", map[string]any{"name": input.Name})
+	}
+	if input.Color != nil && *input.Color != "" && !tagColorPattern.MatchString(*input.Color) {
+		return apperrors.NewValidationError("color must be a hex value like #1f6feb", map[string]any{"color": *input.Color})
+	}
+	tag.Name = name
+	tag.Color = input.Color
+	if tag.Color != nil && *tag.Color == "" {
+		tag.Color = nil
+	}
+	tag.Description = strings.TrimSpace(input.Description)
+	if input.Deprecated != nil {
+		tag.Deprecated = *input.Deprecated
+	}
+	return nil
+}
+
+// normalizeTagNames normalizes and de-duplicates tag names, keeping their order.
+func normalizeTagNames(names []string) []string {
+	result := make([]string, 0, len(names))
+	seen := make(map[string]struct{}, len(names))
+	for _, name := range names {
+		name = domain.NormalizeTagName(name)
+		if name == "" {
+			continue
+		}
+		if _, dup := seen[name]; dup {
+			continue
+		}
+		seen[name] = struct{}{}
+		result = append(result, name)
+	}
+	return result
+}
+
+// resolveCatalogTags normalizes names and checks each is an active catalog tag.
+func resolveCatalogTags(ctx context.Context, tags repository.TagRepository, names []string) ([]string, error) {
+	names = normalizeTagNames(names)
+	if tags == nil || len(names) == 0 {
+		return names, nil
+	}
+	found, err := tags.ListByNames(ctx, names)
+	if err != nil {
+		return nil, apperrors.MapError(err)
+	}
+	known := make(map[string]domain.Tag, len(found))
+	for _, tag := range found {
+		known[tag.Name] = tag
+	}
+	for _, name := range names {
+		tag, ok := known[name]
+		if !ok {
+			return nil, apperrors.NewValidationError("unknown tag", map[string]any{"tag": name})
+		}
+		if tag.Deprecated {
+			return nil, apperrors.NewValidationError("tag is deprecated", map[string]any{"tag": name})
+		}
+	}
+	return names, nil
+}
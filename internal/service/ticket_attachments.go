@@ -0,0 +1,151 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/spec-kit/ticket-service/internal/domain"
+	"github.com/spec-kit/ticket-service/internal/events"
+	apperrors "github.com/spec-kit/ticket-service/pkg/util/errorutil"
+)
+
+// resolveUploads matches a message's attachments to uploads the author made on the ticket and
+// replaces client-supplied metadata with what was recorded at upload time.
+func (s *TicketService) resolveUploads(ctx context.Context, ticketID string, authorType domain.MessageAuthorType, authorID string, attachments []MessageAttachmentInput) ([]MessageAttachmentInput, []string, error) {
+	if len(attachments) == 0 {
+		return nil, nil, nil
+	}
+	if s.uploads == nil {
+		return nil, nil, apperrors.NewValidationError("attachments are not supported", nil)
+	}
+	keys := make([]string, 0, len(attachments))
+	for _, att := range attachments {
+		if att.StorageKey == "" {
+			return nil, nil, apperrors.NewValidationError("attachment storage_key required", nil)
+		}
+		if !containsString(keys, att.StorageKey) {
+			keys = append(keys, att.StorageKey)
+		}
+	}
+	uploads, err := s.uploads.ListPending(ctx, ticketID, keys)
+	if err != nil {
+		return nil, nil, apperrors.MapError(err)
+	}
+	resolved := make([]MessageAttachmentInput, 0, len(keys))
+	ids := make([]string, 0, len(keys))
+	for _, key := range keys {
+		var match *domain.AttachmentUpload
+		for i := range uploads {
+			if uploads[i].StorageKey == key && uploads[i].UploaderType == authorType && uploads[i].UploaderID == authorID {
+				match = &uploads[i]
+				break
+			}
+		}
+		if match == nil {
+			return nil, nil, apperrors.NewValidationError("unknown upload", map[string]any{"storage_key": key})
+		}
+		if match.ScanStatus == domain.ScanStatusInfected || match.ScanStatus == domain.ScanStatusFailed {
+			return nil, nil, apperrors.NewValidationError("upload is quarantined", map[string]any{"storage_key": key, "scan_status": match.ScanStatus})
+		}
+		resolved = append(resolved, MessageAttachmentInput{
+			StorageKey: match.StorageKey,
+			FileName:   match.FileName,
+			MimeType:   match.MimeType,
+			SizeBytes:  match.SizeBytes,
+		})
+		ids = append(ids, match.ID)
+	}
+	return resolved, ids, nil
+}
+
+// releaseAttachmentObject deletes stored bytes once no attachment references them.
+func (s *TicketService) releaseAttachmentObject(ctx context.Context, storageKey string) error {
+	if s.storage == nil {
+		return nil
+	}
+	count, err := s.attachments.CountByStorageKey(ctx, storageKey)
+	if err != nil {
+		return apperrors.MapError(err)
+	}
+	if count > 0 {
+		return nil
+	}
+	if err := s.storage.Delete(ctx, storageKey); err != nil {
+		return apperrors.NewInternalError(err)
+	}
+	if err := s.storage.Delete(ctx, thumbnailKey(storageKey)); err != nil {
+		return apperrors.NewInternalError(err)
+	}
+	return nil
+}
+
+// thumbnailKey is where a rendered thumbnail is cached next to the original object.
+func thumbnailKey(storageKey string) string {
+	return storageKey + ".thumb"
+}
+
+// attachmentOnTicket loads an attachment and the message it belongs to, provided the message is
+// on the ticket. Attachments on internal notes are hidden unless includeInternal is set.
+func (s *TicketService) attachmentOnTicket(ctx context.Context, ticketID, attachmentID string, includeInternal bool) (*domain.AttachmentReference, *domain.TicketMessage, error) {
+	notFound := apperrors.NewNotFound("attachment", map[string]any{"attachment_id": attachmentID})
+	attachment, err := s.attachments.GetByID(ctx, attachmentID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil, notFound
+		}
+		return nil, nil, apperrors.MapError(err)
+	}
+	msg, err := s.messages.GetByID(ctx, attachment.TicketMessageID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil, notFound
+		}
+		return nil, nil, apperrors.MapError(err)
+	}
+	if msg.TicketID != ticketID {
+		return nil, nil, notFound
+	}
+	if msg.MessageType == domain.MessageTypeInternalNote && !includeInternal {
+		return nil, nil, notFound
+	}
+	return attachment, msg, nil
+}
+
+// reportInfectedUpload leaves an internal note on the upload's ticket and publishes an event so
+// staff can follow up with the uploader.
+func (s *TicketService) reportInfectedUpload(ctx context.Context, upload *domain.AttachmentUpload, signature string) error {
+	ticket, err := s.tickets.GetByID(ctx, upload.TicketID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil
+		}
+		return apperrors.MapError(err)
+	}
+	note := &domain.TicketMessage{
+		TicketID:    ticket.ID,
+		AuthorType:  domain.AuthorTypeSystem,
+		MessageType: domain.MessageTypeInternalNote,
+		Body:        fmt.Sprintf("Attachment %q was quarantined: malware detected (%s). It can no longer be downloaded.", upload.FileName, signature),
+	}
+	if err := s.storeMessage(ctx, ticket, note, nil, systemActor()); err != nil {
+		return err
+	}
+	s.publishEvent(ctx, events.Event{
+		Type:     events.EventAttachmentInfected,
+		TicketID: ticket.ID,
+		Actor:    systemActor(),
+		Payload: events.AttachmentInfectedPayload{
+			UploadID:      upload.ID,
+			FileName:      upload.FileName,
+			Signature:     signature,
+			UploaderType:  upload.UploaderType,
+			UploaderID:    upload.UploaderID,
+			MessageID:     upload.MessageID,
+			NoteMessageID: note.ID,
+		},
+	})
+	return nil
+}
@@ -0,0 +1,185 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+
+	"github.com/spec-kit/ticket-service/internal/domain"
+	apperrors "github.com/spec-kit/ticket-service/pkg/util/errorutil"
+)
+
+// emailHashPlaceholder is replaced in the avatar URL template with the author's email hash.
+const emailHashPlaceholder = "{email_hash}"
+
+// systemAuthor is shown for automated messages and changes.
+var systemAuthor = domain.AuthorProfile{Name: "System", Role: domain.AuthorRoleSystem}
+
+// authorRef identifies a message author or history actor.
+type authorRef struct {
+	authorType domain.MessageAuthorType
+	id         string
+}
+
+// resolveMessageAuthors fills Author on each message with at most one staff and one user lookup.
+func (s *TicketService) resolveMessageAuthors(ctx context.Context, msgs ...*domain.TicketMessage) error {
+	refs := make([]authorRef, 0, len(msgs))
+	for _, msg := range msgs {
+		if msg.AuthorID != nil {
+			refs = append(refs, authorRef{authorType: msg.AuthorType, id: *msg.AuthorID})
+		}
+	}
+	profiles, err := s.authorProfiles(ctx, refs)
+	if err != nil {
+		return err
+	}
+	for _, msg := range msgs {
+		msg.Author = authorProfileFor(profiles, msg.AuthorType, msg.AuthorID)
+	}
+	return nil
+}
+
+// resolveHistoryAuthors fills ChangedBy on each history entry.
+func (s *TicketService) resolveHistoryAuthors(ctx context.Context, entries []domain.TicketHistory) error {
+	refs := make([]authorRef, 0, len(entries))
+	for _, entry := range entries {
+		if entry.ChangedByID != nil {
+			refs = append(refs, authorRef{authorType: entry.ChangedByType, id: *entry.ChangedByID})
+		}
+	}
+	profiles, err := s.authorProfiles(ctx, refs)
+	if err != nil {
+		return err
+	}
+	for i := range entries {
+		entries[i].ChangedBy = authorProfileFor(profiles, entries[i].ChangedByType, entries[i].ChangedByID)
+	}
+	return nil
+}
+
+// authorProfiles loads the staff members and users behind refs. Authors who no longer exist are
+// left out and shown with a role but no name.
+func (s *TicketService) authorProfiles(ctx context.Context, refs []authorRef) (map[authorRef]domain.AuthorProfile, error) {
+	var staffIDs, userIDs []string
+	for _, ref := range refs {
+		switch ref.authorType {
+		case domain.AuthorTypeStaff:
+			if !containsString(staffIDs, ref.id) {
+				staffIDs = append(staffIDs, ref.id)
+			}
+		case domain.AuthorTypeUser:
+			if !containsString(userIDs, ref.id) {
+				userIDs = append(userIDs, ref.id)
+			}
+		}
+	}
+	profiles := make(map[authorRef]domain.AuthorProfile, len(staffIDs)+len(userIDs))
+	if len(staffIDs) > 0 && s.staff != nil {
+		members, err := s.staff.ListByIDs(ctx, staffIDs)
+		if err != nil {
+			return nil, apperrors.MapError(err)
+		}
+		for _, member := range members {
+			profiles[authorRef{authorType: domain.AuthorTypeStaff, id: member.ID}] = domain.AuthorProfile{
+				Name:      member.Name,
+				Role:      staffAuthorRole(member.Role),
+				AvatarURL: s.avatarURL(member.Email),
+			}
+		}
+	}
+	if len(userIDs) > 0 && s.users != nil {
+		users, err := s.users.ListByIDs(ctx, userIDs)
+		if err != nil {
+			return nil, apperrors.MapError(err)
+		}
+		for _, user := range users {
+			profiles[authorRef{authorType: domain.AuthorTypeUser, id: user.ID}] = domain.AuthorProfile{
+				Name:      user.Name,
+				Role:      domain.AuthorRoleCustomer,
+				AvatarURL: s.avatarURL(user.Email),
+			}
+		}
+	}
+	return profiles, nil
+}
+
+func authorProfileFor(profiles map[authorRef]domain.AuthorProfile, authorType domain.MessageAuthorType, id *string) domain.AuthorProfile {
+	if id != nil {
+		if profile, ok := profiles[authorRef{authorType: authorType, id: *id}]; ok {
+			return profile
+		}
+	}
+	switch authorType {
+	case domain.AuthorTypeStaff:
+		return domain.AuthorProfile{Role: domain.AuthorRoleAgent}
+	case domain.AuthorTypeUser:
+		return domain.AuthorProfile{Role: domain.AuthorRoleCustomer}
+	}
+	return systemAuthor
+}
+
+func staffAuthorRole(role domain.StaffRole) domain.AuthorRole {
+	switch role {
+	case domain.StaffRoleAdmin:
+		return domain.AuthorRoleAdmin
+	case domain.StaffRoleTeamLead:
+		return domain.AuthorRoleTeamLead
+	}
+	return domain.AuthorRoleAgent
+}
+
+// avatarURL fills the configured template, or returns nil when avatars are disabled.
+func (s *TicketService) avatarURL(email string) *string {
+	if s.authors.AvatarURLTemplate == "" || email == "" {
+		return nil
+	}
+	hash := sha256.Sum256([]byte(strings.ToLower(strings.TrimSpace(email))))
+	url := strings.ReplaceAll(s.authors.AvatarURLTemplate, emailHashPlaceholder, hex.EncodeToString(hash[:]))
+	return &url
+}
+
+// publicAgent is the profile end-users see for every staff author.
+func (s *TicketService) publicAgent() domain.AuthorProfile {
+	profile := domain.AuthorProfile{Name: s.authors.PublicAgentAlias, Role: domain.AuthorRoleAgent}
+	if s.authors.PublicAgentAvatarURL != "" {
+		avatar := s.authors.PublicAgentAvatarURL
+		profile.AvatarURL = &avatar
+	}
+	return profile
+}
+
+// hideStaffAuthors replaces staff identities on messages shown to end-users with the public
+// agent alias.
+func (s *TicketService) hideStaffAuthors(msgs []domain.TicketMessage) {
+	for i := range msgs {
+		if msgs[i].AuthorType == domain.AuthorTypeStaff {
+			msgs[i].AuthorID = nil
+			msgs[i].Author = s.publicAgent()
+		}
+	}
+}
+
+// hideStaffActors does the same for history shown to end-users and reduces assignee changes to
+// whether the ticket is assigned.
+func (s *TicketService) hideStaffActors(entries []domain.TicketHistory) {
+	for i := range entries {
+		entry := &entries[i]
+		if entry.ChangedByType == domain.AuthorTypeStaff {
+			entry.ChangedByID = nil
+			entry.ChangedBy = s.publicAgent()
+		}
+		if entry.ChangeType == domain.ChangeTypeAssignee {
+			entry.OldValue = publicAssigneeValue(entry.OldValue)
+			entry.NewValue = publicAssigneeValue(entry.NewValue)
+		}
+	}
+}
+
+func publicAssigneeValue(value map[string]any) map[string]any {
+	if value == nil {
+		return nil
+	}
+	assignee, _ := value["assignee_staff_id"].(string)
+	return map[string]any{"assigned": assignee != ""}
+}
@@ -0,0 +1,389 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/spec-kit/ticket-service/internal/domain"
+	"github.com/spec-kit/ticket-service/internal/repository"
+	apperrors "github.com/spec-kit/ticket-service/pkg/util/errorutil"
+)
+
+// TicketFormService manages department ticket forms and validates submissions against them.
+type TicketFormService struct {
+	forms       repository.TicketFormRepository
+	fields      repository.CustomFieldRepository
+	departments repository.DepartmentRepository
+	teams       repository.TeamRepository
+}
+
+// TicketFormDependencies bundles repositories for ticket forms.
+type TicketFormDependencies struct {
+	TicketFormRepo  repository.TicketFormRepository
+	CustomFieldRepo repository.CustomFieldRepository
+	DepartmentRepo  repository.DepartmentRepository
+	TeamRepo        repository.TeamRepository
+}
+
+// TicketFormInput describes a form submitted by an admin.
+type TicketFormInput struct {
+	Name     string
+	Fields   []domain.TicketFormField
+	IsActive *bool
+}
+
+// FormOption is a selectable value for ENUM and MULTI_SELECT fields.
+type FormOption struct {
+	Value string
+	Label string
+}
+
+// TicketFormFieldView is a form field resolved with its type and options for rendering.
+type TicketFormFieldView struct {
+	domain.TicketFormField
+	Type    domain.CustomFieldType
+	Options []FormOption
+	Builtin bool
+}
+
+// TicketFormView is the renderable form for a department.
+type TicketFormView struct {
+	ID           string
+	DepartmentID string
+	Name         string
+	Fields       []TicketFormFieldView
+}
+
+// NewTicketFormService constructs the service.
+func NewTicketFormService(deps TicketFormDependencies) *TicketFormService {
+	return &TicketFormService{
+		forms:       deps.TicketFormRepo,
+		fields:      deps.CustomFieldRepo,
+		departments: deps.DepartmentRepo,
+		teams:       deps.TeamRepo,
+	}
+}
+
+// GetForm returns the stored form for a department.
+func (s *TicketFormService) GetForm(ctx context.Context, actor *domain.StaffMember, departmentID string) (*domain.TicketForm, error) {
+	if err := requireAdmin(actor); err != nil {
+		return nil, err
+	}
+	form, err := s.forms.GetByDepartment(ctx, departmentID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperrors.NewNotFound("ticket form", map[string]any{"department_id": departmentID})
+		}
+		return nil, apperrors.MapError(err)
+	}
+	return form, nil
+}
+
+// SaveForm validates and stores the form for a department, replacing any existing one.
+func (s *TicketFormService) SaveForm(ctx context.Context, actor *domain.StaffMember, departmentID string, input TicketFormInput) (*domain.TicketForm, error) {
+	if err := requireAdmin(actor); err != nil {
+		return nil, err
+	}
+	if _, err := s.departments.GetByID(ctx, departmentID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperrors.NewNotFound("department", map[string]any{"department_id": departmentID})
+		}
+		return nil, apperrors.MapError(err)
+	}
+	defs, err := s.fields.List(ctx, repository.CustomFieldFilter{DepartmentID: &departmentID})
+	if err != nil {
+		return nil, apperrors.MapError(err)
+	}
+	form := &domain.TicketForm{
+		DepartmentID: departmentID,
+		Name:         strings.TrimSpace(input.Name),
+		Fields:       input.Fields,
+		IsActive:     true,
+	}
+	if input.IsActive != nil {
+		form.IsActive = *input.IsActive
+	}
+	if err := validateTicketForm(form, defs); err != nil {
+		return nil, err
+	}
+	if err := s.forms.Upsert(ctx, form); err != nil {
+		return nil, apperrors.MapError(err)
+	}
+	return form, nil
+}
+
+// DeleteForm reverts a department to the default form.
+func (s *TicketFormService) DeleteForm(ctx context.Context, actor *domain.StaffMember, departmentID string) error {
+	if err := requireAdmin(actor); err != nil {
+		return err
+	}
+	if err := s.forms.DeleteByDepartment(ctx, departmentID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return apperrors.NewNotFound("ticket form", map[string]any{"department_id": departmentID})
+		}
+		return apperrors.MapError(err)
+	}
+	return nil
+}
+
+// GetDepartmentForm returns the form requesters fill in for an active department.
+// Departments without a form get a default one built from the core fields and applicable custom fields.
+func (s *TicketFormService) GetDepartmentForm(ctx context.Context, departmentID string) (*TicketFormView, error) {
+	dept, err := s.departments.GetByID(ctx, departmentID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperrors.NewNotFound("department", map[string]any{"department_id": departmentID})
+		}
+		return nil, apperrors.MapError(err)
+	}
+	if !dept.IsActive {
+		return nil, apperrors.NewNotFound("department", map[string]any{"department_id": departmentID})
+	}
+	defs, err := s.fields.List(ctx, repository.CustomFieldFilter{DepartmentID: &departmentID})
+	if err != nil {
+		return nil, apperrors.MapError(err)
+	}
+	form, err := s.activeForm(ctx, departmentID)
+	if err != nil {
+		return nil, err
+	}
+	if form == nil {
+		form = defaultTicketForm(departmentID, defs)
+	}
+	teams, err := s.teams.List(ctx, &departmentID, false)
+	if err != nil {
+		return nil, apperrors.MapError(err)
+	}
+
+	byKey := make(map[string]domain.CustomFieldDefinition, len(defs))
+	for _, def := range defs {
+		byKey[def.Key] = def
+	}
+	view := &TicketFormView{ID: form.ID, DepartmentID: form.DepartmentID, Name: form.Name}
+	for _, field := range form.Fields {
+		fieldView := TicketFormFieldView{TicketFormField: field}
+		if domain.IsBuiltinFormField(field.Key) {
+			fieldView.Builtin = true
+			fieldView.Type, fieldView.Options = builtinFormFieldType(field.Key, teams)
+		} else {
+			def, ok := byKey[field.Key]
+			if !ok {
+				// the field was deactivated after the form was saved
+				continue
+			}
+			if fieldView.Label == "" {
+				fieldView.Label = def.Label
+			}
+			fieldView.Type = def.Type
+			for _, option := range def.Options {
+				fieldView.Options = append(fieldView.Options, FormOption{Value: option, Label: option})
+			}
+		}
+		view.Fields = append(view.Fields, fieldView)
+	}
+	return view, nil
+}
+
+// ValidateSubmission checks a ticket submission against the department form and returns it with
+// only the fields the form showed: hidden custom fields are dropped and priority, team and tags
+// are reset unless shown. The form's required flags replace the field definitions'.
+func (s *TicketFormService) ValidateSubmission(ctx context.Context, input TicketCreateInput) (TicketCreateInput, error) {
+	form, err := s.activeForm(ctx, input.DepartmentID)
+	if err != nil || form == nil {
+		return input, err
+	}
+
+	onForm := make(map[string]struct{}, len(form.Fields))
+	for _, field := range form.Fields {
+		onForm[field.Key] = struct{}{}
+	}
+	for key := range input.CustomFields {
+		if _, ok := onForm[key]; !ok {
+			return input, apperrors.NewValidationError("field not part of form", map[string]any{"field": key})
+		}
+	}
+
+	visible := make(map[string]bool, len(form.Fields))
+	cleaned := map[string]any{}
+	missing := []string{}
+	for _, field := range form.Fields {
+		shown := true
+		if cond := field.VisibleWhen; cond != nil {
+			shown = visible[cond.Field] && anyString(submissionValues(input, cond.Field), cond.Values)
+		}
+		visible[field.Key] = shown
+		if !shown {
+			continue
+		}
+		if field.Required && len(submissionValues(input, field.Key)) == 0 {
+			missing = append(missing, field.Key)
+		}
+		if value, ok := input.CustomFields[field.Key]; ok && !domain.IsBuiltinFormField(field.Key) {
+			cleaned[field.Key] = value
+		}
+	}
+	if len(missing) > 0 {
+		return input, apperrors.NewValidationError("required form fields missing", map[string]any{"fields": missing})
+	}
+	input.CustomFields = cleaned
+	if !visible[domain.FormFieldPriority] {
+		input.Priority = ""
+	}
+	if !visible[domain.FormFieldTeam] {
+		input.TeamID = nil
+	}
+	if !visible[domain.FormFieldTags] {
+		input.Tags = nil
+	}
+	input.FormValidated = true
+	return input, nil
+}
+
+func (s *TicketFormService) activeForm(ctx context.Context, departmentID string) (*domain.TicketForm, error) {
+	form, err := s.forms.GetByDepartment(ctx, departmentID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, apperrors.MapError(err)
+	}
+	if !form.IsActive {
+		return nil, nil
+	}
+	return form, nil
+}
+
+// validateTicketForm checks field keys against the built-ins and the department's custom fields.
+func validateTicketForm(form *domain.TicketForm, defs []domain.CustomFieldDefinition) error {
+	if form.Name == "" {
+		return apperrors.NewValidationError("name required", nil)
+	}
+	known := make(map[string]struct{}, len(defs))
+	for _, def := range defs {
+		known[def.Key] = struct{}{}
+	}
+	seen := make(map[string]struct{}, len(form.Fields))
+	for i := range form.Fields {
+		field := &form.Fields[i]
+		field.Label = strings.TrimSpace(field.Label)
+		field.HelpText = strings.TrimSpace(field.HelpText)
+		if _, ok := known[field.Key]; !ok && !domain.IsBuiltinFormField(field.Key) {
+			return apperrors.NewValidationError("unknown form field", map[string]any{"field": field.Key})
+		}
+		if _, dup := seen[field.Key]; dup {
+			return apperrors.NewValidationError("duplicate form field", map[string]any{"field": field.Key})
+		}
+		if cond := field.VisibleWhen; cond != nil {
+			if _, ok := seen[cond.Field]; !ok {
+				return apperrors.NewValidationError("visibility condition must reference an earlier field", map[string]any{"field": field.Key, "depends_on": cond.Field})
+			}
+			if len(cond.Values) == 0 {
+				return apperrors.NewValidationError("visibility condition requires values", map[string]any{"field": field.Key})
+			}
+		}
+		seen[field.Key] = struct{}{}
+	}
+	for _, key := range []string{domain.FormFieldTitle, domain.FormFieldDescription} {
+		if _, ok := seen[key]; !ok {
+			return apperrors.NewValidationError("form must include field", map[string]any{"field": key})
+		}
+	}
+	return nil
+}
+
+func defaultTicketForm(departmentID string, defs []domain.CustomFieldDefinition) *domain.TicketForm {
+	form := &domain.TicketForm{
+		DepartmentID: departmentID,
+		Name:         "Default",
+		Fields: []domain.TicketFormField{
+			{Key: domain.FormFieldTitle, Required: true},
+			{Key: domain.FormFieldDescription, Required: true},
+			{Key: domain.FormFieldPriority},
+		},
+		IsActive: true,
+	}
+	for _, def := range defs {
+		form.Fields = append(form.Fields, domain.TicketFormField{Key: def.Key, Required: def.Required})
+	}
+	return form
+}
+
+func builtinFormFieldType(key string, teams []domain.Team) (domain.CustomFieldType, []FormOption) {
+	switch key {
+	case domain.FormFieldPriority:
+		options := []FormOption{}
+		for _, priority := range []domain.TicketPriority{domain.TicketPriorityLow, domain.TicketPriorityMedium, domain.TicketPriorityHigh, domain.TicketPriorityUrgent} {
+			options = append(options, FormOption{Value: string(priority), Label: string(priority)})
+		}
+		return domain.CustomFieldTypeEnum, options
+	case domain.FormFieldTeam:
+		options := []FormOption{}
+		for _, team := range teams {
+			options = append(options, FormOption{Value: team.ID, Label: team.Name})
+		}
+		return domain.CustomFieldTypeEnum, options
+	case domain.FormFieldTags:
+		return domain.CustomFieldTypeMultiSelect, nil
+	default:
+		return domain.CustomFieldTypeText, nil
+	}
+}
+
+// submissionValues returns the non-empty values submitted for a form field as strings.
+func submissionValues(input TicketCreateInput, key string) []string {
+	nonEmpty := func(values ...string) []string {
+		result := []string{}
+		for _, value := range values {
+			if strings.TrimSpace(value) != "" {
+				result = append(result, value)
+			}
+		}
+		return result
+	}
+	switch key {
+	case domain.FormFieldTitle:
+		return nonEmpty(input.Title)
+	case domain.FormFieldDescription:
+		return nonEmpty(input.Description)
+	case domain.FormFieldPriority:
+		return nonEmpty(string(input.Priority))
+	case domain.FormFieldTags:
+		return nonEmpty(input.Tags...)
+	case domain.FormFieldTeam:
+		if input.TeamID == nil {
+			return nil
+		}
+		return nonEmpty(*input.TeamID)
+	}
+	switch value := input.CustomFields[key].(type) {
+	case string:
+		return nonEmpty(value)
+	case float64:
+		return []string{strconv.FormatFloat(value, 'f', -1, 64)}
+	case bool:
+		return []string{strconv.FormatBool(value)}
+	case []any:
+		result := []string{}
+		for _, item := range value {
+			if str, ok := item.(string); ok && strings.TrimSpace(str) != "" {
+				result = append(result, str)
+			}
+		}
+		return result
+	default:
+		return nil
+	}
+}
+
+func anyString(values, candidates []string) bool {
+	for _, value := range values {
+		if containsString(candidates, value) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,354 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/spec-kit/ticket-service/internal/domain"
+	apperrors "github.com/spec-kit/ticket-service/pkg/util/errorutil"
+)
+
+// maxLinkDepth bounds how far up a parent chain is walked when checking for cycles.
+const maxLinkDepth = 10
+
+var linkPhrases = map[domain.TicketLinkType]string{
+	domain.TicketLinkParentOf:     "is the parent of",
+	domain.TicketLinkChildOf:      "is a child of",
+	domain.TicketLinkBlocks:       "blocks",
+	domain.TicketLinkBlockedBy:    "is blocked by",
+	domain.TicketLinkRelated:      "is related to",
+	domain.TicketLinkDuplicateOf:  "is a duplicate of",
+	domain.TicketLinkDuplicatedBy: "is duplicated by",
+}
+
+// TicketLinkInput links the ticket in the path to another ticket.
+type TicketLinkInput struct {
+	TicketID string
+	Type     domain.TicketLinkType
+	// Propagate is only accepted on parent/child links.
+	Propagate bool
+}
+
+// ListLinks returns every link on a ticket, described from its side.
+func (s *TicketService) ListLinks(ctx context.Context, staff *domain.StaffMember, ticketID string) ([]domain.LinkedTicket, error) {
+	ticket, err := s.ticketForStaff(ctx, staff, ticketID)
+	if err != nil {
+		return nil, err
+	}
+	links, err := s.links.ListForTicket(ctx, ticket.ID)
+	if err != nil {
+		return nil, apperrors.MapError(err)
+	}
+	return links, nil
+}
+
+// LinkTickets creates a typed link between two tickets and records LINK history on both.
+func (s *TicketService) LinkTickets(ctx context.Context, staff *domain.StaffMember, ticketID string, input TicketLinkInput) (*domain.LinkedTicket, error) {
+	if !input.Type.IsValid() {
+		return nil, apperrors.NewValidationError("invalid link type", map[string]any{"type": input.Type})
+	}
+	if input.TicketID == "" {
+		return nil, apperrors.NewValidationError("ticket_id required", nil)
+	}
+	ticket, err := s.ticketForStaff(ctx, staff, ticketID)
+	if err != nil {
+		return nil, err
+	}
+	other, err := s.ticketForStaff(ctx, staff, input.TicketID)
+	if err != nil {
+		return nil, err
+	}
+	if ticket.ID == other.ID {
+		return nil, apperrors.NewValidationError("cannot link a ticket to itself", map[string]any{"ticket_id": ticket.ID})
+	}
+
+	linkType, swap := input.Type.Canonical()
+	source, target := ticket, other
+	if swap {
+		source, target = other, ticket
+	}
+	if input.Propagate && linkType != domain.TicketLinkParentOf {
+		return nil, apperrors.NewValidationError("propagate is only supported on parent/child links", map[string]any{"type": input.Type})
+	}
+
+	existing, err := s.links.ListForTicket(ctx, ticket.ID)
+	if err != nil {
+		return nil, apperrors.MapError(err)
+	}
+	for _, linked := range existing {
+		if linked.TicketID == other.ID && linked.Link.LinkType == linkType {
+			return nil, apperrors.NewConflict("tickets already linked", map[string]any{"link_id": linked.Link.ID, "type": linked.Type})
+		}
+	}
+	if linkType == domain.TicketLinkParentOf {
+		if err := s.checkParentLink(ctx, source.ID, target.ID); err != nil {
+			return nil, err
+		}
+	}
+
+	link := &domain.TicketLink{
+		SourceTicketID:   source.ID,
+		TargetTicketID:   target.ID,
+		LinkType:         linkType,
+		Propagate:        input.Propagate,
+		CreatedByStaffID: &staff.ID,
+	}
+	if err := s.links.Create(ctx, link); err != nil {
+		return nil, apperrors.MapError(err)
+	}
+	if err := s.recordLinkChange(ctx, staff, link, source, target, true); err != nil {
+		return nil, err
+	}
+	return &domain.LinkedTicket{
+		Link:        *link,
+		Type:        input.Type,
+		TicketID:    other.ID,
+		ExternalKey: other.ExternalKey,
+		Title:       other.Title,
+		Status:      other.Status,
+		Priority:    other.Priority,
+	}, nil
+}
+
+// SetLinkPropagation turns status and reply propagation on or off for a parent/child link.
+func (s *TicketService) SetLinkPropagation(ctx context.Context, staff *domain.StaffMember, ticketID, linkID string, propagate bool) (*domain.TicketLink, error) {
+	link, err := s.linkOnTicket(ctx, staff, ticketID, linkID)
+	if err != nil {
+		return nil, err
+	}
+	if link.LinkType != domain.TicketLinkParentOf {
+		return nil, apperrors.NewValidationError("propagate is only supported on parent/child links", map[string]any{"link_id": link.ID})
+	}
+	if err := s.links.SetPropagate(ctx, link.ID, propagate); err != nil {
+		return nil, apperrors.MapError(err)
+	}
+	link.Propagate = propagate
+	return link, nil
+}
+
+// UnlinkTickets removes a link and records LINK history on both tickets.
+func (s *TicketService) UnlinkTickets(ctx context.Context, staff *domain.StaffMember, ticketID, linkID string) error {
+	link, err := s.linkOnTicket(ctx, staff, ticketID, linkID)
+	if err != nil {
+		return err
+	}
+	source, err := s.tickets.GetByID(ctx, link.SourceTicketID)
+	if err != nil {
+		return apperrors.MapError(err)
+	}
+	target, err := s.tickets.GetByID(ctx, link.TargetTicketID)
+	if err != nil {
+		return apperrors.MapError(err)
+	}
+	if err := s.links.Delete(ctx, link.ID); err != nil {
+		return apperrors.MapError(err)
+	}
+	return s.recordLinkChange(ctx, staff, link, source, target, false)
+}
+
+// propagateStatus moves the children of a parent ticket to the parent's status where each
+// child's department workflow allows it. A child whose workflow has the parent's workflow status
+// key moves to that key, otherwise to its status for the parent's built-in status. Children
+// outside the staff member's scope are still updated.
+func (s *TicketService) propagateStatus(ctx context.Context, staff *domain.StaffMember, parent *domain.Ticket) error {
+	children, err := s.propagatingChildren(ctx, parent)
+	if err != nil {
+		return err
+	}
+	comment := "propagated_from:" + parent.ExternalKey
+	for _, child := range children {
+		workflow, err := activeWorkflow(ctx, s.workflows, child.DepartmentID)
+		if err != nil {
+			return err
+		}
+		target := string(parent.Status)
+		if workflow != nil && parent.WorkflowStatus != nil {
+			if _, ok := workflow.Status(*parent.WorkflowStatus); ok {
+				target = *parent.WorkflowStatus
+			}
+		}
+		if workflow != nil {
+			if current := currentWorkflowKey(workflow, child); current == target || (target == string(parent.Status) && child.Status == parent.Status) {
+				continue
+			}
+		} else if child.Status == parent.Status {
+			continue
+		}
+		status, workflowKey, err := nextStatus(workflow, child, target, comment)
+		if err != nil {
+			// the child's workflow does not allow the move; it keeps its status
+			continue
+		}
+		if err := s.transitionStatus(ctx, child, status, workflowKey, domain.AuthorTypeStaff, &staff.ID, staffActor(staff.ID), comment); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// propagateReply copies a staff public reply on a parent ticket to its open children. Each copy
+// points back at the reply so a redaction of the reply reaches it.
+func (s *TicketService) propagateReply(ctx context.Context, staff *domain.StaffMember, parent *domain.Ticket, reply *domain.TicketMessage, attachments []MessageAttachmentInput) error {
+	children, err := s.propagatingChildren(ctx, parent)
+	if err != nil {
+		return err
+	}
+	for _, child := range children {
+		if child.Status == domain.TicketStatusClosed || child.Status == domain.TicketStatusCancelled {
+			continue
+		}
+		msg := &domain.TicketMessage{
+			TicketID:     child.ID,
+			AuthorType:   domain.AuthorTypeStaff,
+			AuthorID:     &staff.ID,
+			MessageType:  domain.MessageTypePublicReply,
+			Body:         reply.Body,
+			BodyFormat:   reply.BodyFormat,
+			CopiedFromID: &reply.ID,
+		}
+		if err := s.postMessage(ctx, child, msg, attachments, staffActor(staff.ID)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// propagatingChildren loads the unmerged children whose links opt in to propagation.
+func (s *TicketService) propagatingChildren(ctx context.Context, parent *domain.Ticket) ([]*domain.Ticket, error) {
+	if s.links == nil {
+		return nil, nil
+	}
+	links, err := s.links.ListForTicket(ctx, parent.ID)
+	if err != nil {
+		return nil, apperrors.MapError(err)
+	}
+	var children []*domain.Ticket
+	for _, linked := range links {
+		if linked.Type != domain.TicketLinkParentOf || !linked.Link.Propagate {
+			continue
+		}
+		child, err := s.tickets.GetByID(ctx, linked.TicketID)
+		if err != nil {
+			return nil, apperrors.MapError(err)
+		}
+		if child.MergedIntoID == nil {
+			children = append(children, child)
+		}
+	}
+	return children, nil
+}
+
+// checkParentLink rejects a second parent for the child and links that would form a cycle.
+func (s *TicketService) checkParentLink(ctx context.Context, parentID, childID string) error {
+	existing, err := s.links.ParentOf(ctx, childID)
+	if err != nil {
+		return apperrors.MapError(err)
+	}
+	if existing != nil {
+		return apperrors.NewConflict("ticket already has a parent", map[string]any{"ticket_id": childID, "parent_ticket_id": *existing})
+	}
+	current := parentID
+	for depth := 0; depth < maxLinkDepth; depth++ {
+		next, err := s.links.ParentOf(ctx, current)
+		if err != nil {
+			return apperrors.MapError(err)
+		}
+		if next == nil {
+			return nil
+		}
+		if *next == childID {
+			return apperrors.NewConflict("link would create a parent cycle", map[string]any{"ticket_id": childID})
+		}
+		current = *next
+	}
+	return apperrors.NewConflict("parent chain too deep", map[string]any{"max_depth": maxLinkDepth})
+}
+
+// recordLinkChange notes the change on both tickets. The notes are internal, like link history,
+// because the other ticket may belong to a different requester.
+func (s *TicketService) recordLinkChange(ctx context.Context, staff *domain.StaffMember, link *domain.TicketLink, source, target *domain.Ticket, added bool) error {
+	action := "removed"
+	if added {
+		action = "added"
+	}
+	if err := s.addSystemNote(ctx, source.ID, fmt.Sprintf("Link %s: this ticket %s %s.", action, linkPhrases[link.LinkType], target.ExternalKey)); err != nil {
+		return err
+	}
+	if err := s.addSystemNote(ctx, target.ID, fmt.Sprintf("Link %s: this ticket %s %s.", action, linkPhrases[link.LinkType.Inverse()], source.ExternalKey)); err != nil {
+		return err
+	}
+	if s.history == nil {
+		return nil
+	}
+	sides := []struct {
+		ticket *domain.Ticket
+		other  *domain.Ticket
+		typ    domain.TicketLinkType
+	}{
+		{source, target, link.LinkType},
+		{target, source, link.LinkType.Inverse()},
+	}
+	for _, side := range sides {
+		value := map[string]any{
+			"link_id":             link.ID,
+			"type":                side.typ,
+			"ticket_id":           side.other.ID,
+			"ticket_external_key": side.other.ExternalKey,
+		}
+		entry := &domain.TicketHistory{
+			TicketID:      side.ticket.ID,
+			ChangedByType: domain.AuthorTypeStaff,
+			ChangedByID:   &staff.ID,
+			ChangeType:    domain.ChangeTypeLink,
+		}
+		if added {
+			entry.NewValue = value
+		} else {
+			entry.OldValue = value
+		}
+		if err := s.history.Create(ctx, entry); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// linkOnTicket loads a link that touches the ticket in the path.
+func (s *TicketService) linkOnTicket(ctx context.Context, staff *domain.StaffMember, ticketID, linkID string) (*domain.TicketLink, error) {
+	ticket, err := s.ticketForStaff(ctx, staff, ticketID)
+	if err != nil {
+		return nil, err
+	}
+	link, err := s.links.GetByID(ctx, linkID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperrors.NewNotFound("ticket_link", map[string]any{"link_id": linkID})
+		}
+		return nil, apperrors.MapError(err)
+	}
+	if link.SourceTicketID != ticket.ID && link.TargetTicketID != ticket.ID {
+		return nil, apperrors.NewNotFound("ticket_link", map[string]any{"link_id": linkID})
+	}
+	return link, nil
+}
+
+// ticketForStaff loads a ticket the staff member can access.
+func (s *TicketService) ticketForStaff(ctx context.Context, staff *domain.StaffMember, ticketID string) (*domain.Ticket, error) {
+	if staff == nil {
+		return nil, apperrors.NewUnauthorized("staff required")
+	}
+	ticket, err := s.tickets.GetByID(ctx, ticketID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperrors.NewNotFound("ticket", map[string]any{"ticket_id": ticketID})
+		}
+		return nil, apperrors.MapError(err)
+	}
+	if !s.staffCanAccessTicket(staff, ticket) {
+		return nil, apperrors.NewForbidden("access denied")
+	}
+	return ticket, nil
+}
@@ -0,0 +1,38 @@
+package service
+
+import (
+	"context"
+	"strings"
+
+	"github.com/spec-kit/ticket-service/internal/domain"
+)
+
+// postMacroReply adds a macro's staff reply. When the macro set a status itself, keepStatus
+// stops the reply from moving the ticket on again (e.g. a resolved ticket to pending user).
+func (s *TicketService) postMacroReply(ctx context.Context, staff *domain.StaffMember, ticket *domain.Ticket, messageType domain.TicketMessageType, body string, keepStatus bool) (*domain.TicketMessage, error) {
+	msg := &domain.TicketMessage{
+		TicketID:    ticket.ID,
+		AuthorType:  domain.AuthorTypeStaff,
+		AuthorID:    &staff.ID,
+		MessageType: messageType,
+		Body:        strings.TrimSpace(body),
+	}
+	post := s.postMessage
+	if keepStatus {
+		post = s.storeMessage
+	}
+	if err := post(ctx, ticket, msg, nil, staffActor(staff.ID)); err != nil {
+		return nil, err
+	}
+	switch messageType {
+	case domain.MessageTypePublicReply:
+		if err := s.propagateReply(ctx, staff, ticket, msg, nil); err != nil {
+			return nil, err
+		}
+	case domain.MessageTypeInternalNote:
+		if err := s.recordMentions(ctx, staff, ticket, msg); err != nil {
+			return nil, err
+		}
+	}
+	return msg, nil
+}
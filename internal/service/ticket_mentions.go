@@ -0,0 +1,108 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/spec-kit/ticket-service/internal/domain"
+	"github.com/spec-kit/ticket-service/internal/events"
+	"github.com/spec-kit/ticket-service/internal/richtext"
+	apperrors "github.com/spec-kit/ticket-service/pkg/util/errorutil"
+)
+
+// maxMentionsPerMessage bounds how many distinct handles one note can notify.
+const maxMentionsPerMessage = 20
+
+var (
+	// mentionPattern matches @email or @handle at the start of the body or after whitespace or an opening bracket.
+	mentionPattern       = regexp.MustCompile(`(?:^|[\s(\[])@([A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}|[A-Za-z0-9][A-Za-z0-9._-]*)`)
+	mentionHandleCleaner = regexp.MustCompile(`[^a-z0-9]`)
+)
+
+// parseMentions returns the distinct handles and emails mentioned in a body, in order of appearance.
+func parseMentions(body string) []string {
+	var mentions []string
+	for _, match := range mentionPattern.FindAllStringSubmatch(body, -1) {
+		mention := strings.ToLower(strings.TrimRight(match[1], "._-"))
+		if mention != "" && !containsString(mentions, mention) {
+			mentions = append(mentions, mention)
+		}
+		if len(mentions) == maxMentionsPerMessage {
+			break
+		}
+	}
+	return mentions
+}
+
+// recordMentions stores a mention for each staff member named in an internal note and publishes
+// ticket_staff_mentioned. Mentions are read from the note's plain text, so markup and links do not
+// count. Handles that match nobody, several people, the author, or staff without access to the
+// ticket are skipped.
+func (s *TicketService) recordMentions(ctx context.Context, author *domain.StaffMember, ticket *domain.Ticket, msg *domain.TicketMessage) error {
+	if s.mentions == nil {
+		return nil
+	}
+	text := richtext.PlainText(msg.BodyFormat, msg.Body)
+	for _, mention := range parseMentions(text) {
+		member, err := s.resolveMention(ctx, mention)
+		if err != nil {
+			return err
+		}
+		if member == nil || member.ID == author.ID || !s.staffCanAccessTicket(member, ticket) {
+			continue
+		}
+		record := &domain.StaffMention{
+			TicketID:           ticket.ID,
+			MessageID:          msg.ID,
+			MentionedStaffID:   member.ID,
+			MentionedByStaffID: &author.ID,
+		}
+		created, err := s.mentions.Create(ctx, record)
+		if err != nil {
+			return apperrors.MapError(err)
+		}
+		if !created {
+			continue
+		}
+		s.publishEvent(ctx, events.Event{
+			Type:     events.EventTicketStaffMentioned,
+			TicketID: ticket.ID,
+			Actor:    staffActor(author.ID),
+			Payload: events.TicketStaffMentionedPayload{
+				MentionID:        record.ID,
+				MessageID:        msg.ID,
+				MentionedStaffID: member.ID,
+				BodyPreview:      stringPreview(text, 120),
+			},
+		})
+	}
+	return nil
+}
+
+func (s *TicketService) resolveMention(ctx context.Context, mention string) (*domain.StaffMember, error) {
+	if strings.Contains(mention, "@") {
+		member, err := s.staff.GetByEmail(ctx, mention)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return nil, nil
+			}
+			return nil, apperrors.MapError(err)
+		}
+		if !member.Active {
+			return nil, nil
+		}
+		return member, nil
+	}
+	matches, err := s.staff.FindByHandle(ctx, mentionHandleCleaner.ReplaceAllString(mention, ""))
+	if err != nil {
+		return nil, apperrors.MapError(err)
+	}
+	if len(matches) != 1 {
+		return nil, nil
+	}
+	return &matches[0], nil
+}
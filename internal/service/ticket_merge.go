@@ -0,0 +1,186 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/spec-kit/ticket-service/internal/domain"
+	"github.com/spec-kit/ticket-service/internal/events"
+	apperrors "github.com/spec-kit/ticket-service/pkg/util/errorutil"
+)
+
+const (
+	maxMergeSources = 20
+	// maxMergeDepth bounds how many merge pointers are followed when resolving a ticket.
+	maxMergeDepth = 5
+)
+
+// MergeTickets folds duplicate source tickets into the target. Each source's messages and
+// attachments move to the target, the source is closed with a SYSTEM_EVENT pointing at the
+// target, and both sides record a MERGE history entry. All sources merge in one transaction,
+// so a failing source leaves nothing half-merged.
+func (s *TicketService) MergeTickets(ctx context.Context, staff *domain.StaffMember, targetID string, sourceIDs []string) (*domain.Ticket, error) {
+	if staff == nil {
+		return nil, apperrors.NewUnauthorized("staff required")
+	}
+	sourceIDs = normalizeIDs(sourceIDs)
+	if len(sourceIDs) == 0 {
+		return nil, apperrors.NewValidationError("source_ticket_ids required", nil)
+	}
+	if len(sourceIDs) > maxMergeSources {
+		return nil, apperrors.NewValidationError("too many source tickets", map[string]any{"max": maxMergeSources})
+	}
+	target, err := s.ticketForMerge(ctx, staff, targetID)
+	if err != nil {
+		return nil, err
+	}
+	if target.Status == domain.TicketStatusClosed || target.Status == domain.TicketStatusCancelled {
+		return nil, apperrors.NewConflict("cannot merge into a closed ticket", map[string]any{"ticket_id": target.ID, "status": target.Status})
+	}
+
+	sources := make([]*domain.Ticket, 0, len(sourceIDs))
+	for _, id := range sourceIDs {
+		if id == target.ID {
+			return nil, apperrors.NewValidationError("cannot merge a ticket into itself", map[string]any{"ticket_id": id})
+		}
+		source, err := s.ticketForMerge(ctx, staff, id)
+		if err != nil {
+			return nil, err
+		}
+		if source.RequesterID != target.RequesterID {
+			return nil, apperrors.NewConflict("tickets belong to different requesters", map[string]any{"ticket_id": source.ID})
+		}
+		sources = append(sources, source)
+	}
+
+	err = withinTx(ctx, s.tx, func(ctx context.Context) error {
+		for _, source := range sources {
+			if err := s.mergeInto(ctx, staff, source, target); err != nil {
+				return err
+			}
+		}
+		// bump the target so it surfaces at the top of recently-updated queues
+		if err := s.tickets.Update(ctx, target); err != nil {
+			return apperrors.MapError(err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return target, nil
+}
+
+func (s *TicketService) mergeInto(ctx context.Context, staff *domain.StaffMember, source, target *domain.Ticket) error {
+	moved, err := s.messages.MoveToTicket(ctx, source.ID, target.ID)
+	if err != nil {
+		return apperrors.MapError(err)
+	}
+	// CCs keep access once the conversation moves to the target
+	if s.participants != nil {
+		if _, err := s.participants.CopyToTicket(ctx, source.ID, target.ID); err != nil {
+			return apperrors.MapError(err)
+		}
+	}
+	if err := s.addSystemMessage(ctx, target.ID, fmt.Sprintf("Ticket %s was merged into this ticket.\n\n%s\n\n%s",
+		source.ExternalKey, source.Title, source.Description)); err != nil {
+		return err
+	}
+	if err := s.addSystemMessage(ctx, source.ID, fmt.Sprintf("This ticket was merged into %s. Please follow up there.", target.ExternalKey)); err != nil {
+		return err
+	}
+
+	source.MergedIntoID = &target.ID
+	if source.Status == domain.TicketStatusClosed || source.Status == domain.TicketStatusCancelled {
+		if err := s.tickets.Update(ctx, source); err != nil {
+			return apperrors.MapError(err)
+		}
+	} else if err := s.transitionStatus(ctx, source, domain.TicketStatusClosed, nil, domain.AuthorTypeStaff, &staff.ID, staffActor(staff.ID), "merged_into:"+target.ExternalKey); err != nil {
+		return err
+	}
+
+	if s.history != nil {
+		if err := s.history.Create(ctx, &domain.TicketHistory{
+			TicketID:      source.ID,
+			ChangedByType: domain.AuthorTypeStaff,
+			ChangedByID:   &staff.ID,
+			ChangeType:    domain.ChangeTypeMerge,
+			OldValue:      map[string]any{"merged_into": nil},
+			NewValue: map[string]any{
+				"merged_into":         target.ID,
+				"target_external_key": target.ExternalKey,
+			},
+		}); err != nil {
+			return err
+		}
+		if err := s.history.Create(ctx, &domain.TicketHistory{
+			TicketID:      target.ID,
+			ChangedByType: domain.AuthorTypeStaff,
+			ChangedByID:   &staff.ID,
+			ChangeType:    domain.ChangeTypeMerge,
+			NewValue: map[string]any{
+				"merged_from":         source.ID,
+				"source_external_key": source.ExternalKey,
+				"messages_moved":      moved,
+			},
+		}); err != nil {
+			return err
+		}
+	}
+
+	s.publishEvent(ctx, events.Event{
+		Type:     events.EventTicketMerged,
+		TicketID: source.ID,
+		Actor:    staffActor(staff.ID),
+		Payload: events.TicketMergedPayload{
+			TargetTicketID:    target.ID,
+			TargetExternalKey: target.ExternalKey,
+			RequesterUserID:   source.RequesterID,
+			MessagesMoved:     moved,
+		},
+	})
+	return nil
+}
+
+// ticketForMerge loads a ticket the staff member may merge; already-merged tickets are rejected.
+func (s *TicketService) ticketForMerge(ctx context.Context, staff *domain.StaffMember, ticketID string) (*domain.Ticket, error) {
+	ticket, err := s.tickets.GetByID(ctx, ticketID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperrors.NewNotFound("ticket", map[string]any{"ticket_id": ticketID})
+		}
+		return nil, apperrors.MapError(err)
+	}
+	if !s.staffCanAccessTicket(staff, ticket) {
+		return nil, apperrors.NewForbidden("access denied")
+	}
+	if ticket.MergedIntoID != nil {
+		return nil, apperrors.NewConflict("ticket already merged", map[string]any{"ticket_id": ticket.ID, "merged_into": *ticket.MergedIntoID})
+	}
+	return ticket, nil
+}
+
+// followMerges resolves a merged ticket to the ticket it was ultimately folded into.
+func (s *TicketService) followMerges(ctx context.Context, ticket *domain.Ticket) (*domain.Ticket, error) {
+	for depth := 0; ticket.MergedIntoID != nil && depth < maxMergeDepth; depth++ {
+		next, err := s.tickets.GetByID(ctx, *ticket.MergedIntoID)
+		if err != nil {
+			return nil, apperrors.MapError(err)
+		}
+		ticket = next
+	}
+	return ticket, nil
+}
+
+func normalizeIDs(ids []string) []string {
+	result := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if id != "" && !containsString(result, id) {
+			result = append(result, id)
+		}
+	}
+	return result
+}
@@ -0,0 +1,346 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/spec-kit/ticket-service/internal/domain"
+	"github.com/spec-kit/ticket-service/internal/richtext"
+	apperrors "github.com/spec-kit/ticket-service/pkg/util/errorutil"
+)
+
+// redactedPlaceholder replaces each redacted segment of a message body.
+const redactedPlaceholder = "[redacted]"
+
+// MessageRedactionInput selects what an admin removes from a message.
+type MessageRedactionInput struct {
+	// Segments are exact substrings of the body to replace with redactedPlaceholder.
+	Segments      []string
+	AttachmentIDs []string
+	Reason        string
+}
+
+// EditMessage lets staff change the body of their own reply or note within the configured
+// edit window. The previous body is kept as an admin-only revision.
+func (s *TicketService) EditMessage(ctx context.Context, staff *domain.StaffMember, ticketID, messageID, body string) (*domain.TicketMessage, error) {
+	ticket, err := s.ticketForStaff(ctx, staff, ticketID)
+	if err != nil {
+		return nil, err
+	}
+	msg, err := s.messageOnTicket(ctx, ticket.ID, messageID)
+	if err != nil {
+		return nil, err
+	}
+	if msg.AuthorType != domain.AuthorTypeStaff || msg.AuthorID == nil || *msg.AuthorID != staff.ID {
+		return nil, apperrors.NewForbidden("only the author can edit this message")
+	}
+	if msg.MessageType == domain.MessageTypeSystemEvent {
+		return nil, apperrors.NewValidationError("system messages cannot be edited", map[string]any{"message_id": msg.ID})
+	}
+	window := s.effects.EditWindow()
+	if window == 0 {
+		return nil, apperrors.NewForbidden("message editing is disabled")
+	}
+	if time.Since(msg.CreatedAt) > window {
+		return nil, apperrors.NewConflict("edit window has passed", map[string]any{"message_id": msg.ID, "window_minutes": int(window.Minutes())})
+	}
+	_, body, _ = richtext.Normalize(msg.BodyFormat, body)
+	if body == "" {
+		return nil, apperrors.NewValidationError("body required", nil)
+	}
+	if body == msg.Body {
+		return msg, nil
+	}
+
+	previous := msg.Body
+	now := time.Now().UTC()
+	msg.Body = body
+	msg.EditedAt = &now
+	err = withinTx(ctx, s.tx, func(ctx context.Context) error {
+		if err := s.revisions.Create(ctx, &domain.MessageRevision{
+			MessageID:     msg.ID,
+			Kind:          domain.MessageRevisionEdit,
+			EditorStaffID: &staff.ID,
+			PreviousBody:  &previous,
+		}); err != nil {
+			return apperrors.MapError(err)
+		}
+		if err := s.messages.UpdateBody(ctx, msg); err != nil {
+			return apperrors.MapError(err)
+		}
+		if err := s.recordMessageChange(ctx, staff, ticket.ID, domain.ChangeTypeMessageEdit, map[string]any{
+			"message_id":   msg.ID,
+			"message_type": msg.MessageType,
+		}); err != nil {
+			return err
+		}
+		if msg.MessageType == domain.MessageTypeInternalNote {
+			return s.recordMentions(ctx, staff, ticket, msg)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return msg, nil
+}
+
+// RedactMessage lets an admin strip sensitive text and attachments from any message. The
+// removed text is also scrubbed from earlier revisions and from copies of the message, and is
+// not kept anywhere. The database changes are made in one transaction; removed attachment
+// objects are deleted from storage only once it commits.
+func (s *TicketService) RedactMessage(ctx context.Context, staff *domain.StaffMember, ticketID, messageID string, input MessageRedactionInput) (*domain.TicketMessage, error) {
+	if err := requireAdmin(staff); err != nil {
+		return nil, err
+	}
+	ticket, err := s.ticketForStaff(ctx, staff, ticketID)
+	if err != nil {
+		return nil, err
+	}
+	msg, err := s.messageOnTicket(ctx, ticket.ID, messageID)
+	if err != nil {
+		return nil, err
+	}
+
+	var segments []string
+	for _, segment := range input.Segments {
+		if strings.TrimSpace(segment) == "" || containsString(segments, segment) {
+			continue
+		}
+		if !strings.Contains(msg.Body, segment) {
+			return nil, apperrors.NewValidationError("segment not found in message body", map[string]any{"message_id": msg.ID})
+		}
+		segments = append(segments, segment)
+	}
+	// longer segments first so a segment contained in another is not left half-replaced
+	sort.SliceStable(segments, func(i, j int) bool { return len(segments[i]) > len(segments[j]) })
+
+	attachments := msg.Attachments
+	attachmentIDs := normalizeIDs(input.AttachmentIDs)
+	var removed []domain.AttachmentReference
+	for _, id := range attachmentIDs {
+		found := false
+		for _, attachment := range attachments {
+			if attachment.ID == id {
+				removed = append(removed, attachment)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, apperrors.NewNotFound("attachment", map[string]any{"attachment_id": id})
+		}
+	}
+	if len(segments) == 0 && len(removed) == 0 {
+		return nil, apperrors.NewValidationError("segments or attachment_ids required", nil)
+	}
+
+	err = withinTx(ctx, s.tx, func(ctx context.Context) error {
+		if err := s.applyRedaction(ctx, staff, msg, segments, removed, input.Reason); err != nil {
+			return err
+		}
+		if err := s.recordMessageChange(ctx, staff, ticket.ID, domain.ChangeTypeMessageRedact, map[string]any{
+			"message_id":     msg.ID,
+			"segments":       len(segments),
+			"attachment_ids": attachmentIDs,
+		}); err != nil {
+			return err
+		}
+		return s.redactCopies(ctx, staff, ticket, msg, segments, removed, input.Reason)
+	})
+	if err != nil {
+		return nil, err
+	}
+	// copies share the original's storage keys, so releasing these covers theirs too
+	for _, attachment := range removed {
+		if err := s.releaseAttachmentObject(ctx, attachment.StorageKey); err != nil {
+			return nil, err
+		}
+	}
+
+	remaining := make([]domain.AttachmentReference, 0, len(attachments))
+	for _, attachment := range attachments {
+		if !containsString(attachmentIDs, attachment.ID) {
+			remaining = append(remaining, attachment)
+		}
+	}
+	msg.Attachments = remaining
+	return msg, nil
+}
+
+// applyRedaction replaces the segments in the message body and its earlier revisions, removes
+// the attachment references and records the redaction revision. Releasing the removed objects
+// from storage is left to the caller.
+func (s *TicketService) applyRedaction(ctx context.Context, staff *domain.StaffMember, msg *domain.TicketMessage, segments []string, removed []domain.AttachmentReference, reason string) error {
+	if len(segments) > 0 {
+		if err := s.revisions.RedactPrevious(ctx, msg.ID, segments, redactedPlaceholder); err != nil {
+			return apperrors.MapError(err)
+		}
+	}
+	if err := s.revisions.Create(ctx, &domain.MessageRevision{
+		MessageID:          msg.ID,
+		Kind:               domain.MessageRevisionRedact,
+		EditorStaffID:      &staff.ID,
+		RedactedSegments:   len(segments),
+		RemovedAttachments: removed,
+		Reason:             strings.TrimSpace(reason),
+	}); err != nil {
+		return apperrors.MapError(err)
+	}
+	for _, attachment := range removed {
+		if err := s.attachments.Delete(ctx, attachment.ID); err != nil && !errors.Is(err, pgx.ErrNoRows) {
+			return apperrors.MapError(err)
+		}
+	}
+	for _, segment := range segments {
+		msg.Body = strings.ReplaceAll(msg.Body, segment, redactedPlaceholder)
+	}
+	if msg.BodyFormat == domain.BodyFormatHTML {
+		// a segment cut through markup must not leave a broken or unsafe tag behind
+		msg.Body = richtext.Sanitize(msg.Body)
+	}
+	now := time.Now().UTC()
+	msg.RedactedAt = &now
+	if err := s.messages.UpdateBody(ctx, msg); err != nil {
+		return apperrors.MapError(err)
+	}
+	return nil
+}
+
+// redactCopies carries a redaction over to copies of the message text:
+//   - the ticket description, which a split copies from the first reply it moves, and the
+//     descriptions of tickets merged into this one;
+//   - system messages on the ticket, such as the merge notice quoting a merged ticket's description;
+//   - the replies propagated from the message to child tickets.
+//
+// Search vectors follow from the updated bodies and descriptions.
+func (s *TicketService) redactCopies(ctx context.Context, staff *domain.StaffMember, ticket *domain.Ticket, msg *domain.TicketMessage, segments []string, removed []domain.AttachmentReference, reason string) error {
+	if len(segments) > 0 {
+		if err := s.tickets.RedactDescriptions(ctx, ticket.ID, segments, redactedPlaceholder); err != nil {
+			return apperrors.MapError(err)
+		}
+		thread, err := s.messages.ListByTicket(ctx, ticket.ID)
+		if err != nil {
+			return apperrors.MapError(err)
+		}
+		for i := range thread {
+			if thread[i].MessageType != domain.MessageTypeSystemEvent {
+				continue
+			}
+			if err := s.redactCopy(ctx, staff, msg, &thread[i], segments, nil, reason); err != nil {
+				return err
+			}
+		}
+	}
+	copies, err := s.messages.ListCopies(ctx, msg.ID)
+	if err != nil {
+		return apperrors.MapError(err)
+	}
+	for i := range copies {
+		dup := &copies[i]
+		if err := s.hydrateMessages(ctx, dup); err != nil {
+			return err
+		}
+		if err := s.redactCopy(ctx, staff, msg, dup, segments, removed, reason); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// redactCopy redacts the segments found in dup and its attachments that share an object with
+// the removed ones, recording a history entry on dup's ticket.
+func (s *TicketService) redactCopy(ctx context.Context, staff *domain.StaffMember, msg, dup *domain.TicketMessage, segments []string, removed []domain.AttachmentReference, reason string) error {
+	found := segmentsIn(dup.Body, segments)
+	var dupRemoved []domain.AttachmentReference
+	for _, attachment := range dup.Attachments {
+		for _, original := range removed {
+			if attachment.StorageKey == original.StorageKey {
+				dupRemoved = append(dupRemoved, attachment)
+				break
+			}
+		}
+	}
+	if len(found) == 0 && len(dupRemoved) == 0 {
+		return nil
+	}
+	if err := s.applyRedaction(ctx, staff, dup, found, dupRemoved, reason); err != nil {
+		return err
+	}
+	removedIDs := make([]string, 0, len(dupRemoved))
+	for _, attachment := range dupRemoved {
+		removedIDs = append(removedIDs, attachment.ID)
+	}
+	return s.recordMessageChange(ctx, staff, dup.TicketID, domain.ChangeTypeMessageRedact, map[string]any{
+		"message_id":     dup.ID,
+		"copied_from":    msg.ID,
+		"segments":       len(found),
+		"attachment_ids": removedIDs,
+	})
+}
+
+// segmentsIn returns the segments that occur in text.
+func segmentsIn(text string, segments []string) []string {
+	var found []string
+	for _, segment := range segments {
+		if strings.Contains(text, segment) {
+			found = append(found, segment)
+		}
+	}
+	return found
+}
+
+// ListMessageRevisions returns a message's edit and redaction trail; admins only.
+func (s *TicketService) ListMessageRevisions(ctx context.Context, staff *domain.StaffMember, ticketID, messageID string) ([]domain.MessageRevision, error) {
+	if err := requireAdmin(staff); err != nil {
+		return nil, err
+	}
+	ticket, err := s.ticketForStaff(ctx, staff, ticketID)
+	if err != nil {
+		return nil, err
+	}
+	msg, err := s.messageOnTicket(ctx, ticket.ID, messageID)
+	if err != nil {
+		return nil, err
+	}
+	revisions, err := s.revisions.ListByMessage(ctx, msg.ID)
+	if err != nil {
+		return nil, apperrors.MapError(err)
+	}
+	return revisions, nil
+}
+
+func (s *TicketService) messageOnTicket(ctx context.Context, ticketID, messageID string) (*domain.TicketMessage, error) {
+	msg, err := s.messages.GetByID(ctx, messageID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperrors.NewNotFound("ticket_message", map[string]any{"message_id": messageID})
+		}
+		return nil, apperrors.MapError(err)
+	}
+	if msg.TicketID != ticketID {
+		return nil, apperrors.NewNotFound("ticket_message", map[string]any{"message_id": messageID})
+	}
+	if err := s.hydrateMessages(ctx, msg); err != nil {
+		return nil, err
+	}
+	return msg, nil
+}
+
+func (s *TicketService) recordMessageChange(ctx context.Context, staff *domain.StaffMember, ticketID string, changeType domain.TicketChangeType, value map[string]any) error {
+	if s.history == nil {
+		return nil
+	}
+	return s.history.Create(ctx, &domain.TicketHistory{
+		TicketID:      ticketID,
+		ChangedByType: domain.AuthorTypeStaff,
+		ChangedByID:   &staff.ID,
+		ChangeType:    changeType,
+		NewValue:      value,
+	})
+}
@@ -0,0 +1,349 @@
+package service
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/spec-kit/ticket-service/internal/domain"
+	"github.com/spec-kit/ticket-service/internal/repository"
+	"github.com/spec-kit/ticket-service/internal/storage"
+	apperrors "github.com/spec-kit/ticket-service/pkg/util/errorutil"
+)
+
+func TestSegmentsIn(t *testing.T) {
+	segments := []string{"4111 1111", "555-0100", "secret"}
+	tests := []struct {
+		text string
+		want []string
+	}{
+		{"card 4111 1111 and phone 555-0100", []string{"4111 1111", "555-0100"}},
+		{"the secret is out", []string{"secret"}},
+		{"card 4111-1111", nil},
+		{"", nil},
+	}
+	for _, tt := range tests {
+		if got := segmentsIn(tt.text, segments); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("segmentsIn(%q) = %q, want %q", tt.text, got, tt.want)
+		}
+	}
+}
+
+type redactTickets struct {
+	repository.TicketRepository
+	tickets map[string]*domain.Ticket
+}
+
+func (r *redactTickets) GetByID(_ context.Context, id string) (*domain.Ticket, error) {
+	ticket, ok := r.tickets[id]
+	if !ok {
+		return nil, pgx.ErrNoRows
+	}
+	copied := *ticket
+	return &copied, nil
+}
+
+func (r *redactTickets) RedactDescriptions(_ context.Context, ticketID string, segments []string, replacement string) error {
+	for _, ticket := range r.tickets {
+		if ticket.ID != ticketID && (ticket.MergedIntoID == nil || *ticket.MergedIntoID != ticketID) {
+			continue
+		}
+		for _, segment := range segments {
+			ticket.Description = strings.ReplaceAll(ticket.Description, segment, replacement)
+		}
+	}
+	return nil
+}
+
+type redactMessages struct {
+	repository.TicketMessageRepository
+	messages []*domain.TicketMessage
+}
+
+func (r *redactMessages) find(id string) *domain.TicketMessage {
+	for _, msg := range r.messages {
+		if msg.ID == id {
+			return msg
+		}
+	}
+	return nil
+}
+
+func (r *redactMessages) GetByID(_ context.Context, id string) (*domain.TicketMessage, error) {
+	msg := r.find(id)
+	if msg == nil {
+		return nil, pgx.ErrNoRows
+	}
+	copied := *msg
+	return &copied, nil
+}
+
+func (r *redactMessages) ListByTicket(_ context.Context, ticketID string) ([]domain.TicketMessage, error) {
+	var thread []domain.TicketMessage
+	for _, msg := range r.messages {
+		if msg.TicketID == ticketID {
+			thread = append(thread, *msg)
+		}
+	}
+	return thread, nil
+}
+
+func (r *redactMessages) ListCopies(_ context.Context, messageID string) ([]domain.TicketMessage, error) {
+	var copies []domain.TicketMessage
+	for _, msg := range r.messages {
+		if msg.CopiedFromID != nil && *msg.CopiedFromID == messageID {
+			copies = append(copies, *msg)
+		}
+	}
+	return copies, nil
+}
+
+func (r *redactMessages) UpdateBody(_ context.Context, msg *domain.TicketMessage) error {
+	stored := r.find(msg.ID)
+	if stored == nil {
+		return pgx.ErrNoRows
+	}
+	stored.Body = msg.Body
+	stored.RedactedAt = msg.RedactedAt
+	return nil
+}
+
+type redactAttachments struct {
+	repository.AttachmentRepository
+	refs []domain.AttachmentReference
+}
+
+func (r *redactAttachments) ListByMessages(_ context.Context, messageIDs []string) ([]domain.AttachmentReference, error) {
+	var found []domain.AttachmentReference
+	for _, ref := range r.refs {
+		if containsString(messageIDs, ref.TicketMessageID) {
+			found = append(found, ref)
+		}
+	}
+	return found, nil
+}
+
+func (r *redactAttachments) Delete(_ context.Context, id string) error {
+	for i, ref := range r.refs {
+		if ref.ID == id {
+			r.refs = append(r.refs[:i], r.refs[i+1:]...)
+			return nil
+		}
+	}
+	return pgx.ErrNoRows
+}
+
+func (r *redactAttachments) CountByStorageKey(_ context.Context, storageKey string) (int, error) {
+	count := 0
+	for _, ref := range r.refs {
+		if ref.StorageKey == storageKey {
+			count++
+		}
+	}
+	return count, nil
+}
+
+type redactRevisions struct {
+	repository.MessageRevisionRepository
+	created  []*domain.MessageRevision
+	scrubbed []string
+}
+
+func (r *redactRevisions) Create(_ context.Context, revision *domain.MessageRevision) error {
+	r.created = append(r.created, revision)
+	return nil
+}
+
+func (r *redactRevisions) RedactPrevious(_ context.Context, messageID string, _ []string, _ string) error {
+	r.scrubbed = append(r.scrubbed, messageID)
+	return nil
+}
+
+// commitCheckTransactor runs a check after fn succeeds, while the transaction would still be open.
+type commitCheckTransactor struct {
+	beforeCommit func()
+}
+
+func (t *commitCheckTransactor) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	if err := fn(ctx); err != nil {
+		return err
+	}
+	t.beforeCommit()
+	return nil
+}
+
+type redactFixture struct {
+	service     *TicketService
+	tickets     *redactTickets
+	messages    *redactMessages
+	attachments *redactAttachments
+	revisions   *redactRevisions
+	history     *autoCloseHistory
+	store       storage.Storage
+	tx          *commitCheckTransactor
+}
+
+// newRedactFixture builds a ticket whose reply was propagated to a child ticket and quoted,
+// together with a merged ticket's description, in a merge notice.
+func newRedactFixture(t *testing.T) *redactFixture {
+	t.Helper()
+	store, err := storage.NewLocalStorage(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Put(context.Background(), "tickets/t1/card.png", strings.NewReader("png"), 3, "image/png"); err != nil {
+		t.Fatal(err)
+	}
+	parent, reply := "t1", "m1"
+	f := &redactFixture{
+		tickets: &redactTickets{tickets: map[string]*domain.Ticket{
+			"t1": {ID: "t1", Description: "Card 4111 1111 declined"},
+			"t2": {ID: "t2", Description: "Child of t1"},
+			"t3": {ID: "t3", Description: "Same card 4111 1111", MergedIntoID: &parent},
+		}},
+		messages: &redactMessages{messages: []*domain.TicketMessage{
+			{ID: "m1", TicketID: "t1", AuthorType: domain.AuthorTypeUser, MessageType: domain.MessageTypePublicReply, BodyFormat: domain.BodyFormatText, Body: "card 4111 1111 and phone 555-0100"},
+			{ID: "m2", TicketID: "t1", AuthorType: domain.AuthorTypeSystem, MessageType: domain.MessageTypeSystemEvent, Body: "Merged TCK-3: Same card 4111 1111"},
+			{ID: "m3", TicketID: "t2", AuthorType: domain.AuthorTypeUser, MessageType: domain.MessageTypePublicReply, BodyFormat: domain.BodyFormatText, Body: "card 4111 1111 and phone 555-0100", CopiedFromID: &reply},
+			{ID: "m4", TicketID: "t1", AuthorType: domain.AuthorTypeSystem, MessageType: domain.MessageTypeSystemEvent, Body: "Status changed to OPEN"},
+		}},
+		attachments: &redactAttachments{refs: []domain.AttachmentReference{
+			{ID: "a1", TicketMessageID: "m1", StorageKey: "tickets/t1/card.png"},
+			{ID: "a2", TicketMessageID: "m1", StorageKey: "tickets/t1/log.txt"},
+			{ID: "a3", TicketMessageID: "m3", StorageKey: "tickets/t1/card.png"},
+		}},
+		revisions: &redactRevisions{},
+		history:   &autoCloseHistory{},
+		store:     store,
+		tx:        &commitCheckTransactor{beforeCommit: func() {}},
+	}
+	f.service = NewTicketService(TicketDependencies{
+		TicketRepo:     f.tickets,
+		MessageRepo:    f.messages,
+		AttachmentRepo: f.attachments,
+		HistoryRepo:    f.history,
+		RevisionRepo:   f.revisions,
+		Storage:        store,
+		Transactor:     f.tx,
+	})
+	return f
+}
+
+func TestRedactMessage(t *testing.T) {
+	f := newRedactFixture(t)
+	admin := &domain.StaffMember{ID: "admin-1", Role: domain.StaffRoleAdmin}
+	stored := func() bool {
+		reader, err := f.store.Get(context.Background(), "tickets/t1/card.png")
+		if err != nil {
+			return false
+		}
+		reader.Close()
+		return true
+	}
+	f.tx.beforeCommit = func() {
+		if !stored() {
+			t.Error("attachment object deleted before the transaction committed")
+		}
+	}
+
+	msg, err := f.service.RedactMessage(context.Background(), admin, "t1", "m1", MessageRedactionInput{
+		Segments:      []string{"4111 1111", " ", "4111 1111"},
+		AttachmentIDs: []string{"a1"},
+		Reason:        " card number ",
+	})
+	if err != nil {
+		t.Fatalf("RedactMessage err = %v", err)
+	}
+	if msg.Body != "card [redacted] and phone 555-0100" || msg.RedactedAt == nil {
+		t.Errorf("message = %q, redacted at %v", msg.Body, msg.RedactedAt)
+	}
+	if len(msg.Attachments) != 1 || msg.Attachments[0].ID != "a2" {
+		t.Errorf("remaining attachments = %+v, want only a2", msg.Attachments)
+	}
+
+	wantBodies := map[string]string{
+		"m1": "card [redacted] and phone 555-0100",
+		"m2": "Merged TCK-3: Same card [redacted]",
+		"m3": "card [redacted] and phone 555-0100",
+		"m4": "Status changed to OPEN",
+	}
+	for id, want := range wantBodies {
+		if got := f.messages.find(id).Body; got != want {
+			t.Errorf("message %s body = %q, want %q", id, got, want)
+		}
+	}
+	wantDescriptions := map[string]string{
+		"t1": "Card [redacted] declined",
+		"t2": "Child of t1",
+		"t3": "Same card [redacted]",
+	}
+	for id, want := range wantDescriptions {
+		if got := f.tickets.tickets[id].Description; got != want {
+			t.Errorf("ticket %s description = %q, want %q", id, got, want)
+		}
+	}
+
+	var refs []string
+	for _, ref := range f.attachments.refs {
+		refs = append(refs, ref.ID)
+	}
+	if !reflect.DeepEqual(refs, []string{"a2"}) {
+		t.Errorf("attachment references = %v, want the copy's shared reference removed too", refs)
+	}
+	if stored() {
+		t.Error("attachment object kept after its last reference was removed")
+	}
+
+	var revised []string
+	for _, revision := range f.revisions.created {
+		revised = append(revised, revision.MessageID)
+		if revision.Kind != domain.MessageRevisionRedact || revision.Reason != "card number" {
+			t.Errorf("revision = %+v, want a redaction with the trimmed reason", revision)
+		}
+	}
+	sort.Strings(revised)
+	if !reflect.DeepEqual(revised, []string{"m1", "m2", "m3"}) || !reflect.DeepEqual(f.revisions.scrubbed, revised) {
+		t.Errorf("revisions = %v, scrubbed = %v, want m1, m2 and m3", revised, f.revisions.scrubbed)
+	}
+	var changed []string
+	for _, entry := range f.history.entries {
+		changed = append(changed, entry.TicketID+"/"+entry.NewValue["message_id"].(string))
+	}
+	if want := []string{"t1/m1", "t1/m2", "t2/m3"}; !reflect.DeepEqual(changed, want) {
+		t.Errorf("history = %v, want %v", changed, want)
+	}
+}
+
+func TestRedactMessageRejects(t *testing.T) {
+	admin := &domain.StaffMember{ID: "admin-1", Role: domain.StaffRoleAdmin}
+	agent := &domain.StaffMember{ID: "agent-1", Role: domain.StaffRoleAgent}
+	tests := []struct {
+		name      string
+		staff     *domain.StaffMember
+		messageID string
+		input     MessageRedactionInput
+		wantCode  string
+	}{
+		{"not an admin", agent, "m1", MessageRedactionInput{Segments: []string{"4111 1111"}}, "FORBIDDEN"},
+		{"message on another ticket", admin, "m3", MessageRedactionInput{Segments: []string{"4111 1111"}}, "NOT_FOUND"},
+		{"segment not in body", admin, "m1", MessageRedactionInput{Segments: []string{"5500 0000"}}, "VALIDATION_FAILED"},
+		{"unknown attachment", admin, "m1", MessageRedactionInput{AttachmentIDs: []string{"a3"}}, "NOT_FOUND"},
+		{"nothing to redact", admin, "m1", MessageRedactionInput{Segments: []string{"  "}}, "VALIDATION_FAILED"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newRedactFixture(t)
+			_, err := f.service.RedactMessage(context.Background(), tt.staff, "t1", tt.messageID, tt.input)
+			if derr := apperrors.ToDomainError(err); err == nil || derr.Code != tt.wantCode {
+				t.Fatalf("RedactMessage err = %v, want %s", err, tt.wantCode)
+			}
+			if len(f.revisions.created) != 0 || f.messages.find("m1").Body != "card 4111 1111 and phone 555-0100" {
+				t.Error("rejected redaction changed the message")
+			}
+		})
+	}
+}
@@ -0,0 +1,308 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/mail"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/spec-kit/ticket-service/internal/domain"
+	apperrors "github.com/spec-kit/ticket-service/pkg/util/errorutil"
+)
+
+// maxTicketParticipants caps watchers plus CCs on a single ticket.
+const maxTicketParticipants = 50
+
+// ParticipantInput adds a watcher (StaffID) or a CC (UserID or Email) to a ticket.
+type ParticipantInput struct {
+	Role    domain.ParticipantRole
+	StaffID string
+	UserID  string
+	Email   string
+}
+
+// ListParticipants returns the watchers and CCs on a ticket.
+func (s *TicketService) ListParticipants(ctx context.Context, staff *domain.StaffMember, ticketID string) ([]domain.TicketParticipant, error) {
+	ticket, err := s.ticketForStaff(ctx, staff, ticketID)
+	if err != nil {
+		return nil, err
+	}
+	participants, err := s.participants.ListByTicket(ctx, ticket.ID)
+	if err != nil {
+		return nil, apperrors.MapError(err)
+	}
+	return participants, nil
+}
+
+// WatchTicket adds the staff member as a watcher; watching twice is a no-op.
+func (s *TicketService) WatchTicket(ctx context.Context, staff *domain.StaffMember, ticketID string) (*domain.TicketParticipant, error) {
+	return s.AddParticipant(ctx, staff, ticketID, ParticipantInput{Role: domain.ParticipantRoleWatcher, StaffID: staff.ID})
+}
+
+// UnwatchTicket removes the staff member from a ticket's watchers.
+func (s *TicketService) UnwatchTicket(ctx context.Context, staff *domain.StaffMember, ticketID string) error {
+	participants, err := s.ListParticipants(ctx, staff, ticketID)
+	if err != nil {
+		return err
+	}
+	for _, participant := range participants {
+		if participant.StaffID != nil && *participant.StaffID == staff.ID {
+			if err := s.participants.Delete(ctx, participant.ID); err != nil {
+				return apperrors.MapError(err)
+			}
+			return nil
+		}
+	}
+	return nil
+}
+
+// AddParticipant adds a staff watcher or a CC. CC emails that belong to a registered user are
+// stored against the account so the user can read and reply to the ticket.
+func (s *TicketService) AddParticipant(ctx context.Context, staff *domain.StaffMember, ticketID string, input ParticipantInput) (*domain.TicketParticipant, error) {
+	ticket, err := s.ticketForStaff(ctx, staff, ticketID)
+	if err != nil {
+		return nil, err
+	}
+	participant := &domain.TicketParticipant{
+		TicketID:    ticket.ID,
+		Role:        input.Role,
+		AddedByType: domain.AuthorTypeStaff,
+		AddedByID:   &staff.ID,
+	}
+	switch input.Role {
+	case domain.ParticipantRoleWatcher:
+		if input.StaffID == "" || input.UserID != "" || input.Email != "" {
+			return nil, apperrors.NewValidationError("watchers require staff_id only", nil)
+		}
+		member, err := s.staff.GetByID(ctx, input.StaffID)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return nil, apperrors.NewNotFound("staff", map[string]any{"staff_id": input.StaffID})
+			}
+			return nil, apperrors.MapError(err)
+		}
+		if !member.Active {
+			return nil, apperrors.NewConflict("staff member inactive", map[string]any{"staff_id": member.ID})
+		}
+		// watchers receive message bodies, so they must be able to read the ticket themselves
+		if !s.staffCanAccessTicket(member, ticket) {
+			return nil, apperrors.NewConflict("staff member cannot access ticket", map[string]any{"staff_id": member.ID})
+		}
+		participant.StaffID = &member.ID
+	case domain.ParticipantRoleCC:
+		if input.StaffID != "" {
+			return nil, apperrors.NewValidationError("staff members follow tickets as watchers", nil)
+		}
+		if err := s.resolveCC(ctx, ticket, participant, input.UserID, input.Email); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, apperrors.NewValidationError("invalid participant role", map[string]any{"role": input.Role})
+	}
+	return s.createParticipant(ctx, participant)
+}
+
+// RemoveParticipant removes a watcher or CC from a ticket.
+func (s *TicketService) RemoveParticipant(ctx context.Context, staff *domain.StaffMember, ticketID, participantID string) error {
+	ticket, err := s.ticketForStaff(ctx, staff, ticketID)
+	if err != nil {
+		return err
+	}
+	participant, err := s.participantOnTicket(ctx, ticket.ID, participantID)
+	if err != nil {
+		return err
+	}
+	if err := s.participants.Delete(ctx, participant.ID); err != nil {
+		return apperrors.MapError(err)
+	}
+	return nil
+}
+
+// ListCCForUser returns the CCs on a ticket the user can read; staff watchers are not exposed.
+func (s *TicketService) ListCCForUser(ctx context.Context, userID, ticketID string) ([]domain.TicketParticipant, error) {
+	ticket, err := s.ticketForUser(ctx, userID, ticketID)
+	if err != nil {
+		return nil, err
+	}
+	participants, err := s.participants.ListByTicket(ctx, ticket.ID)
+	if err != nil {
+		return nil, apperrors.MapError(err)
+	}
+	cc := []domain.TicketParticipant{}
+	for _, participant := range participants {
+		if participant.Role == domain.ParticipantRoleCC {
+			cc = append(cc, participant)
+		}
+	}
+	return cc, nil
+}
+
+// AddCCForUser lets the requester copy another person on their ticket.
+func (s *TicketService) AddCCForUser(ctx context.Context, userID, ticketID, email string) (*domain.TicketParticipant, error) {
+	ticket, err := s.ticketForUser(ctx, userID, ticketID)
+	if err != nil {
+		return nil, err
+	}
+	if ticket.RequesterID != userID {
+		return nil, apperrors.NewForbidden("only the requester can add CC participants")
+	}
+	participant := &domain.TicketParticipant{
+		TicketID:    ticket.ID,
+		Role:        domain.ParticipantRoleCC,
+		AddedByType: domain.AuthorTypeUser,
+		AddedByID:   &userID,
+	}
+	if err := s.resolveCC(ctx, ticket, participant, "", email); err != nil {
+		return nil, err
+	}
+	return s.createParticipant(ctx, participant)
+}
+
+// RemoveCCForUser lets the requester remove any CC, and a CC user remove themselves.
+func (s *TicketService) RemoveCCForUser(ctx context.Context, userID, ticketID, participantID string) error {
+	ticket, err := s.ticketForUser(ctx, userID, ticketID)
+	if err != nil {
+		return err
+	}
+	participant, err := s.participantOnTicket(ctx, ticket.ID, participantID)
+	if err != nil {
+		return err
+	}
+	if participant.Role != domain.ParticipantRoleCC {
+		return apperrors.NewNotFound("ticket_participant", map[string]any{"participant_id": participantID})
+	}
+	self := participant.UserID != nil && *participant.UserID == userID
+	if ticket.RequesterID != userID && !self {
+		return apperrors.NewForbidden("access denied")
+	}
+	if err := s.participants.Delete(ctx, participant.ID); err != nil {
+		return apperrors.MapError(err)
+	}
+	return nil
+}
+
+// userCanAccessTicket reports whether the user is the requester or a CC on the ticket.
+func (s *TicketService) userCanAccessTicket(ctx context.Context, userID string, ticket *domain.Ticket) (bool, error) {
+	if ticket.RequesterID == userID {
+		return true, nil
+	}
+	if s.participants == nil {
+		return false, nil
+	}
+	cc, err := s.participants.IsUserCC(ctx, ticket.ID, userID)
+	if err != nil {
+		return false, apperrors.MapError(err)
+	}
+	return cc, nil
+}
+
+// ticketForUser loads a ticket the user can read, following merges.
+func (s *TicketService) ticketForUser(ctx context.Context, userID, ticketID string) (*domain.Ticket, error) {
+	ticket, err := s.tickets.GetByID(ctx, ticketID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperrors.NewNotFound("ticket", map[string]any{"ticket_id": ticketID})
+		}
+		return nil, apperrors.MapError(err)
+	}
+	if ok, err := s.userCanAccessTicket(ctx, userID, ticket); err != nil {
+		return nil, err
+	} else if !ok {
+		return nil, apperrors.NewForbidden("access denied")
+	}
+	// merged duplicates redirect to the ticket the conversation continues on
+	if ticket, err = s.followMerges(ctx, ticket); err != nil {
+		return nil, err
+	}
+	if ok, err := s.userCanAccessTicket(ctx, userID, ticket); err != nil {
+		return nil, err
+	} else if !ok {
+		return nil, apperrors.NewForbidden("access denied")
+	}
+	return ticket, nil
+}
+
+// resolveCC fills in the CC target, preferring a registered account over a bare email.
+func (s *TicketService) resolveCC(ctx context.Context, ticket *domain.Ticket, participant *domain.TicketParticipant, userID, email string) error {
+	email = strings.ToLower(strings.TrimSpace(email))
+	switch {
+	case userID != "" && email != "":
+		return apperrors.NewValidationError("provide user_id or email, not both", nil)
+	case userID != "":
+		user, err := s.users.GetByID(ctx, userID)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return apperrors.NewNotFound("user", map[string]any{"user_id": userID})
+			}
+			return apperrors.MapError(err)
+		}
+		participant.UserID = &user.ID
+	case email != "":
+		addr, err := mail.ParseAddress(email)
+		if err != nil || addr.Address != email {
+			return apperrors.NewValidationError("invalid email", map[string]any{"email": email})
+		}
+		if user, err := s.users.GetByEmail(ctx, email); err == nil {
+			participant.UserID = &user.ID
+		} else if errors.Is(err, pgx.ErrNoRows) {
+			participant.Email = &email
+		} else {
+			return apperrors.MapError(err)
+		}
+	default:
+		return apperrors.NewValidationError("user_id or email required", nil)
+	}
+	if participant.UserID != nil && *participant.UserID == ticket.RequesterID {
+		return apperrors.NewValidationError("requester is already a participant", nil)
+	}
+	return nil
+}
+
+// createParticipant stores a participant unless an equivalent one already exists, in which case
+// the existing entry is returned.
+func (s *TicketService) createParticipant(ctx context.Context, participant *domain.TicketParticipant) (*domain.TicketParticipant, error) {
+	existing, err := s.participants.ListByTicket(ctx, participant.TicketID)
+	if err != nil {
+		return nil, apperrors.MapError(err)
+	}
+	for i := range existing {
+		if sameParticipant(&existing[i], participant) {
+			return &existing[i], nil
+		}
+	}
+	if len(existing) >= maxTicketParticipants {
+		return nil, apperrors.NewConflict("too many participants", map[string]any{"max": maxTicketParticipants})
+	}
+	if err := s.participants.Create(ctx, participant); err != nil {
+		return nil, apperrors.MapError(err)
+	}
+	return participant, nil
+}
+
+func (s *TicketService) participantOnTicket(ctx context.Context, ticketID, participantID string) (*domain.TicketParticipant, error) {
+	participant, err := s.participants.GetByID(ctx, participantID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperrors.NewNotFound("ticket_participant", map[string]any{"participant_id": participantID})
+		}
+		return nil, apperrors.MapError(err)
+	}
+	if participant.TicketID != ticketID {
+		return nil, apperrors.NewNotFound("ticket_participant", map[string]any{"participant_id": participantID})
+	}
+	return participant, nil
+}
+
+func sameParticipant(a, b *domain.TicketParticipant) bool {
+	switch {
+	case a.StaffID != nil && b.StaffID != nil:
+		return *a.StaffID == *b.StaffID
+	case a.UserID != nil && b.UserID != nil:
+		return *a.UserID == *b.UserID
+	case a.Email != nil && b.Email != nil:
+		return strings.EqualFold(*a.Email, *b.Email)
+	}
+	return false
+}
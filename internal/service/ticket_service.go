@@ -3,40 +3,77 @@ package service
 import (
 	"context"
 	"errors"
+	"fmt"
+	"slices"
 	"strings"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
 
+	"github.com/spec-kit/ticket-service/internal/config"
 	"github.com/spec-kit/ticket-service/internal/domain"
 	"github.com/spec-kit/ticket-service/internal/events"
 	"github.com/spec-kit/ticket-service/internal/repository"
+	"github.com/spec-kit/ticket-service/internal/richtext"
+	"github.com/spec-kit/ticket-service/internal/storage"
 	apperrors "github.com/spec-kit/ticket-service/pkg/util/errorutil"
 )
 
 // TicketService coordinates ticket workflows.
 type TicketService struct {
-	tickets     repository.TicketRepository
-	messages    repository.TicketMessageRepository
-	attachments repository.AttachmentRepository
-	departments repository.DepartmentRepository
-	teams       repository.TeamRepository
-	staff       repository.StaffRepository
-	history     repository.TicketHistoryRepository
-	dispatcher  events.Dispatcher
+	tickets      repository.TicketRepository
+	messages     repository.TicketMessageRepository
+	attachments  repository.AttachmentRepository
+	departments  repository.DepartmentRepository
+	teams        repository.TeamRepository
+	staff        repository.StaffRepository
+	history      repository.TicketHistoryRepository
+	workflows    repository.WorkflowRepository
+	fields       repository.CustomFieldRepository
+	tags         repository.TagRepository
+	links        repository.TicketLinkRepository
+	participants repository.TicketParticipantRepository
+	users        repository.UserRepository
+	mentions     repository.StaffMentionRepository
+	revisions    repository.MessageRevisionRepository
+	uploads      repository.AttachmentUploadRepository
+	storage      storage.Storage
+	tx           repository.Transactor
+	dispatcher   events.Dispatcher
+	sla          config.SLAConfig
+	effects      config.MessageEffectsConfig
+	policy       config.AttachmentPolicyConfig
+	signer       *AttachmentLinkSigner
+	authors      config.AuthorDisplayConfig
 }
 
 // TicketDependencies bundles repositories for ticket service.
 type TicketDependencies struct {
-	TicketRepo     repository.TicketRepository
-	MessageRepo    repository.TicketMessageRepository
-	AttachmentRepo repository.AttachmentRepository
-	DepartmentRepo repository.DepartmentRepository
-	TeamRepo       repository.TeamRepository
-	StaffRepo      repository.StaffRepository
-	HistoryRepo    repository.TicketHistoryRepository
-	Dispatcher     events.Dispatcher
+	TicketRepo       repository.TicketRepository
+	MessageRepo      repository.TicketMessageRepository
+	AttachmentRepo   repository.AttachmentRepository
+	DepartmentRepo   repository.DepartmentRepository
+	TeamRepo         repository.TeamRepository
+	StaffRepo        repository.StaffRepository
+	HistoryRepo      repository.TicketHistoryRepository
+	WorkflowRepo     repository.WorkflowRepository
+	CustomFieldRepo  repository.CustomFieldRepository
+	TagRepo          repository.TagRepository
+	LinkRepo         repository.TicketLinkRepository
+	ParticipantRepo  repository.TicketParticipantRepository
+	UserRepo         repository.UserRepository
+	MentionRepo      repository.StaffMentionRepository
+	RevisionRepo     repository.MessageRevisionRepository
+	UploadRepo       repository.AttachmentUploadRepository
+	Storage          storage.Storage
+	Transactor       repository.Transactor
+	Dispatcher       events.Dispatcher
+	SLA              config.SLAConfig
+	MessageEffects   config.MessageEffectsConfig
+	AttachmentPolicy config.AttachmentPolicyConfig
+	LinkSigner       *AttachmentLinkSigner
+	AuthorDisplay    config.AuthorDisplayConfig
 }
 
 // TicketCreateInput describes ticket creation payload.
@@ -47,6 +84,10 @@ type TicketCreateInput struct {
 	Description  string
 	Priority     domain.TicketPriority
 	Tags         []string
+	CustomFields map[string]any
+	// FormValidated is set once the department form has checked the submission; its required
+	// flags then replace the custom field definitions'.
+	FormValidated bool
 }
 
 // TicketUserFilter describes end-user listing filters.
@@ -55,8 +96,11 @@ type TicketUserFilter struct {
 	Priorities  []domain.TicketPriority
 	CreatedFrom *time.Time
 	CreatedTo   *time.Time
-	Limit       int
-	Offset      int
+	SearchTerm  *string
+	// Sort is a comma-separated list of sort fields, each optionally prefixed with '-' for descending.
+	Sort   string
+	Limit  int
+	Cursor string
 }
 
 // TicketStaffFilter describes staff listing filters.
@@ -71,8 +115,15 @@ type TicketStaffFilter struct {
 	CreatedTo    *time.Time
 	UpdatedFrom  *time.Time
 	UpdatedTo    *time.Time
-	Limit        int
-	Offset       int
+	// CustomFields maps field keys to raw query values.
+	CustomFields map[string]string
+	TagsAny      []string
+	TagsAll      []string
+	TagsNone     []string
+	// Sort is a comma-separated list of sort fields, each optionally prefixed with '-' for descending.
+	Sort   string
+	Limit  int
+	Cursor string
 }
 
 // MessageAttachmentInput defines attachment metadata.
@@ -86,14 +137,30 @@ type MessageAttachmentInput struct {
 // NewTicketService constructs the service.
 func NewTicketService(deps TicketDependencies) *TicketService {
 	return &TicketService{
-		tickets:     deps.TicketRepo,
-		messages:    deps.MessageRepo,
-		attachments: deps.AttachmentRepo,
-		departments: deps.DepartmentRepo,
-		teams:       deps.TeamRepo,
-		staff:       deps.StaffRepo,
-		history:     deps.HistoryRepo,
-		dispatcher:  deps.Dispatcher,
+		tickets:      deps.TicketRepo,
+		messages:     deps.MessageRepo,
+		attachments:  deps.AttachmentRepo,
+		departments:  deps.DepartmentRepo,
+		teams:        deps.TeamRepo,
+		staff:        deps.StaffRepo,
+		history:      deps.HistoryRepo,
+		workflows:    deps.WorkflowRepo,
+		fields:       deps.CustomFieldRepo,
+		tags:         deps.TagRepo,
+		links:        deps.LinkRepo,
+		participants: deps.ParticipantRepo,
+		users:        deps.UserRepo,
+		mentions:     deps.MentionRepo,
+		revisions:    deps.RevisionRepo,
+		uploads:      deps.UploadRepo,
+		storage:      deps.Storage,
+		tx:           deps.Transactor,
+		dispatcher:   deps.Dispatcher,
+		sla:          deps.SLA,
+		effects:      deps.MessageEffects,
+		policy:       deps.AttachmentPolicy,
+		signer:       deps.LinkSigner,
+		authors:      deps.AuthorDisplay,
 	}
 }
 
@@ -118,6 +185,10 @@ func (s *TicketService) CreateTicket(ctx context.Context, userID string, input T
 			return nil, apperrors.NewValidationError("team not part of department", map[string]any{"team_id": *input.TeamID})
 		}
 	}
+	tags, err := resolveCatalogTags(ctx, s.tags, input.Tags)
+	if err != nil {
+		return nil, err
+	}
 
 	ticket := &domain.Ticket{
 		ExternalKey:  generateTicketKey(),
@@ -128,12 +199,32 @@ func (s *TicketService) CreateTicket(ctx context.Context, userID string, input T
 		Description:  strings.TrimSpace(input.Description),
 		Status:       domain.TicketStatusOpen,
 		Priority:     input.Priority,
-		Tags:         input.Tags,
+		Tags:         tags,
 	}
 
 	if ticket.Priority == "" {
 		ticket.Priority = domain.TicketPriorityMedium
 	}
+	ticket.SLADueAt = slaDueAt(s.sla, time.Now(), ticket.Priority)
+
+	defs, err := s.customFieldDefinitions(ctx, &ticket.DepartmentID)
+	if err != nil {
+		return nil, err
+	}
+	customFields, err := applyCustomFieldChanges(defs, nil, input.CustomFields)
+	if err != nil {
+		return nil, err
+	}
+	if !input.FormValidated {
+		if err := checkRequiredCustomFields(defs, customFields); err != nil {
+			return nil, err
+		}
+	}
+	ticket.CustomFields = customFields
+
+	if err := s.applyInitialStatus(ctx, ticket); err != nil {
+		return nil, err
+	}
 
 	if err := s.tickets.Create(ctx, ticket); err != nil {
 		return nil, apperrors.MapError(err)
@@ -153,30 +244,39 @@ func (s *TicketService) CreateTicket(ctx context.Context, userID string, input T
 }
 
 // ListUserTickets returns paginated tickets for a requester.
-func (s *TicketService) ListUserTickets(ctx context.Context, userID string, filter TicketUserFilter) ([]domain.Ticket, error) {
+func (s *TicketService) ListUserTickets(ctx context.Context, userID string, filter TicketUserFilter) (repository.Page[domain.Ticket], error) {
+	cursor, err := decodeCursor(filter.Cursor)
+	if err != nil {
+		return repository.Page[domain.Ticket]{}, err
+	}
+	sort, err := parseTicketSort(filter.Sort)
+	if err != nil {
+		return repository.Page[domain.Ticket]{}, err
+	}
+	if err := checkTicketCursor(cursor, sort, filter.Cursor); err != nil {
+		return repository.Page[domain.Ticket]{}, err
+	}
 	repoFilter := repository.TicketFilter{
 		RequesterID: &userID,
 		Statuses:    filter.Statuses,
 		Priorities:  filter.Priorities,
 		CreatedFrom: filter.CreatedFrom,
 		CreatedTo:   filter.CreatedTo,
-		Limit:       filter.Limit,
-		Offset:      filter.Offset,
+		Sort:        sort,
+		Limit:       repository.ClampPageSize(filter.Limit),
+		Cursor:      cursor,
+	}
+	if filter.SearchTerm != nil && strings.TrimSpace(*filter.SearchTerm) != "" {
+		repoFilter.Search = parseSearchQuery(*filter.SearchTerm, false)
 	}
 	return s.tickets.ListWithFilter(ctx, repoFilter)
 }
 
-// GetTicketForUser fetches a ticket ensuring ownership.
+// GetTicketForUser fetches a ticket for its requester or a CC user.
 func (s *TicketService) GetTicketForUser(ctx context.Context, userID, ticketID string) (*domain.Ticket, []domain.TicketMessage, error) {
-	ticket, err := s.tickets.GetByID(ctx, ticketID)
+	ticket, err := s.ticketForUser(ctx, userID, ticketID)
 	if err != nil {
-		if errors.Is(err, pgx.ErrNoRows) {
-			return nil, nil, apperrors.NewNotFound("ticket", map[string]any{"ticket_id": ticketID})
-		}
-		return nil, nil, apperrors.MapError(err)
-	}
-	if ticket.RequesterID != userID {
-		return nil, nil, apperrors.NewForbidden("access denied")
+		return nil, nil, err
 	}
 	msgs, err := s.visibleMessagesForUser(ctx, ticket.ID)
 	if err != nil {
@@ -186,23 +286,83 @@ func (s *TicketService) GetTicketForUser(ctx context.Context, userID, ticketID s
 }
 
 // ListStaffTickets returns tickets accessible to staff.
-func (s *TicketService) ListStaffTickets(ctx context.Context, staff *domain.StaffMember, filter TicketStaffFilter) ([]domain.Ticket, error) {
+func (s *TicketService) ListStaffTickets(ctx context.Context, staff *domain.StaffMember, filter TicketStaffFilter) (repository.Page[domain.Ticket], error) {
+	repoFilter, err := s.staffTicketFilter(ctx, staff, filter)
+	if err != nil {
+		return repository.Page[domain.Ticket]{}, err
+	}
+	return s.tickets.ListWithFilter(ctx, repoFilter)
+}
+
+// SearchStaffTickets returns accessible tickets ranked by relevance with highlighted snippets.
+func (s *TicketService) SearchStaffTickets(ctx context.Context, staff *domain.StaffMember, filter TicketStaffFilter) (repository.Page[domain.TicketSearchHit], error) {
+	repoFilter, err := s.staffTicketFilter(ctx, staff, filter)
+	if err != nil {
+		return repository.Page[domain.TicketSearchHit]{}, err
+	}
+	hits, err := s.tickets.Search(ctx, repoFilter)
+	if err != nil {
+		return hits, apperrors.MapError(err)
+	}
+	return hits, nil
+}
+
+// CountStaffTickets returns how many accessible tickets match the filter.
+func (s *TicketService) CountStaffTickets(ctx context.Context, staff *domain.StaffMember, filter TicketStaffFilter) (int, error) {
+	repoFilter, err := s.staffTicketFilter(ctx, staff, filter)
+	if err != nil {
+		return 0, err
+	}
+	total, err := s.tickets.Count(ctx, repoFilter)
+	if err != nil {
+		return 0, apperrors.MapError(err)
+	}
+	return total, nil
+}
+
+func (s *TicketService) staffTicketFilter(ctx context.Context, staff *domain.StaffMember, filter TicketStaffFilter) (repository.TicketFilter, error) {
+	cursor, err := decodeCursor(filter.Cursor)
+	if err != nil {
+		return repository.TicketFilter{}, err
+	}
+	sort, err := parseTicketSort(filter.Sort)
+	if err != nil {
+		return repository.TicketFilter{}, err
+	}
+	if err := checkTicketCursor(cursor, sort, filter.Cursor); err != nil {
+		return repository.TicketFilter{}, err
+	}
 	repoFilter := repository.TicketFilter{
 		DepartmentID: filter.DepartmentID,
 		TeamID:       filter.TeamID,
 		AssigneeID:   filter.AssigneeID,
 		Statuses:     filter.Statuses,
 		Priorities:   filter.Priorities,
-		SearchTerm:   filter.SearchTerm,
 		CreatedFrom:  filter.CreatedFrom,
 		CreatedTo:    filter.CreatedTo,
 		UpdatedFrom:  filter.UpdatedFrom,
 		UpdatedTo:    filter.UpdatedTo,
-		Limit:        filter.Limit,
-		Offset:       filter.Offset,
+		TagsAny:      normalizeTagNames(filter.TagsAny),
+		TagsAll:      normalizeTagNames(filter.TagsAll),
+		TagsNone:     normalizeTagNames(filter.TagsNone),
+		Sort:         sort,
+		Limit:        repository.ClampPageSize(filter.Limit),
+		Cursor:       cursor,
+	}
+	if filter.SearchTerm != nil && strings.TrimSpace(*filter.SearchTerm) != "" {
+		repoFilter.Search = parseSearchQuery(*filter.SearchTerm, true)
+	}
+	if len(filter.CustomFields) > 0 {
+		defs, err := s.customFieldDefinitions(ctx, filter.DepartmentID)
+		if err != nil {
+			return repoFilter, err
+		}
+		if repoFilter.CustomFields, err = customFieldFilterValues(defs, filter.CustomFields); err != nil {
+			return repoFilter, err
+		}
 	}
 	s.applyStaffScope(&repoFilter, staff)
-	return s.tickets.ListWithFilter(ctx, repoFilter)
+	return repoFilter, nil
 }
 
 // GetTicketForStaff fetches ticket ensuring staff access.
@@ -217,7 +377,7 @@ func (s *TicketService) GetTicketForStaff(ctx context.Context, staff *domain.Sta
 	if !s.staffCanAccessTicket(staff, ticket) {
 		return nil, nil, apperrors.NewForbidden("access denied")
 	}
-	msgs, err := s.messagesWithAttachments(ctx, ticket.ID)
+	msgs, err := s.loadThread(ctx, ticket.ID)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -225,7 +385,11 @@ func (s *TicketService) GetTicketForStaff(ctx context.Context, staff *domain.Sta
 }
 
 // AddMessage appends a message to a ticket.
-func (s *TicketService) AddMessage(ctx context.Context, actor domain.SubjectType, actorID string, staff *domain.StaffMember, ticketID string, messageType domain.TicketMessageType, body string, attachments []MessageAttachmentInput) (*domain.TicketMessage, error) {
+func (s *TicketService) AddMessage(ctx context.Context, actor domain.SubjectType, actorID string, staff *domain.StaffMember, ticketID string, messageType domain.TicketMessageType, body string, bodyFormat domain.MessageBodyFormat, attachments []MessageAttachmentInput) (*domain.TicketMessage, error) {
+	bodyFormat, body, ok := richtext.Normalize(bodyFormat, body)
+	if !ok {
+		return nil, apperrors.NewValidationError("invalid body_format", map[string]any{"body_format": bodyFormat})
+	}
 	ticket, err := s.tickets.GetByID(ctx, ticketID)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -235,12 +399,12 @@ func (s *TicketService) AddMessage(ctx context.Context, actor domain.SubjectType
 	}
 	switch actor {
 	case domain.SubjectTypeUser:
-		if ticket.RequesterID != actorID {
-			return nil, apperrors.NewForbidden("access denied")
-		}
 		if messageType != domain.MessageTypePublicReply {
 			return nil, apperrors.NewValidationError("users can only post public replies", nil)
 		}
+		if ticket, err = s.ticketForUser(ctx, actorID, ticket.ID); err != nil {
+			return nil, err
+		}
 	case domain.SubjectTypeStaff:
 		if staff == nil {
 			return nil, apperrors.NewUnauthorized("staff context required")
@@ -254,16 +418,25 @@ func (s *TicketService) AddMessage(ctx context.Context, actor domain.SubjectType
 	default:
 		return nil, apperrors.NewInternalError(errors.New("unknown actor"))
 	}
-
+	authorType := domain.AuthorTypeUser
+	if actor == domain.SubjectTypeStaff {
+		authorType = domain.AuthorTypeStaff
+	}
+	attachments, uploadIDs, err := s.resolveUploads(ctx, ticket.ID, authorType, actorID, attachments)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkMessageAttachments(ctx, ticket, attachments); err != nil {
+		return nil, err
+	}
 	msg := &domain.TicketMessage{
-		TicketID:    ticket.ID,
 		MessageType: messageType,
-		Body:        strings.TrimSpace(body),
+		Body:        body,
+		BodyFormat:  bodyFormat,
 	}
 	if actor == domain.SubjectTypeUser {
 		msg.AuthorType = domain.AuthorTypeUser
-		authorID := ticket.RequesterID
-		msg.AuthorID = &authorID
+		msg.AuthorID = &actorID
 	} else {
 		msg.AuthorType = domain.AuthorTypeStaff
 		if staff != nil {
@@ -271,8 +444,55 @@ func (s *TicketService) AddMessage(ctx context.Context, actor domain.SubjectType
 		}
 	}
 
+	// a follow-up ticket and the reply that opened it are stored together, so a failure leaves
+	// neither behind and a retry does not open a second follow-up
+	err = withinTx(ctx, s.tx, func(ctx context.Context) error {
+		if actor == domain.SubjectTypeUser && ticket.Status == domain.TicketStatusClosed && s.effects.FollowUpOnClosedReply {
+			followUp, err := s.createFollowUp(ctx, ticket)
+			if err != nil {
+				return err
+			}
+			ticket = followUp
+		}
+		msg.TicketID = ticket.ID
+		if err := s.postMessage(ctx, ticket, msg, attachments, actorFromSubject(actor, actorID)); err != nil {
+			return err
+		}
+		if len(uploadIDs) > 0 {
+			if err := s.uploads.MarkAttached(ctx, uploadIDs, msg.ID); err != nil {
+				return apperrors.MapError(err)
+			}
+		}
+		if actor == domain.SubjectTypeStaff && messageType == domain.MessageTypePublicReply {
+			if err := s.propagateReply(ctx, staff, ticket, msg, attachments); err != nil {
+				return err
+			}
+		}
+		if actor == domain.SubjectTypeStaff && messageType == domain.MessageTypeInternalNote {
+			if err := s.recordMentions(ctx, staff, ticket, msg); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return msg, nil
+}
+
+// postMessage stores a message with its attachments, publishes it and applies status effects.
+func (s *TicketService) postMessage(ctx context.Context, ticket *domain.Ticket, msg *domain.TicketMessage, attachments []MessageAttachmentInput, actor events.Actor) error {
+	if err := s.storeMessage(ctx, ticket, msg, attachments, actor); err != nil {
+		return err
+	}
+	return s.applyMessageStatusEffects(ctx, ticket, msg)
+}
+
+// storeMessage stores a message with its attachments and publishes it without touching status.
+func (s *TicketService) storeMessage(ctx context.Context, ticket *domain.Ticket, msg *domain.TicketMessage, attachments []MessageAttachmentInput, actor events.Actor) error {
 	if err := s.messages.Create(ctx, msg); err != nil {
-		return nil, apperrors.MapError(err)
+		return apperrors.MapError(err)
 	}
 	for _, att := range attachments {
 		record := &domain.AttachmentReference{
@@ -283,23 +503,27 @@ func (s *TicketService) AddMessage(ctx context.Context, actor domain.SubjectType
 			SizeBytes:       att.SizeBytes,
 		}
 		if err := s.attachments.Create(ctx, record); err != nil {
-			return nil, apperrors.MapError(err)
+			return apperrors.MapError(err)
 		}
 		msg.Attachments = append(msg.Attachments, *record)
 	}
+	s.signAttachmentLinks(msg)
+	if err := s.resolveMessageAuthors(ctx, msg); err != nil {
+		return err
+	}
 	s.publishEvent(ctx, events.Event{
 		Type:     events.EventTicketMessageAdded,
 		TicketID: ticket.ID,
-		Actor:    actorFromSubject(actor, actorID),
+		Actor:    actor,
 		Payload: events.TicketMessageAddedPayload{
 			MessageID:   msg.ID,
 			MessageType: msg.MessageType,
 			AuthorType:  msg.AuthorType,
 			AuthorID:    msg.AuthorID,
-			BodyPreview: stringPreview(msg.Body, 120),
+			BodyPreview: stringPreview(richtext.PlainText(msg.BodyFormat, msg.Body), 120),
 		},
 	})
-	return msg, nil
+	return nil
 }
 
 // CloseTicketAsUser closes ticket when allowed states.
@@ -314,29 +538,23 @@ func (s *TicketService) CloseTicketAsUser(ctx context.Context, userID, ticketID
 	if ticket.RequesterID != userID {
 		return nil, apperrors.NewForbidden("access denied")
 	}
-	if ticket.Status != domain.TicketStatusResolved && ticket.Status != domain.TicketStatusPendingUser {
-		return nil, apperrors.NewConflict("ticket cannot be closed in current status", map[string]any{"status": ticket.Status})
+	workflow, err := activeWorkflow(ctx, s.workflows, ticket.DepartmentID)
+	if err != nil {
+		return nil, err
 	}
-	now := time.Now()
-	oldStatus := ticket.Status
-	ticket.Status = domain.TicketStatusClosed
-	ticket.ClosedAt = &now
-	if err := s.tickets.Update(ctx, ticket); err != nil {
-		return nil, apperrors.MapError(err)
+	var workflowKey *string
+	if workflow != nil {
+		next, err := checkWorkflowTransition(workflow, ticket, string(domain.TicketStatusClosed), "user_closed")
+		if err != nil {
+			return nil, err
+		}
+		workflowKey = &next.Key
+	} else if ticket.Status != domain.TicketStatusResolved && ticket.Status != domain.TicketStatusPendingUser {
+		return nil, apperrors.NewConflict("ticket cannot be closed in current status", map[string]any{"status": ticket.Status})
 	}
-	if err := s.recordStatusChange(ctx, domain.AuthorTypeUser, &userID, ticket.ID, oldStatus, ticket.Status, "user_closed"); err != nil {
+	if err := s.transitionStatus(ctx, ticket, domain.TicketStatusClosed, workflowKey, domain.AuthorTypeUser, &userID, userActor(userID), "user_closed"); err != nil {
 		return nil, err
 	}
-	s.publishEvent(ctx, events.Event{
-		Type:     events.EventTicketStatusChanged,
-		TicketID: ticket.ID,
-		Actor:    userActor(userID),
-		Payload: events.TicketStatusChangedPayload{
-			OldStatus: oldStatus,
-			NewStatus: ticket.Status,
-			Comment:   "user_closed",
-		},
-	})
 	return ticket, nil
 }
 
@@ -355,33 +573,20 @@ func (s *TicketService) UpdateStatus(ctx context.Context, staff *domain.StaffMem
 	if !s.staffCanAccessTicket(staff, ticket) {
 		return nil, apperrors.NewForbidden("access denied")
 	}
-	if !isValidTransition(ticket.Status, newStatus) {
-		return nil, apperrors.NewConflict("invalid status transition", map[string]any{"from": ticket.Status, "to": newStatus})
+	workflow, err := activeWorkflow(ctx, s.workflows, ticket.DepartmentID)
+	if err != nil {
+		return nil, err
 	}
-	oldStatus := ticket.Status
-	if newStatus == domain.TicketStatusClosed {
-		now := time.Now()
-		ticket.ClosedAt = &now
-	} else if ticket.ClosedAt != nil {
-		ticket.ClosedAt = nil
+	status, workflowKey, err := nextStatus(workflow, ticket, string(newStatus), comment)
+	if err != nil {
+		return nil, err
 	}
-	ticket.Status = newStatus
-	if err := s.tickets.Update(ctx, ticket); err != nil {
-		return nil, apperrors.MapError(err)
+	if err := s.transitionStatus(ctx, ticket, status, workflowKey, domain.AuthorTypeStaff, &staff.ID, staffActor(staff.ID), comment); err != nil {
+		return nil, err
 	}
-	if err := s.recordStatusChange(ctx, domain.AuthorTypeStaff, &staff.ID, ticket.ID, oldStatus, newStatus, comment); err != nil {
+	if err := s.propagateStatus(ctx, staff, ticket); err != nil {
 		return nil, err
 	}
-	s.publishEvent(ctx, events.Event{
-		Type:     events.EventTicketStatusChanged,
-		TicketID: ticket.ID,
-		Actor:    staffActor(staff.ID),
-		Payload: events.TicketStatusChangedPayload{
-			OldStatus: oldStatus,
-			NewStatus: newStatus,
-			Comment:   comment,
-		},
-	})
 	return ticket, nil
 }
 
@@ -402,6 +607,7 @@ func (s *TicketService) UpdatePriority(ctx context.Context, staff *domain.StaffM
 	}
 	oldPriority := ticket.Priority
 	ticket.Priority = newPriority
+	ticket.SLADueAt = slaDueAt(s.sla, ticket.CreatedAt, newPriority)
 	if err := s.tickets.Update(ctx, ticket); err != nil {
 		return nil, apperrors.MapError(err)
 	}
@@ -420,11 +626,56 @@ func (s *TicketService) UpdatePriority(ctx context.Context, staff *domain.StaffM
 	return ticket, nil
 }
 
-// ListHistoryForStaff returns history entries for staff.
-func (s *TicketService) ListHistoryForStaff(ctx context.Context, staff *domain.StaffMember, ticketID string, limit, offset int) ([]domain.TicketHistory, error) {
-	if s.history == nil {
-		return []domain.TicketHistory{}, nil
+// UpdateCustomFields applies custom field changes by staff; nil values clear a field.
+func (s *TicketService) UpdateCustomFields(ctx context.Context, staff *domain.StaffMember, ticketID string, changes map[string]any) (*domain.Ticket, error) {
+	if staff == nil {
+		return nil, apperrors.NewUnauthorized("staff required")
+	}
+	ticket, err := s.tickets.GetByID(ctx, ticketID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperrors.NewNotFound("ticket", map[string]any{"ticket_id": ticketID})
+		}
+		return nil, apperrors.MapError(err)
+	}
+	if !s.staffCanAccessTicket(staff, ticket) {
+		return nil, apperrors.NewForbidden("access denied")
+	}
+	defs, err := s.customFieldDefinitions(ctx, &ticket.DepartmentID)
+	if err != nil {
+		return nil, err
+	}
+	updated, err := applyCustomFieldChanges(defs, ticket.CustomFields, changes)
+	if err != nil {
+		return nil, err
+	}
+	oldFields := ticket.CustomFields
+	ticket.CustomFields = updated
+	if err := s.tickets.Update(ctx, ticket); err != nil {
+		return nil, apperrors.MapError(err)
+	}
+	if s.history != nil {
+		if err := s.history.Create(ctx, &domain.TicketHistory{
+			TicketID:      ticket.ID,
+			ChangedByType: domain.AuthorTypeStaff,
+			ChangedByID:   &staff.ID,
+			ChangeType:    domain.ChangeTypeCustomFields,
+			OldValue: map[string]any{
+				"custom_fields": oldFields,
+			},
+			NewValue: map[string]any{
+				"custom_fields": updated,
+			},
+		}); err != nil {
+			return nil, err
+		}
 	}
+	return ticket, nil
+}
+
+// UpdateTags adds and removes catalog tags on a ticket. Only active tags may be added;
+// deprecated ones can still be removed.
+func (s *TicketService) UpdateTags(ctx context.Context, staff *domain.StaffMember, ticketID string, add, remove []string) (*domain.Ticket, error) {
 	if staff == nil {
 		return nil, apperrors.NewUnauthorized("staff required")
 	}
@@ -438,10 +689,89 @@ func (s *TicketService) ListHistoryForStaff(ctx context.Context, staff *domain.S
 	if !s.staffCanAccessTicket(staff, ticket) {
 		return nil, apperrors.NewForbidden("access denied")
 	}
-	return s.history.ListByTicket(ctx, ticketID, limit, offset)
+	added, err := resolveCatalogTags(ctx, s.tags, add)
+	if err != nil {
+		return nil, err
+	}
+	removed := normalizeTagNames(remove)
+	for _, name := range added {
+		if containsString(removed, name) {
+			return nil, apperrors.NewValidationError("tag cannot be both added and removed", map[string]any{"tag": name})
+		}
+	}
+
+	oldTags := append([]string{}, ticket.Tags...)
+	updated := make([]string, 0, len(ticket.Tags)+len(added))
+	for _, name := range ticket.Tags {
+		if !containsString(removed, name) {
+			updated = append(updated, name)
+		}
+	}
+	for _, name := range added {
+		if !containsString(updated, name) {
+			updated = append(updated, name)
+		}
+	}
+	if slices.Equal(oldTags, updated) {
+		return ticket, nil
+	}
+	ticket.Tags = updated
+	if err := s.tickets.Update(ctx, ticket); err != nil {
+		return nil, apperrors.MapError(err)
+	}
+	if s.history != nil {
+		if err := s.history.Create(ctx, &domain.TicketHistory{
+			TicketID:      ticket.ID,
+			ChangedByType: domain.AuthorTypeStaff,
+			ChangedByID:   &staff.ID,
+			ChangeType:    domain.ChangeTypeTags,
+			OldValue: map[string]any{
+				"tags": oldTags,
+			},
+			NewValue: map[string]any{
+				"tags": updated,
+			},
+		}); err != nil {
+			return nil, err
+		}
+	}
+	return ticket, nil
 }
 
-// ListHistoryForUser returns user-safe history entries.
+// ListHistoryForStaff returns history entries for staff.
+func (s *TicketService) ListHistoryForStaff(ctx context.Context, staff *domain.StaffMember, ticketID string, limit int, cursorToken string) (repository.Page[domain.TicketHistory], error) {
+	empty := repository.Page[domain.TicketHistory]{Items: []domain.TicketHistory{}}
+	if s.history == nil {
+		return empty, nil
+	}
+	if staff == nil {
+		return empty, apperrors.NewUnauthorized("staff required")
+	}
+	cursor, err := decodeCursor(cursorToken)
+	if err != nil {
+		return empty, err
+	}
+	ticket, err := s.tickets.GetByID(ctx, ticketID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return empty, apperrors.NewNotFound("ticket", map[string]any{"ticket_id": ticketID})
+		}
+		return empty, apperrors.MapError(err)
+	}
+	if !s.staffCanAccessTicket(staff, ticket) {
+		return empty, apperrors.NewForbidden("access denied")
+	}
+	page, err := s.history.ListByTicket(ctx, ticketID, repository.ClampPageSize(limit), cursor)
+	if err != nil {
+		return empty, err
+	}
+	if err := s.resolveHistoryAuthors(ctx, page.Items); err != nil {
+		return empty, err
+	}
+	return page, nil
+}
+
+// ListHistoryForUser returns user-safe history entries with staff shown as the public agent alias.
 func (s *TicketService) ListHistoryForUser(ctx context.Context, userID, ticketID string) ([]domain.TicketHistory, error) {
 	if s.history == nil {
 		return []domain.TicketHistory{}, nil
@@ -453,22 +783,256 @@ func (s *TicketService) ListHistoryForUser(ctx context.Context, userID, ticketID
 		}
 		return nil, apperrors.MapError(err)
 	}
-	if ticket.RequesterID != userID {
+	if ok, err := s.userCanAccessTicket(ctx, userID, ticket); err != nil {
+		return nil, err
+	} else if !ok {
 		return nil, apperrors.NewForbidden("access denied")
 	}
-	history, err := s.history.ListByTicket(ctx, ticketID, 100, 0)
+	history, err := s.history.ListByTicket(ctx, ticketID, repository.MaxPageSize, nil)
 	if err != nil {
 		return nil, apperrors.MapError(err)
 	}
 	allowed := []domain.TicketHistory{}
-	for _, entry := range history {
-		if entry.ChangeType == domain.ChangeTypeStatus || entry.ChangeType == domain.ChangeTypeAssignee || entry.ChangeType == domain.ChangeTypeTeam {
+	for _, entry := range history.Items {
+		if entry.ChangeType == domain.ChangeTypeStatus {
+			// status comments are staff notes or reason codes such as macro:<name>
+			entry.NewValue = withoutKey(entry.NewValue, "comment")
+		}
+		if entry.ChangeType == domain.ChangeTypeStatus || entry.ChangeType == domain.ChangeTypeAssignee || entry.ChangeType == domain.ChangeTypeTeam || entry.ChangeType == domain.ChangeTypeMerge || entry.ChangeType == domain.ChangeTypeSplit || entry.ChangeType == domain.ChangeTypeFollowUp {
 			allowed = append(allowed, entry)
 		}
 	}
+	if err := s.resolveHistoryAuthors(ctx, allowed); err != nil {
+		return nil, err
+	}
+	s.hideStaffActors(allowed)
 	return allowed, nil
 }
 
+// withoutKey returns a copy of value without key.
+func withoutKey(value map[string]any, key string) map[string]any {
+	if _, ok := value[key]; !ok {
+		return value
+	}
+	result := make(map[string]any, len(value))
+	for k, v := range value {
+		if k != key {
+			result[k] = v
+		}
+	}
+	return result
+}
+
+// transitionStatus moves a ticket to newStatus, records the history entry and publishes the status event.
+// When workflowKey is nil the department workflow status is derived from newStatus.
+func (s *TicketService) transitionStatus(ctx context.Context, ticket *domain.Ticket, newStatus domain.TicketStatus, workflowKey *string, authorType domain.MessageAuthorType, authorID *string, actor events.Actor, comment string) error {
+	oldStatus := ticket.Status
+	oldKey := ticket.WorkflowStatus
+	if workflowKey == nil {
+		key, err := workflowKeyFor(ctx, s.workflows, ticket, newStatus)
+		if err != nil {
+			return err
+		}
+		workflowKey = key
+	}
+	ticket.WorkflowStatus = workflowKey
+	if newStatus == domain.TicketStatusClosed {
+		now := time.Now()
+		ticket.ClosedAt = &now
+	} else if ticket.ClosedAt != nil {
+		ticket.ClosedAt = nil
+	}
+	ticket.Status = newStatus
+	if err := s.tickets.Update(ctx, ticket); err != nil {
+		return apperrors.MapError(err)
+	}
+	if err := s.recordStatusChange(ctx, authorType, authorID, ticket.ID, oldStatus, newStatus, oldKey, ticket.WorkflowStatus, comment); err != nil {
+		return err
+	}
+	s.publishEvent(ctx, events.Event{
+		Type:     events.EventTicketStatusChanged,
+		TicketID: ticket.ID,
+		Actor:    actor,
+		Payload: events.TicketStatusChangedPayload{
+			OldStatus: oldStatus,
+			NewStatus: newStatus,
+			Comment:   comment,
+		},
+	})
+	return nil
+}
+
+// applyMessageStatusEffects moves the ticket along when a new message changes who owes the next reply.
+// Both moves follow the department workflow; when it does not allow one from the current status
+// the message stands without a status change.
+func (s *TicketService) applyMessageStatusEffects(ctx context.Context, ticket *domain.Ticket, msg *domain.TicketMessage) error {
+	if msg.AuthorID == nil {
+		return nil
+	}
+	var target domain.TicketStatus
+	var authorType domain.MessageAuthorType
+	var actor events.Actor
+	var comment string
+	switch msg.AuthorType {
+	case domain.AuthorTypeUser:
+		if !s.effects.ReopenOnRequesterReply {
+			return nil
+		}
+		if ticket.Status != domain.TicketStatusPendingUser && ticket.Status != domain.TicketStatusResolved {
+			return nil
+		}
+		target, authorType, actor, comment = domain.TicketStatusInProgress, domain.AuthorTypeUser, userActor(*msg.AuthorID), "requester_replied"
+	case domain.AuthorTypeStaff:
+		if !s.effects.PendingUserOnStaffReply || msg.MessageType != domain.MessageTypePublicReply {
+			return nil
+		}
+		target, authorType, actor, comment = domain.TicketStatusPendingUser, domain.AuthorTypeStaff, staffActor(*msg.AuthorID), "staff_replied"
+	default:
+		return nil
+	}
+	workflow, err := activeWorkflow(ctx, s.workflows, ticket.DepartmentID)
+	if err != nil {
+		return err
+	}
+	status, workflowKey, err := nextStatus(workflow, ticket, string(target), comment)
+	if err != nil {
+		return nil
+	}
+	return s.transitionStatus(ctx, ticket, status, workflowKey, authorType, msg.AuthorID, actor, comment)
+}
+
+// createFollowUp opens a new ticket for a requester reply on a closed ticket and cross-references
+// both threads and histories. The reply itself becomes the follow-up's first message.
+func (s *TicketService) createFollowUp(ctx context.Context, closed *domain.Ticket) (*domain.Ticket, error) {
+	followUp := &domain.Ticket{
+		ExternalKey:  generateTicketKey(),
+		RequesterID:  closed.RequesterID,
+		DepartmentID: closed.DepartmentID,
+		TeamID:       closed.TeamID,
+		Title:        truncate("Follow-up: "+closed.Title, 200),
+		Description:  fmt.Sprintf("Follow-up to closed ticket %s.", closed.ExternalKey),
+		Status:       domain.TicketStatusOpen,
+		Priority:     closed.Priority,
+		Tags:         closed.Tags,
+		CustomFields: closed.CustomFields,
+	}
+	followUp.SLADueAt = slaDueAt(s.sla, time.Now(), followUp.Priority)
+	if err := s.applyInitialStatus(ctx, followUp); err != nil {
+		return nil, err
+	}
+	if err := s.tickets.Create(ctx, followUp); err != nil {
+		return nil, apperrors.MapError(err)
+	}
+	if s.participants != nil {
+		if _, err := s.participants.CopyToTicket(ctx, closed.ID, followUp.ID); err != nil {
+			return nil, apperrors.MapError(err)
+		}
+	}
+	if err := s.addSystemMessage(ctx, closed.ID, fmt.Sprintf("Requester replied after closure; follow-up ticket %s was opened.", followUp.ExternalKey)); err != nil {
+		return nil, err
+	}
+	if err := s.addSystemMessage(ctx, followUp.ID, followUp.Description); err != nil {
+		return nil, err
+	}
+	if s.history != nil {
+		requesterID := closed.RequesterID
+		if err := s.history.Create(ctx, &domain.TicketHistory{
+			TicketID:      closed.ID,
+			ChangedByType: domain.AuthorTypeUser,
+			ChangedByID:   &requesterID,
+			ChangeType:    domain.ChangeTypeFollowUp,
+			NewValue: map[string]any{
+				"follow_up_ticket_id":    followUp.ID,
+				"follow_up_external_key": followUp.ExternalKey,
+			},
+		}); err != nil {
+			return nil, apperrors.MapError(err)
+		}
+		if err := s.history.Create(ctx, &domain.TicketHistory{
+			TicketID:      followUp.ID,
+			ChangedByType: domain.AuthorTypeUser,
+			ChangedByID:   &requesterID,
+			ChangeType:    domain.ChangeTypeFollowUp,
+			NewValue: map[string]any{
+				"follow_up_of":        closed.ID,
+				"source_external_key": closed.ExternalKey,
+			},
+		}); err != nil {
+			return nil, apperrors.MapError(err)
+		}
+	}
+	s.publishEvent(ctx, events.Event{
+		Type:     events.EventTicketFollowUpOpened,
+		TicketID: closed.ID,
+		Actor:    userActor(closed.RequesterID),
+		Payload: events.TicketFollowUpOpenedPayload{
+			FollowUpTicketID:    followUp.ID,
+			FollowUpExternalKey: followUp.ExternalKey,
+		},
+	})
+	s.publishEvent(ctx, events.Event{
+		Type:     events.EventTicketCreated,
+		TicketID: followUp.ID,
+		Actor:    userActor(followUp.RequesterID),
+		Payload: events.TicketCreatedPayload{
+			DepartmentID: followUp.DepartmentID,
+			TeamID:       followUp.TeamID,
+			Priority:     followUp.Priority,
+			Title:        followUp.Title,
+		},
+	})
+	return followUp, nil
+}
+
+// applyInitialStatus places a new ticket in its department workflow's initial status, if one is active.
+func (s *TicketService) applyInitialStatus(ctx context.Context, ticket *domain.Ticket) error {
+	workflow, err := activeWorkflow(ctx, s.workflows, ticket.DepartmentID)
+	if err != nil {
+		return err
+	}
+	if workflow != nil {
+		initial, _ := workflow.Status(workflow.InitialStatus)
+		ticket.Status = initial.SystemStatus
+		ticket.WorkflowStatus = &initial.Key
+	}
+	return nil
+}
+
+// customFieldDefinitions returns the active fields that apply to a department, or all active fields when nil.
+func (s *TicketService) customFieldDefinitions(ctx context.Context, departmentID *string) ([]domain.CustomFieldDefinition, error) {
+	if s.fields == nil {
+		return nil, nil
+	}
+	defs, err := s.fields.List(ctx, repository.CustomFieldFilter{DepartmentID: departmentID})
+	if err != nil {
+		return nil, apperrors.MapError(err)
+	}
+	return defs, nil
+}
+
+// addSystemMessage appends a SYSTEM_EVENT entry to a ticket thread.
+func (s *TicketService) addSystemMessage(ctx context.Context, ticketID, body string) error {
+	return s.addSystemEntry(ctx, ticketID, domain.MessageTypeSystemEvent, body)
+}
+
+// addSystemNote appends a system-authored internal note, which end-users never see.
+func (s *TicketService) addSystemNote(ctx context.Context, ticketID, body string) error {
+	return s.addSystemEntry(ctx, ticketID, domain.MessageTypeInternalNote, body)
+}
+
+func (s *TicketService) addSystemEntry(ctx context.Context, ticketID string, messageType domain.TicketMessageType, body string) error {
+	msg := &domain.TicketMessage{
+		TicketID:    ticketID,
+		AuthorType:  domain.AuthorTypeSystem,
+		MessageType: messageType,
+		Body:        body,
+	}
+	if err := s.messages.Create(ctx, msg); err != nil {
+		return apperrors.MapError(err)
+	}
+	return nil
+}
+
 func (s *TicketService) applyStaffScope(filter *repository.TicketFilter, staff *domain.StaffMember) {
 	if staff == nil || staff.Role == domain.StaffRoleAdmin {
 		return
@@ -498,7 +1062,7 @@ func (s *TicketService) staffCanAccessTicket(staff *domain.StaffMember, ticket *
 }
 
 func (s *TicketService) visibleMessagesForUser(ctx context.Context, ticketID string) ([]domain.TicketMessage, error) {
-	msgs, err := s.messagesWithAttachments(ctx, ticketID)
+	msgs, err := s.loadThread(ctx, ticketID)
 	if err != nil {
 		return nil, err
 	}
@@ -509,22 +1073,37 @@ func (s *TicketService) visibleMessagesForUser(ctx context.Context, ticketID str
 		}
 		filtered = append(filtered, msg)
 	}
+	s.hideStaffAuthors(filtered)
 	return filtered, nil
 }
 
-func (s *TicketService) messagesWithAttachments(ctx context.Context, ticketID string) ([]domain.TicketMessage, error) {
-	msgs, err := s.messages.ListByTicket(ctx, ticketID)
+// decodeCursor parses a client page token.
+func decodeCursor(token string) (*repository.Cursor, error) {
+	cursor, err := repository.DecodeCursor(token)
 	if err != nil {
-		return nil, err
+		return nil, apperrors.NewValidationError("invalid cursor", map[string]any{"cursor": token})
 	}
-	for i := range msgs {
-		attachments, err := s.attachments.ListByMessage(ctx, msgs[i].ID)
-		if err != nil {
-			return nil, err
-		}
-		msgs[i].Attachments = attachments
+	return cursor, nil
+}
+
+// checkTicketCursor rejects a page token issued for a different sort.
+func checkTicketCursor(cursor *repository.Cursor, sort []repository.TicketSort, token string) error {
+	if err := repository.CheckTicketCursor(cursor, sort); err != nil {
+		return apperrors.NewValidationError("invalid cursor", map[string]any{"cursor": token})
 	}
-	return msgs, nil
+	return nil
+}
+
+// parseTicketSort validates a client sort parameter against the repository whitelist.
+func parseTicketSort(raw string) ([]repository.TicketSort, error) {
+	sort, err := repository.ParseTicketSort(raw)
+	if err != nil {
+		return nil, apperrors.NewValidationError("invalid sort", map[string]any{
+			"sort":    raw,
+			"allowed": repository.TicketSortFields(),
+		})
+	}
+	return sort, nil
 }
 
 func generateTicketKey() string {
@@ -541,7 +1120,7 @@ func (s *TicketService) publishEvent(ctx context.Context, event events.Event) {
 	if event.Timestamp.IsZero() {
 		event.Timestamp = time.Now()
 	}
-	_ = s.dispatcher.Publish(ctx, event)
+	publish(ctx, s.dispatcher, event)
 }
 
 func userActor(userID string) events.Actor {
@@ -558,6 +1137,10 @@ func staffActor(staffID string) events.Actor {
 	}
 }
 
+func systemActor() events.Actor {
+	return events.Actor{Type: domain.SubjectTypeSystem}
+}
+
 func actorFromSubject(subject domain.SubjectType, id string) events.Actor {
 	switch subject {
 	case domain.SubjectTypeStaff:
@@ -567,6 +1150,14 @@ func actorFromSubject(subject domain.SubjectType, id string) events.Actor {
 	}
 }
 
+func truncate(value string, max int) string {
+	runes := []rune(value)
+	if len(runes) <= max {
+		return value
+	}
+	return string(runes[:max])
+}
+
 func stringPreview(body string, max int) string {
 	body = strings.TrimSpace(body)
 	if len(body) <= max {
@@ -596,7 +1187,7 @@ func isValidTransition(current, next domain.TicketStatus) bool {
 	return false
 }
 
-func (s *TicketService) recordStatusChange(ctx context.Context, actorType domain.MessageAuthorType, actorID *string, ticketID string, oldStatus, newStatus domain.TicketStatus, comment string) error {
+func (s *TicketService) recordStatusChange(ctx context.Context, actorType domain.MessageAuthorType, actorID *string, ticketID string, oldStatus, newStatus domain.TicketStatus, oldKey, newKey *string, comment string) error {
 	if s.history == nil {
 		return nil
 	}
@@ -613,6 +1204,10 @@ func (s *TicketService) recordStatusChange(ctx context.Context, actorType domain
 			"comment": comment,
 		},
 	}
+	if oldKey != nil || newKey != nil {
+		entry.OldValue["workflow_status"] = oldKey
+		entry.NewValue["workflow_status"] = newKey
+	}
 	return s.history.Create(ctx, entry)
 }
 
@@ -0,0 +1,154 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spec-kit/ticket-service/internal/domain"
+	"github.com/spec-kit/ticket-service/internal/events"
+	"github.com/spec-kit/ticket-service/internal/richtext"
+	apperrors "github.com/spec-kit/ticket-service/pkg/util/errorutil"
+)
+
+// TicketSplitInput selects the messages to move into a new ticket.
+type TicketSplitInput struct {
+	MessageIDs []string
+	// Title defaults to the source title; Priority defaults to the source priority.
+	Title    string
+	Priority domain.TicketPriority
+}
+
+// SplitTicket moves selected replies and notes into a new ticket for the same requester,
+// department, team and tags, cross-referencing both tickets with system messages and history.
+// The new ticket and the moves are stored in one transaction.
+func (s *TicketService) SplitTicket(ctx context.Context, staff *domain.StaffMember, ticketID string, input TicketSplitInput) (*domain.Ticket, error) {
+	if staff == nil {
+		return nil, apperrors.NewUnauthorized("staff required")
+	}
+	messageIDs := normalizeIDs(input.MessageIDs)
+	if len(messageIDs) == 0 {
+		return nil, apperrors.NewValidationError("message_ids required", nil)
+	}
+	source, err := s.ticketForMerge(ctx, staff, ticketID)
+	if err != nil {
+		return nil, err
+	}
+	switch input.Priority {
+	case "", domain.TicketPriorityLow, domain.TicketPriorityMedium, domain.TicketPriorityHigh, domain.TicketPriorityUrgent:
+	default:
+		return nil, apperrors.NewValidationError("invalid priority", map[string]any{"priority": input.Priority})
+	}
+
+	thread, err := s.messages.ListByTicket(ctx, source.ID)
+	if err != nil {
+		return nil, apperrors.MapError(err)
+	}
+	// thread is in created order, so the first selected public reply becomes the new description
+	var description string
+	selected := 0
+	for _, msg := range thread {
+		if !containsString(messageIDs, msg.ID) {
+			continue
+		}
+		if msg.MessageType == domain.MessageTypeSystemEvent {
+			return nil, apperrors.NewValidationError("system messages cannot be split", map[string]any{"message_id": msg.ID})
+		}
+		if description == "" && msg.MessageType == domain.MessageTypePublicReply {
+			description = strings.TrimSpace(richtext.PlainText(msg.BodyFormat, msg.Body))
+		}
+		selected++
+	}
+	if selected != len(messageIDs) {
+		return nil, apperrors.NewValidationError("messages must belong to the ticket", map[string]any{"ticket_id": source.ID})
+	}
+	if description == "" {
+		description = fmt.Sprintf("Split from %s.", source.ExternalKey)
+	}
+
+	title := strings.TrimSpace(input.Title)
+	if title == "" {
+		title = source.Title
+	}
+	split := &domain.Ticket{
+		ExternalKey:  generateTicketKey(),
+		RequesterID:  source.RequesterID,
+		DepartmentID: source.DepartmentID,
+		TeamID:       source.TeamID,
+		Title:        truncate(title, 200),
+		Description:  description,
+		Status:       domain.TicketStatusOpen,
+		Priority:     input.Priority,
+		Tags:         source.Tags,
+	}
+	if split.Priority == "" {
+		split.Priority = source.Priority
+	}
+	split.SLADueAt = slaDueAt(s.sla, time.Now(), split.Priority)
+	err = withinTx(ctx, s.tx, func(ctx context.Context) error {
+		if err := s.applyInitialStatus(ctx, split); err != nil {
+			return err
+		}
+		if err := s.tickets.Create(ctx, split); err != nil {
+			return apperrors.MapError(err)
+		}
+
+		moved, err := s.messages.MoveMessages(ctx, source.ID, split.ID, messageIDs)
+		if err != nil {
+			return apperrors.MapError(err)
+		}
+		if err := s.addSystemMessage(ctx, source.ID, fmt.Sprintf("%d message(s) were split into new ticket %s.", moved, split.ExternalKey)); err != nil {
+			return err
+		}
+		if err := s.addSystemMessage(ctx, split.ID, fmt.Sprintf("Split from ticket %s.", source.ExternalKey)); err != nil {
+			return err
+		}
+
+		if s.history != nil {
+			if err := s.history.Create(ctx, &domain.TicketHistory{
+				TicketID:      source.ID,
+				ChangedByType: domain.AuthorTypeStaff,
+				ChangedByID:   &staff.ID,
+				ChangeType:    domain.ChangeTypeSplit,
+				NewValue: map[string]any{
+					"split_to":            split.ID,
+					"target_external_key": split.ExternalKey,
+					"message_ids":         messageIDs,
+				},
+			}); err != nil {
+				return err
+			}
+			if err := s.history.Create(ctx, &domain.TicketHistory{
+				TicketID:      split.ID,
+				ChangedByType: domain.AuthorTypeStaff,
+				ChangedByID:   &staff.ID,
+				ChangeType:    domain.ChangeTypeSplit,
+				NewValue: map[string]any{
+					"split_from":          source.ID,
+					"source_external_key": source.ExternalKey,
+					"message_ids":         messageIDs,
+				},
+			}); err != nil {
+				return err
+			}
+		}
+
+		s.publishEvent(ctx, events.Event{
+			Type:     events.EventTicketCreated,
+			TicketID: split.ID,
+			Actor:    staffActor(staff.ID),
+			Payload: events.TicketCreatedPayload{
+				DepartmentID: split.DepartmentID,
+				TeamID:       split.TeamID,
+				Priority:     split.Priority,
+				Title:        split.Title,
+			},
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return split, nil
+}
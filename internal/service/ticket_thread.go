@@ -0,0 +1,50 @@
+package service
+
+import (
+	"context"
+
+	"github.com/spec-kit/ticket-service/internal/domain"
+	apperrors "github.com/spec-kit/ticket-service/pkg/util/errorutil"
+)
+
+// loadThread returns a ticket's messages with attachments and author profiles. It issues the same
+// handful of queries however long the thread is.
+func (s *TicketService) loadThread(ctx context.Context, ticketID string) ([]domain.TicketMessage, error) {
+	msgs, err := s.messages.ListByTicket(ctx, ticketID)
+	if err != nil {
+		return nil, apperrors.MapError(err)
+	}
+	thread := make([]*domain.TicketMessage, len(msgs))
+	for i := range msgs {
+		thread[i] = &msgs[i]
+	}
+	if err := s.hydrateMessages(ctx, thread...); err != nil {
+		return nil, err
+	}
+	return msgs, nil
+}
+
+// hydrateMessages loads attachments for all messages in one query, signs their links and
+// resolves author profiles.
+func (s *TicketService) hydrateMessages(ctx context.Context, msgs ...*domain.TicketMessage) error {
+	if len(msgs) == 0 {
+		return nil
+	}
+	ids := make([]string, 0, len(msgs))
+	for _, msg := range msgs {
+		ids = append(ids, msg.ID)
+	}
+	attachments, err := s.attachments.ListByMessages(ctx, ids)
+	if err != nil {
+		return apperrors.MapError(err)
+	}
+	byMessage := make(map[string][]domain.AttachmentReference, len(msgs))
+	for _, att := range attachments {
+		byMessage[att.TicketMessageID] = append(byMessage[att.TicketMessageID], att)
+	}
+	for _, msg := range msgs {
+		msg.Attachments = byMessage[msg.ID]
+	}
+	s.signAttachmentLinks(msgs...)
+	return s.resolveMessageAuthors(ctx, msgs...)
+}
@@ -0,0 +1,58 @@
+package service
+
+import (
+	"context"
+
+	"github.com/spec-kit/ticket-service/internal/events"
+	"github.com/spec-kit/ticket-service/internal/repository"
+)
+
+type pendingEventsKey struct{}
+
+// pendingEvents holds events published inside a transaction until it commits.
+type pendingEvents struct {
+	dispatchers []events.Dispatcher
+	events      []events.Event
+}
+
+// withinTx runs fn in one transaction. Events published by fn are delivered only once it
+// commits, so subscribers never see changes that were rolled back. Without a transactor fn
+// runs directly but events are still held until it succeeds.
+func withinTx(ctx context.Context, tx repository.Transactor, fn func(ctx context.Context) error) error {
+	if _, nested := ctx.Value(pendingEventsKey{}).(*pendingEvents); nested {
+		if tx == nil {
+			return fn(ctx)
+		}
+		return tx.WithinTx(ctx, fn)
+	}
+	pending := &pendingEvents{}
+	run := func(ctx context.Context) error {
+		return fn(context.WithValue(ctx, pendingEventsKey{}, pending))
+	}
+	var err error
+	if tx == nil {
+		err = run(ctx)
+	} else {
+		err = tx.WithinTx(ctx, run)
+	}
+	if err != nil {
+		return err
+	}
+	for i, event := range pending.events {
+		_ = pending.dispatchers[i].Publish(ctx, event)
+	}
+	return nil
+}
+
+// publish delivers the event now, or when the enclosing withinTx commits.
+func publish(ctx context.Context, dispatcher events.Dispatcher, event events.Event) {
+	if dispatcher == nil {
+		return
+	}
+	if pending, ok := ctx.Value(pendingEventsKey{}).(*pendingEvents); ok {
+		pending.dispatchers = append(pending.dispatchers, dispatcher)
+		pending.events = append(pending.events, event)
+		return
+	}
+	_ = dispatcher.Publish(ctx, event)
+}
@@ -0,0 +1,313 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/spec-kit/ticket-service/internal/domain"
+	"github.com/spec-kit/ticket-service/internal/repository"
+	apperrors "github.com/spec-kit/ticket-service/pkg/util/errorutil"
+)
+
+var workflowKeyPattern = regexp.MustCompile(`^[A-Z][A-Z0-9_]{0,63}$`)
+
+// WorkflowService manages per-department ticket workflows.
+type WorkflowService struct {
+	workflows   repository.WorkflowRepository
+	departments repository.DepartmentRepository
+}
+
+// WorkflowDependencies bundles repositories for workflow management.
+type WorkflowDependencies struct {
+	WorkflowRepo   repository.WorkflowRepository
+	DepartmentRepo repository.DepartmentRepository
+}
+
+// WorkflowInput describes a workflow definition submitted by an admin.
+type WorkflowInput struct {
+	Name          string
+	InitialStatus string
+	Statuses      []domain.WorkflowStatus
+	Transitions   []domain.WorkflowTransition
+	IsActive      *bool
+}
+
+// NewWorkflowService constructs the service.
+func NewWorkflowService(deps WorkflowDependencies) *WorkflowService {
+	return &WorkflowService{
+		workflows:   deps.WorkflowRepo,
+		departments: deps.DepartmentRepo,
+	}
+}
+
+// GetWorkflow returns the workflow configured for a department.
+func (s *WorkflowService) GetWorkflow(ctx context.Context, actor *domain.StaffMember, departmentID string) (*domain.Workflow, error) {
+	if err := requireAdmin(actor); err != nil {
+		return nil, err
+	}
+	workflow, err := s.workflows.GetByDepartment(ctx, departmentID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperrors.NewNotFound("workflow", map[string]any{"department_id": departmentID})
+		}
+		return nil, apperrors.MapError(err)
+	}
+	return workflow, nil
+}
+
+// SaveWorkflow validates and stores the workflow for a department, replacing any existing one.
+func (s *WorkflowService) SaveWorkflow(ctx context.Context, actor *domain.StaffMember, departmentID string, input WorkflowInput) (*domain.Workflow, error) {
+	if err := requireAdmin(actor); err != nil {
+		return nil, err
+	}
+	if _, err := s.departments.GetByID(ctx, departmentID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperrors.NewNotFound("department", map[string]any{"department_id": departmentID})
+		}
+		return nil, apperrors.MapError(err)
+	}
+	workflow := &domain.Workflow{
+		DepartmentID:  departmentID,
+		Name:          strings.TrimSpace(input.Name),
+		InitialStatus: input.InitialStatus,
+		Statuses:      input.Statuses,
+		Transitions:   input.Transitions,
+		IsActive:      true,
+	}
+	if input.IsActive != nil {
+		workflow.IsActive = *input.IsActive
+	}
+	if err := validateWorkflow(workflow); err != nil {
+		return nil, err
+	}
+	if err := s.workflows.Upsert(ctx, workflow); err != nil {
+		return nil, apperrors.MapError(err)
+	}
+	return workflow, nil
+}
+
+// DeleteWorkflow reverts a department to the default workflow.
+func (s *WorkflowService) DeleteWorkflow(ctx context.Context, actor *domain.StaffMember, departmentID string) error {
+	if err := requireAdmin(actor); err != nil {
+		return err
+	}
+	if err := s.workflows.DeleteByDepartment(ctx, departmentID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return apperrors.NewNotFound("workflow", map[string]any{"department_id": departmentID})
+		}
+		return apperrors.MapError(err)
+	}
+	return nil
+}
+
+// validateWorkflow checks the definition and fills in default system statuses.
+func validateWorkflow(workflow *domain.Workflow) error {
+	if workflow.Name == "" {
+		return apperrors.NewValidationError("name required", nil)
+	}
+	if len(workflow.Statuses) == 0 {
+		return apperrors.NewValidationError("at least one status required", nil)
+	}
+	seen := make(map[string]struct{}, len(workflow.Statuses))
+	for i := range workflow.Statuses {
+		status := &workflow.Statuses[i]
+		if !workflowKeyPattern.MatchString(status.Key) {
+			return apperrors.NewValidationError("status key must be upper snake case", map[string]any{"key": status.Key})
+		}
+		if _, dup := seen[status.Key]; dup {
+			return apperrors.NewValidationError("duplicate status key", map[string]any{"key": status.Key})
+		}
+		seen[status.Key] = struct{}{}
+		if strings.TrimSpace(status.Name) == "" {
+			status.Name = status.Key
+		}
+		if status.SystemStatus == "" {
+			status.SystemStatus = defaultSystemStatus(status.Category)
+		}
+		if status.SystemStatus == "" {
+			return apperrors.NewValidationError("invalid status category", map[string]any{"key": status.Key, "category": status.Category})
+		}
+		if !status.SystemStatus.IsValid() {
+			return apperrors.NewValidationError("invalid system status", map[string]any{"key": status.Key, "system_status": status.SystemStatus})
+		}
+		if domain.CategoryForStatus(status.SystemStatus) != status.Category {
+			return apperrors.NewValidationError("system status does not match category", map[string]any{
+				"key":           status.Key,
+				"category":      status.Category,
+				"system_status": status.SystemStatus,
+			})
+		}
+	}
+
+	initial, ok := workflow.Status(workflow.InitialStatus)
+	if !ok {
+		return apperrors.NewValidationError("initial status must be one of the workflow statuses", map[string]any{"initial_status": workflow.InitialStatus})
+	}
+	if initial.Category != domain.StatusCategoryOpen {
+		return apperrors.NewValidationError("initial status must be in the OPEN category", map[string]any{"initial_status": workflow.InitialStatus})
+	}
+
+	pairs := make(map[[2]string]struct{}, len(workflow.Transitions))
+	for _, transition := range workflow.Transitions {
+		if _, ok := seen[transition.From]; !ok && transition.From != domain.WorkflowTransitionAny {
+			return apperrors.NewValidationError("transition references unknown status", map[string]any{"from": transition.From})
+		}
+		if _, ok := seen[transition.To]; !ok {
+			return apperrors.NewValidationError("transition references unknown status", map[string]any{"to": transition.To})
+		}
+		if transition.From == transition.To {
+			return apperrors.NewValidationError("transition must change status", map[string]any{"from": transition.From})
+		}
+		pair := [2]string{transition.From, transition.To}
+		if _, dup := pairs[pair]; dup {
+			return apperrors.NewValidationError("duplicate transition", map[string]any{"from": transition.From, "to": transition.To})
+		}
+		pairs[pair] = struct{}{}
+		for _, field := range transition.RequiredFields {
+			if !isWorkflowField(field) {
+				return apperrors.NewValidationError("unknown required field", map[string]any{"field": field})
+			}
+		}
+	}
+	return nil
+}
+
+func defaultSystemStatus(category domain.StatusCategory) domain.TicketStatus {
+	switch category {
+	case domain.StatusCategoryOpen:
+		return domain.TicketStatusOpen
+	case domain.StatusCategoryPending:
+		return domain.TicketStatusPendingUser
+	case domain.StatusCategorySolved:
+		return domain.TicketStatusResolved
+	case domain.StatusCategoryClosed:
+		return domain.TicketStatusClosed
+	default:
+		return ""
+	}
+}
+
+func isWorkflowField(field string) bool {
+	switch field {
+	case domain.WorkflowFieldAssignee, domain.WorkflowFieldTeam, domain.WorkflowFieldTags:
+		return true
+	default:
+		return false
+	}
+}
+
+// missingWorkflowFields lists required fields that are empty on the ticket.
+func missingWorkflowFields(ticket *domain.Ticket, fields []string) []string {
+	missing := []string{}
+	for _, field := range fields {
+		switch field {
+		case domain.WorkflowFieldAssignee:
+			if ticket.AssigneeID == nil {
+				missing = append(missing, field)
+			}
+		case domain.WorkflowFieldTeam:
+			if ticket.TeamID == nil {
+				missing = append(missing, field)
+			}
+		case domain.WorkflowFieldTags:
+			if len(ticket.Tags) == 0 {
+				missing = append(missing, field)
+			}
+		}
+	}
+	return missing
+}
+
+// activeWorkflow returns the department's workflow, or nil when it uses the default transitions.
+func activeWorkflow(ctx context.Context, workflows repository.WorkflowRepository, departmentID string) (*domain.Workflow, error) {
+	if workflows == nil {
+		return nil, nil
+	}
+	workflow, err := workflows.GetByDepartment(ctx, departmentID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, apperrors.MapError(err)
+	}
+	if !workflow.IsActive {
+		return nil, nil
+	}
+	return workflow, nil
+}
+
+// workflowKeyFor maps a built-in status onto the ticket's department workflow for system-driven changes.
+func workflowKeyFor(ctx context.Context, workflows repository.WorkflowRepository, ticket *domain.Ticket, status domain.TicketStatus) (*string, error) {
+	workflow, err := activeWorkflow(ctx, workflows, ticket.DepartmentID)
+	if err != nil || workflow == nil {
+		return nil, err
+	}
+	if ticket.WorkflowStatus != nil {
+		if current, ok := workflow.Status(*ticket.WorkflowStatus); ok && current.SystemStatus == status {
+			return ticket.WorkflowStatus, nil
+		}
+	}
+	target, ok := workflow.StatusFor(status)
+	if !ok {
+		return nil, nil
+	}
+	return &target.Key, nil
+}
+
+// currentWorkflowKey resolves where the ticket sits in the workflow, falling back to its built-in status.
+func currentWorkflowKey(workflow *domain.Workflow, ticket *domain.Ticket) string {
+	if ticket.WorkflowStatus != nil {
+		if _, ok := workflow.Status(*ticket.WorkflowStatus); ok {
+			return *ticket.WorkflowStatus
+		}
+	}
+	if status, ok := workflow.StatusFor(ticket.Status); ok {
+		return status.Key
+	}
+	return workflow.InitialStatus
+}
+
+// checkWorkflowTransition validates a staff status change against the department workflow.
+// The target may be a workflow key or a built-in status mapped by the workflow.
+func checkWorkflowTransition(workflow *domain.Workflow, ticket *domain.Ticket, target, comment string) (domain.WorkflowStatus, error) {
+	next, ok := workflow.Status(target)
+	if !ok {
+		next, ok = workflow.StatusFor(domain.TicketStatus(target))
+	}
+	if !ok {
+		return domain.WorkflowStatus{}, apperrors.NewValidationError("unknown workflow status", map[string]any{"status": target})
+	}
+	from := currentWorkflowKey(workflow, ticket)
+	transition, ok := workflow.Transition(from, next.Key)
+	if !ok {
+		return domain.WorkflowStatus{}, apperrors.NewConflict("invalid status transition", map[string]any{"from": from, "to": next.Key})
+	}
+	if transition.RequireComment && strings.TrimSpace(comment) == "" {
+		return domain.WorkflowStatus{}, apperrors.NewValidationError("comment required for this transition", map[string]any{"from": from, "to": next.Key})
+	}
+	if missing := missingWorkflowFields(ticket, transition.RequiredFields); len(missing) > 0 {
+		return domain.WorkflowStatus{}, apperrors.NewValidationError("required fields missing for this transition", map[string]any{"from": from, "to": next.Key, "fields": missing})
+	}
+	return next, nil
+}
+
+// nextStatus validates a move to target against the department workflow, or against the built-in
+// transitions when workflow is nil, and returns the system status and workflow key to apply.
+func nextStatus(workflow *domain.Workflow, ticket *domain.Ticket, target, comment string) (domain.TicketStatus, *string, error) {
+	if workflow != nil {
+		next, err := checkWorkflowTransition(workflow, ticket, target, comment)
+		if err != nil {
+			return "", nil, err
+		}
+		return next.SystemStatus, &next.Key, nil
+	}
+	status := domain.TicketStatus(target)
+	if !isValidTransition(ticket.Status, status) {
+		return "", nil, apperrors.NewConflict("invalid status transition", map[string]any{"from": ticket.Status, "to": status})
+	}
+	return status, nil, nil
+}
@@ -0,0 +1,97 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/spec-kit/ticket-service/internal/domain"
+	apperrors "github.com/spec-kit/ticket-service/pkg/util/errorutil"
+)
+
+func testWorkflow() *domain.Workflow {
+	return &domain.Workflow{
+		Name:          "Support",
+		InitialStatus: "NEW",
+		Statuses: []domain.WorkflowStatus{
+			{Key: "NEW", Category: domain.StatusCategoryOpen},
+			{Key: "TRIAGE", Name: "Triage", Category: domain.StatusCategoryOpen, SystemStatus: domain.TicketStatusInProgress},
+			{Key: "WAITING", Category: domain.StatusCategoryPending},
+			{Key: "DONE", Category: domain.StatusCategorySolved},
+			{Key: "ARCHIVED", Category: domain.StatusCategoryClosed},
+		},
+		Transitions: []domain.WorkflowTransition{
+			{From: "NEW", To: "TRIAGE", RequiredFields: []string{domain.WorkflowFieldTeam}},
+			{From: "TRIAGE", To: "WAITING", RequireComment: true},
+			{From: domain.WorkflowTransitionAny, To: "DONE", RequiredFields: []string{domain.WorkflowFieldAssignee, domain.WorkflowFieldTags}},
+			{From: "DONE", To: "ARCHIVED"},
+		},
+	}
+}
+
+func TestValidateWorkflow(t *testing.T) {
+	tests := []struct {
+		name   string
+		mutate func(w *domain.Workflow)
+		want   string
+	}{
+		{"valid", func(w *domain.Workflow) {}, ""},
+		{"missing name", func(w *domain.Workflow) { w.Name = "" }, "name required"},
+		{"no statuses", func(w *domain.Workflow) { w.Statuses = nil }, "at least one status required"},
+		{"lower case key", func(w *domain.Workflow) { w.Statuses[1].Key = "triage" }, "status key must be upper snake case"},
+		{"key with leading digit", func(w *domain.Workflow) { w.Statuses[1].Key = "1ST_LINE" }, "status key must be upper snake case"},
+		{"duplicate key", func(w *domain.Workflow) { w.Statuses[2].Key = "TRIAGE" }, "duplicate status key"},
+		{"unknown category", func(w *domain.Workflow) { w.Statuses[1].Category = "LATER"; w.Statuses[1].SystemStatus = "" }, "invalid status category"},
+		{"unknown system status", func(w *domain.Workflow) { w.Statuses[1].SystemStatus = "BOGUS" }, "invalid system status"},
+		{"category mismatch", func(w *domain.Workflow) { w.Statuses[2].SystemStatus = domain.TicketStatusResolved }, "system status does not match category"},
+		{"cancelled is closed", func(w *domain.Workflow) { w.Statuses[4].SystemStatus = domain.TicketStatusCancelled }, ""},
+		{"unknown initial status", func(w *domain.Workflow) { w.InitialStatus = "START" }, "initial status must be one of the workflow statuses"},
+		{"initial status not open", func(w *domain.Workflow) { w.InitialStatus = "WAITING" }, "initial status must be in the OPEN category"},
+		{"transition from unknown", func(w *domain.Workflow) { w.Transitions[0].From = "START" }, "transition references unknown status"},
+		{"transition to unknown", func(w *domain.Workflow) { w.Transitions[0].To = "START" }, "transition references unknown status"},
+		{"transition to any", func(w *domain.Workflow) { w.Transitions[0].To = domain.WorkflowTransitionAny }, "transition references unknown status"},
+		{"self transition", func(w *domain.Workflow) { w.Transitions[0].To = "NEW" }, "transition must change status"},
+		{"duplicate transition", func(w *domain.Workflow) { w.Transitions[1] = w.Transitions[0] }, "duplicate transition"},
+		{"unknown required field", func(w *domain.Workflow) { w.Transitions[1].RequiredFields = []string{"title"} }, "unknown required field"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			workflow := testWorkflow()
+			tt.mutate(workflow)
+			err := validateWorkflow(workflow)
+			if tt.want == "" {
+				if err != nil {
+					t.Fatalf("validateWorkflow err = %v, want nil", err)
+				}
+				return
+			}
+			derr := apperrors.ToDomainError(err)
+			if err == nil || derr.Code != "VALIDATION_FAILED" || derr.Message != tt.want {
+				t.Errorf("validateWorkflow err = %v, want validation error %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateWorkflowDefaults(t *testing.T) {
+	workflow := testWorkflow()
+	if err := validateWorkflow(workflow); err != nil {
+		t.Fatalf("validateWorkflow err = %v", err)
+	}
+	want := map[string]domain.TicketStatus{
+		"NEW":      domain.TicketStatusOpen,
+		"TRIAGE":   domain.TicketStatusInProgress,
+		"WAITING":  domain.TicketStatusPendingUser,
+		"DONE":     domain.TicketStatusResolved,
+		"ARCHIVED": domain.TicketStatusClosed,
+	}
+	for _, status := range workflow.Statuses {
+		if status.SystemStatus != want[status.Key] {
+			t.Errorf("status %s system status = %s, want %s", status.Key, status.SystemStatus, want[status.Key])
+		}
+	}
+	if status, _ := workflow.Status("NEW"); status.Name != "NEW" {
+		t.Errorf("blank name = %q, want the key", status.Name)
+	}
+	if status, _ := workflow.Status("TRIAGE"); status.Name != "Triage" {
+		t.Errorf("explicit name = %q, want Triage", status.Name)
+	}
+}
@@ -0,0 +1,90 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestValidKey(t *testing.T) {
+	tests := []struct {
+		key  string
+		want bool
+	}{
+		{"attachments/2026/file.pdf", true},
+		{"file..name.txt", true},
+		{".hidden", true},
+		{"", false},
+		{"..", false},
+		{".", false},
+		{"../outside", false},
+		{"a/../../outside", false},
+		{"a/./b", false},
+		{"a/..", false},
+		{"/etc/passwd", false},
+		{"a//b", false},
+		{"a/", false},
+		{`..\outside`, false},
+		{`a\b`, false},
+	}
+	for _, tt := range tests {
+		if got := validKey(tt.key); got != tt.want {
+			t.Errorf("validKey(%q) = %v, want %v", tt.key, got, tt.want)
+		}
+	}
+}
+
+func TestLocalStorage(t *testing.T) {
+	parent := t.TempDir()
+	root := filepath.Join(parent, "root")
+	s, err := NewLocalStorage(root)
+	if err != nil {
+		t.Fatalf("NewLocalStorage: %v", err)
+	}
+	ctx := context.Background()
+
+	if err := s.Put(ctx, "a/b/c.txt", strings.NewReader("hello"), 5, "text/plain"); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+	r, err := s.Get(ctx, "a/b/c.txt")
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	body, _ := io.ReadAll(r)
+	r.Close()
+	if string(body) != "hello" {
+		t.Errorf("Get = %q, want hello", body)
+	}
+	if err := s.Delete(ctx, "a/b/c.txt"); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if _, err := s.Get(ctx, "a/b/c.txt"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Get after delete err = %v, want ErrNotFound", err)
+	}
+	if err := s.Delete(ctx, "a/b/c.txt"); err != nil {
+		t.Errorf("Delete of missing key = %v, want nil", err)
+	}
+
+	secret := filepath.Join(parent, "secret.txt")
+	if err := os.WriteFile(secret, []byte("keep"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	for _, key := range []string{"../secret.txt", "a/../../secret.txt", secret} {
+		if err := s.Put(ctx, key, strings.NewReader("overwritten"), 11, ""); err == nil {
+			t.Errorf("Put(%q) succeeded", key)
+		}
+		if _, err := s.Get(ctx, key); err == nil || errors.Is(err, ErrNotFound) {
+			t.Errorf("Get(%q) err = %v, want an invalid key error", key, err)
+		}
+		if err := s.Delete(ctx, key); err == nil {
+			t.Errorf("Delete(%q) succeeded", key)
+		}
+	}
+	if data, _ := os.ReadFile(secret); string(data) != "keep" {
+		t.Errorf("file outside the root changed to %q", data)
+	}
+}
@@ -0,0 +1,254 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+const (
+	testAccessKey = "AKIDEXAMPLE"
+	testSecretKey = "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY"
+	testRegion    = "eu-west-1"
+	testBucket    = "attachments"
+)
+
+// fakeS3 is an in-memory stand-in for an S3 bucket that checks every request's SigV4 signature.
+type fakeS3 struct {
+	t       *testing.T
+	mu      sync.Mutex
+	objects map[string]fakeObject
+	hosts   []string
+	fail    bool
+}
+
+type fakeObject struct {
+	body        []byte
+	contentType string
+}
+
+func newFakeS3(t *testing.T) (*fakeS3, *httptest.Server) {
+	fake := &fakeS3{t: t, objects: map[string]fakeObject{}}
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+	return fake, server
+}
+
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.hosts = append(f.hosts, r.Host)
+	if err := checkSignature(r); err != "" {
+		f.t.Errorf("%s %s: %s", r.Method, r.URL.Path, err)
+		http.Error(w, "SignatureDoesNotMatch", http.StatusForbidden)
+		return
+	}
+	if f.fail {
+		http.Error(w, "InternalError", http.StatusInternalServerError)
+		return
+	}
+	key := r.URL.Path
+	switch r.Method {
+	case http.MethodPut:
+		if r.ContentLength < 0 {
+			http.Error(w, "MissingContentLength", http.StatusLengthRequired)
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		f.objects[key] = fakeObject{body: body, contentType: r.Header.Get("Content-Type")}
+	case http.MethodGet:
+		object, ok := f.objects[key]
+		if !ok {
+			http.Error(w, "NoSuchKey", http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", object.contentType)
+		_, _ = w.Write(object.body)
+	case http.MethodDelete:
+		if _, ok := f.objects[key]; !ok {
+			http.Error(w, "NoSuchKey", http.StatusNotFound)
+			return
+		}
+		delete(f.objects, key)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+// checkSignature recomputes the SigV4 signature from the request as the server received it.
+func checkSignature(r *http.Request) string {
+	amzDate := r.Header.Get("X-Amz-Date")
+	when, err := time.Parse("20060102T150405Z", amzDate)
+	if err != nil {
+		return "bad X-Amz-Date " + amzDate
+	}
+	if got := r.Header.Get("X-Amz-Content-Sha256"); got != unsignedPayload {
+		return "bad X-Amz-Content-Sha256 " + got
+	}
+	day := when.Format("20060102")
+	scope := day + "/" + testRegion + "/s3/aws4_request"
+	canonicalRequest := strings.Join([]string{
+		r.Method,
+		r.URL.EscapedPath(),
+		r.URL.RawQuery,
+		"host:" + r.Host + "\nx-amz-content-sha256:" + unsignedPayload + "\nx-amz-date:" + amzDate + "\n",
+		"host;x-amz-content-sha256;x-amz-date",
+		unsignedPayload,
+	}, "\n")
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + hashHex(canonicalRequest)
+	key := hmacSHA256([]byte("AWS4"+testSecretKey), day)
+	key = hmacSHA256(key, testRegion)
+	key = hmacSHA256(key, "s3")
+	key = hmacSHA256(key, "aws4_request")
+	want := "AWS4-HMAC-SHA256 Credential=" + testAccessKey + "/" + scope +
+		", SignedHeaders=host;x-amz-content-sha256;x-amz-date, Signature=" + hex.EncodeToString(hmacSHA256(key, stringToSign))
+	if got := r.Header.Get("Authorization"); got != want {
+		return "Authorization = " + got + ", want " + want
+	}
+	return ""
+}
+
+func newTestS3(t *testing.T, endpoint string, pathStyle bool, client *http.Client) *S3Storage {
+	t.Helper()
+	s, err := NewS3Storage(S3Options{
+		Endpoint:  endpoint,
+		Region:    testRegion,
+		Bucket:    testBucket,
+		AccessKey: testAccessKey,
+		SecretKey: testSecretKey,
+		PathStyle: pathStyle,
+		Client:    client,
+	})
+	if err != nil {
+		t.Fatalf("NewS3Storage: %v", err)
+	}
+	s.now = func() time.Time { return time.Date(2026, 3, 4, 5, 6, 7, 0, time.UTC) }
+	return s
+}
+
+func TestS3StoragePutGetDelete(t *testing.T) {
+	fake, server := newFakeS3(t)
+	s := newTestS3(t, server.URL, true, server.Client())
+	ctx := context.Background()
+	key := "tickets/2026/report (final)+1.pdf"
+
+	if err := s.Put(ctx, key, strings.NewReader("hello"), 5, "application/pdf"); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+	object, ok := fake.objects["/"+testBucket+"/"+key]
+	if !ok {
+		t.Fatalf("object not stored under bucket path; have %v", fake.objects)
+	}
+	if string(object.body) != "hello" || object.contentType != "application/pdf" {
+		t.Errorf("stored %q as %q", object.body, object.contentType)
+	}
+
+	r, err := s.Get(ctx, key)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	body, _ := io.ReadAll(r)
+	r.Close()
+	if string(body) != "hello" {
+		t.Errorf("Get = %q, want hello", body)
+	}
+
+	if err := s.Delete(ctx, key); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if _, err := s.Get(ctx, key); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Get after delete err = %v, want ErrNotFound", err)
+	}
+	if err := s.Delete(ctx, key); err != nil {
+		t.Errorf("Delete of missing key = %v, want nil", err)
+	}
+}
+
+func TestS3StorageVirtualHostedStyle(t *testing.T) {
+	fake, server := newFakeS3(t)
+	// resolve the bucket subdomain to the test server
+	addr := server.Listener.Addr().String()
+	client := &http.Client{Transport: &http.Transport{
+		DialContext: func(ctx context.Context, network, _ string) (net.Conn, error) {
+			return (&net.Dialer{}).DialContext(ctx, network, addr)
+		},
+	}}
+	s := newTestS3(t, "http://s3.test:9000/", false, client)
+
+	if err := s.Put(context.Background(), "a/b.txt", bytes.NewReader([]byte("x")), 1, ""); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+	if _, ok := fake.objects["/a/b.txt"]; !ok {
+		t.Errorf("object not stored at key path; have %v", fake.objects)
+	}
+	if want := testBucket + ".s3.test:9000"; len(fake.hosts) != 1 || fake.hosts[0] != want {
+		t.Errorf("hosts = %v, want [%s]", fake.hosts, want)
+	}
+}
+
+func TestS3StorageErrors(t *testing.T) {
+	fake, server := newFakeS3(t)
+	s := newTestS3(t, server.URL, true, server.Client())
+	ctx := context.Background()
+
+	if _, err := s.Get(ctx, "missing"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Get missing err = %v, want ErrNotFound", err)
+	}
+	requests := len(fake.hosts)
+	if err := s.Put(ctx, "k", strings.NewReader("x"), -1, ""); err == nil {
+		t.Error("Put with unknown size succeeded")
+	}
+	for _, key := range []string{"../escape", "a/../../b", "/abs", ""} {
+		if err := s.Put(ctx, key, strings.NewReader("x"), 1, ""); err == nil {
+			t.Errorf("Put(%q) succeeded", key)
+		}
+	}
+	if len(fake.hosts) != requests {
+		t.Errorf("rejected puts reached the server: %v", fake.hosts[requests:])
+	}
+
+	fake.fail = true
+	err := s.Put(ctx, "k", strings.NewReader("x"), 1, "")
+	if err == nil || !strings.Contains(err.Error(), "InternalError") {
+		t.Errorf("Put on server error = %v, want the S3 error detail", err)
+	}
+}
+
+func TestNewS3StorageValidatesOptions(t *testing.T) {
+	valid := S3Options{Endpoint: "https://s3.test", Bucket: "b", AccessKey: "a", SecretKey: "s"}
+	tests := []struct {
+		name   string
+		modify func(*S3Options)
+	}{
+		{"missing bucket", func(o *S3Options) { o.Bucket = "" }},
+		{"missing credentials", func(o *S3Options) { o.SecretKey = "" }},
+		{"no scheme", func(o *S3Options) { o.Endpoint = "s3.test" }},
+		{"unsupported scheme", func(o *S3Options) { o.Endpoint = "ftp://s3.test" }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := valid
+			tt.modify(&opts)
+			if _, err := NewS3Storage(opts); err == nil {
+				t.Error("NewS3Storage succeeded")
+			}
+		})
+	}
+	s, err := NewS3Storage(valid)
+	if err != nil {
+		t.Fatalf("NewS3Storage: %v", err)
+	}
+	if s.opts.Region != "us-east-1" {
+		t.Errorf("default region = %q", s.opts.Region)
+	}
+}
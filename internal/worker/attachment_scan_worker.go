@@ -0,0 +1,52 @@
+package worker
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/spec-kit/ticket-service/internal/service"
+)
+
+// StartAttachmentScanWorker scans pending uploads whenever an upload wakes it and on a fixed
+// interval as a fallback, until ctx is cancelled.
+func StartAttachmentScanWorker(ctx context.Context, scanService *service.AttachmentScanService, interval time.Duration, logger *zap.Logger) {
+	if !scanService.Enabled() {
+		return
+	}
+	ticker := time.NewTicker(interval)
+	go func() {
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			case <-scanService.Wake():
+			}
+			runAttachmentScan(ctx, scanService, logger)
+		}
+	}()
+}
+
+// runAttachmentScan drains full batches so a burst of uploads is not left for the next tick.
+func runAttachmentScan(ctx context.Context, scanService *service.AttachmentScanService, logger *zap.Logger) {
+	for ctx.Err() == nil {
+		result, err := scanService.RunOnce(ctx)
+		if err != nil {
+			logger.Error("attachment scan run failed", zap.Error(err))
+		}
+		scanned := result.Clean + result.Infected + result.Failed
+		if scanned+result.Retrying > 0 {
+			logger.Info("attachment scan run completed",
+				zap.Int("clean", result.Clean),
+				zap.Int("infected", result.Infected),
+				zap.Int("failed", result.Failed),
+				zap.Int("retrying", result.Retrying))
+		}
+		if scanned == 0 || err != nil {
+			return
+		}
+	}
+}
@@ -0,0 +1,26 @@
+package worker
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/spec-kit/ticket-service/internal/service"
+)
+
+// StartAutoCloseWorker closes idle tickets on a fixed interval until ctx is cancelled.
+func StartAutoCloseWorker(ctx context.Context, autoCloseService *service.AutoCloseService, interval time.Duration, logger *zap.Logger) {
+	if autoCloseService == nil {
+		return
+	}
+	runPeriodically(ctx, interval, func(ctx context.Context) {
+		result, err := autoCloseService.RunOnce(ctx, time.Now())
+		if err != nil {
+			logger.Error("auto-close run failed", zap.Error(err))
+		}
+		if result.Warned > 0 || result.Closed > 0 {
+			logger.Info("auto-close run completed", zap.Int("warned", result.Warned), zap.Int("closed", result.Closed))
+		}
+	})
+}
@@ -0,0 +1,26 @@
+package worker
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/spec-kit/ticket-service/internal/service"
+)
+
+// StartEscalationWorker evaluates escalation rules on a fixed interval until ctx is cancelled.
+func StartEscalationWorker(ctx context.Context, escalationService *service.EscalationService, interval time.Duration, logger *zap.Logger) {
+	if escalationService == nil {
+		return
+	}
+	runPeriodically(ctx, interval, func(ctx context.Context) {
+		fired, err := escalationService.RunOnce(ctx, time.Now())
+		if err != nil {
+			logger.Error("escalation run failed", zap.Error(err))
+		}
+		if fired > 0 {
+			logger.Info("escalations fired", zap.Int("count", fired))
+		}
+	})
+}
@@ -0,0 +1,29 @@
+-- +migrate Up
+ALTER TABLE tickets ADD COLUMN sla_due_at TIMESTAMPTZ;
+CREATE INDEX idx_tickets_sla_due_at ON tickets(sla_due_at);
+
+CREATE TYPE escalation_condition AS ENUM ('UNASSIGNED_FOR', 'NO_STAFF_REPLY_FOR', 'SLA_BREACH_WITHIN');
+CREATE TYPE escalation_action AS ENUM ('BUMP_PRIORITY', 'ASSIGN_TEAM_LEAD', 'NOTIFY_MANAGER');
+
+CREATE TABLE escalation_rules (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    name VARCHAR(120) NOT NULL,
+    department_id UUID REFERENCES departments(id),
+    priorities TEXT[] NOT NULL DEFAULT '{}',
+    condition escalation_condition NOT NULL,
+    threshold_minutes INTEGER NOT NULL CHECK (threshold_minutes > 0),
+    action escalation_action NOT NULL,
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+CREATE INDEX idx_escalation_rules_department ON escalation_rules(department_id);
+
+CREATE TABLE escalation_firings (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    rule_id UUID NOT NULL REFERENCES escalation_rules(id) ON DELETE CASCADE,
+    ticket_id UUID NOT NULL REFERENCES tickets(id) ON DELETE CASCADE,
+    fired_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (rule_id, ticket_id)
+);
+CREATE INDEX idx_escalation_firings_ticket ON escalation_firings(ticket_id);
@@ -0,0 +1,3 @@
+-- +migrate Up
+ALTER TABLE tickets ADD COLUMN auto_close_warned_at TIMESTAMPTZ;
+CREATE INDEX idx_tickets_status_updated ON tickets(status, updated_at);
@@ -0,0 +1,15 @@
+-- +migrate Up
+CREATE TABLE ticket_workflows (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    department_id UUID NOT NULL UNIQUE REFERENCES departments(id) ON DELETE CASCADE,
+    name VARCHAR(120) NOT NULL,
+    initial_status VARCHAR(64) NOT NULL,
+    statuses JSONB NOT NULL DEFAULT '[]',
+    transitions JSONB NOT NULL DEFAULT '[]',
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+ALTER TABLE tickets ADD COLUMN workflow_status VARCHAR(64);
+CREATE INDEX idx_tickets_workflow_status ON tickets(department_id, workflow_status);
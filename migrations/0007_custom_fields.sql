@@ -0,0 +1,22 @@
+-- +migrate Up
+CREATE TYPE custom_field_type AS ENUM ('TEXT', 'NUMBER', 'DATE', 'ENUM', 'MULTI_SELECT');
+
+CREATE TABLE custom_field_definitions (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    key VARCHAR(64) NOT NULL UNIQUE,
+    label VARCHAR(120) NOT NULL,
+    type custom_field_type NOT NULL,
+    options TEXT[] NOT NULL DEFAULT '{}',
+    required BOOLEAN NOT NULL DEFAULT FALSE,
+    department_id UUID REFERENCES departments(id) ON DELETE CASCADE,
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_custom_field_definitions_department ON custom_field_definitions(department_id);
+
+ALTER TABLE tickets ADD COLUMN custom_fields JSONB NOT NULL DEFAULT '{}';
+CREATE INDEX idx_tickets_custom_fields ON tickets USING GIN (custom_fields jsonb_path_ops);
+
+ALTER TYPE ticket_change_type ADD VALUE IF NOT EXISTS 'CUSTOM_FIELDS_CHANGE';
@@ -0,0 +1,10 @@
+-- +migrate Up
+CREATE TABLE ticket_forms (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    department_id UUID NOT NULL UNIQUE REFERENCES departments(id) ON DELETE CASCADE,
+    name VARCHAR(120) NOT NULL,
+    fields JSONB NOT NULL DEFAULT '[]',
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
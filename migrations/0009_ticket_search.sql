@@ -0,0 +1,58 @@
+-- +migrate Up
+ALTER TABLE tickets ADD COLUMN search_vector TSVECTOR NOT NULL DEFAULT ''::tsvector;
+ALTER TABLE tickets ADD COLUMN staff_search_vector TSVECTOR NOT NULL DEFAULT ''::tsvector;
+
+-- Weights: A title, B description, C public replies, D internal notes (staff vector only).
+CREATE OR REPLACE FUNCTION ticket_search_document(p_ticket_id UUID, p_title TEXT, p_description TEXT, p_include_internal BOOLEAN)
+RETURNS TSVECTOR AS $$
+    SELECT setweight(to_tsvector('english', COALESCE(p_title, '')), 'A')
+        || setweight(to_tsvector('english', COALESCE(p_description, '')), 'B')
+        || setweight(to_tsvector('english', COALESCE((
+            SELECT string_agg(m.body, ' ') FROM ticket_messages m
+            WHERE m.ticket_id = p_ticket_id AND m.message_type = 'PUBLIC_REPLY'), '')), 'C')
+        || CASE WHEN p_include_internal THEN setweight(to_tsvector('english', COALESCE((
+            SELECT string_agg(m.body, ' ') FROM ticket_messages m
+            WHERE m.ticket_id = p_ticket_id AND m.message_type = 'INTERNAL_NOTE'), '')), 'D')
+           ELSE ''::tsvector END
+$$ LANGUAGE sql STABLE;
+
+CREATE OR REPLACE FUNCTION tickets_search_vector_trigger() RETURNS TRIGGER AS $$
+BEGIN
+    NEW.search_vector := ticket_search_document(NEW.id, NEW.title, NEW.description, FALSE);
+    NEW.staff_search_vector := ticket_search_document(NEW.id, NEW.title, NEW.description, TRUE);
+    RETURN NEW;
+END
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trg_tickets_search_vector
+    BEFORE INSERT OR UPDATE OF title, description ON tickets
+    FOR EACH ROW EXECUTE FUNCTION tickets_search_vector_trigger();
+
+CREATE OR REPLACE FUNCTION ticket_messages_search_vector_trigger() RETURNS TRIGGER AS $$
+DECLARE
+    target UUID;
+BEGIN
+    IF TG_OP = 'DELETE' THEN
+        target := OLD.ticket_id;
+    ELSE
+        target := NEW.ticket_id;
+    END IF;
+    UPDATE tickets t SET
+        search_vector = ticket_search_document(t.id, t.title, t.description, FALSE),
+        staff_search_vector = ticket_search_document(t.id, t.title, t.description, TRUE)
+    WHERE t.id = target;
+    RETURN NULL;
+END
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trg_ticket_messages_search_vector
+    AFTER INSERT OR UPDATE OF body, message_type OR DELETE ON ticket_messages
+    FOR EACH ROW EXECUTE FUNCTION ticket_messages_search_vector_trigger();
+
+UPDATE tickets SET
+    search_vector = ticket_search_document(id, title, description, FALSE),
+    staff_search_vector = ticket_search_document(id, title, description, TRUE);
+
+CREATE INDEX idx_tickets_search_vector ON tickets USING GIN (search_vector);
+CREATE INDEX idx_tickets_staff_search_vector ON tickets USING GIN (staff_search_vector);
+CREATE INDEX idx_tickets_tags ON tickets USING GIN (tags);
@@ -0,0 +1,14 @@
+-- +migrate Up
+CREATE TABLE saved_views (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    owner_staff_id UUID NOT NULL REFERENCES staff_members(id) ON DELETE CASCADE,
+    team_id UUID REFERENCES teams(id) ON DELETE SET NULL,
+    name VARCHAR(120) NOT NULL,
+    filter JSONB NOT NULL DEFAULT '{}',
+    sort VARCHAR(120) NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_saved_views_owner ON saved_views(owner_staff_id);
+CREATE INDEX idx_saved_views_team ON saved_views(team_id) WHERE team_id IS NOT NULL;
@@ -0,0 +1,23 @@
+-- +migrate Up
+CREATE TABLE tags (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    name VARCHAR(50) NOT NULL UNIQUE,
+    color VARCHAR(7),
+    description TEXT NOT NULL DEFAULT '',
+    deprecated BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- normalize existing free-form tags and seed the catalog from them
+UPDATE tickets SET tags = ARRAY(
+    SELECT DISTINCT regexp_replace(lower(btrim(t)), '\s+', '-', 'g')
+    FROM unnest(tags) AS t
+    WHERE btrim(t) <> ''
+)
+WHERE cardinality(tags) > 0;
+
+INSERT INTO tags (name)
+SELECT DISTINCT t FROM tickets, unnest(tags) AS t
+WHERE t <> '' AND length(t) <= 50
+ON CONFLICT (name) DO NOTHING;
@@ -0,0 +1,29 @@
+-- +migrate Up
+ALTER TABLE tickets ADD COLUMN merged_into_ticket_id UUID REFERENCES tickets(id);
+CREATE INDEX idx_tickets_merged_into ON tickets(merged_into_ticket_id) WHERE merged_into_ticket_id IS NOT NULL;
+
+ALTER TYPE ticket_change_type ADD VALUE IF NOT EXISTS 'MERGE';
+
+-- messages can now move between tickets; refresh the search vectors of both sides
+CREATE OR REPLACE FUNCTION ticket_messages_search_vector_trigger() RETURNS TRIGGER AS $$
+BEGIN
+    IF TG_OP <> 'INSERT' THEN
+        UPDATE tickets t SET
+            search_vector = ticket_search_document(t.id, t.title, t.description, FALSE),
+            staff_search_vector = ticket_search_document(t.id, t.title, t.description, TRUE)
+        WHERE t.id = OLD.ticket_id;
+    END IF;
+    IF TG_OP <> 'DELETE' AND (TG_OP = 'INSERT' OR NEW.ticket_id <> OLD.ticket_id) THEN
+        UPDATE tickets t SET
+            search_vector = ticket_search_document(t.id, t.title, t.description, FALSE),
+            staff_search_vector = ticket_search_document(t.id, t.title, t.description, TRUE)
+        WHERE t.id = NEW.ticket_id;
+    END IF;
+    RETURN NULL;
+END
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER trg_ticket_messages_search_vector ON ticket_messages;
+CREATE TRIGGER trg_ticket_messages_search_vector
+    AFTER INSERT OR UPDATE OF body, message_type, ticket_id OR DELETE ON ticket_messages
+    FOR EACH ROW EXECUTE FUNCTION ticket_messages_search_vector_trigger();
@@ -0,0 +1,2 @@
+-- +migrate Up
+ALTER TYPE ticket_change_type ADD VALUE IF NOT EXISTS 'SPLIT';
@@ -0,0 +1,26 @@
+-- +migrate Up
+CREATE TYPE ticket_link_type AS ENUM ('PARENT_OF', 'BLOCKS', 'RELATED', 'DUPLICATE_OF');
+
+CREATE TABLE ticket_links (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    source_ticket_id UUID NOT NULL REFERENCES tickets(id) ON DELETE CASCADE,
+    target_ticket_id UUID NOT NULL REFERENCES tickets(id) ON DELETE CASCADE,
+    link_type ticket_link_type NOT NULL,
+    propagate BOOLEAN NOT NULL DEFAULT FALSE,
+    created_by_staff_id UUID REFERENCES staff_members(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CHECK (source_ticket_id <> target_ticket_id)
+);
+
+-- one link of each type per pair of tickets, whichever direction it was created in
+CREATE UNIQUE INDEX idx_ticket_links_pair ON ticket_links (
+    LEAST(source_ticket_id, target_ticket_id),
+    GREATEST(source_ticket_id, target_ticket_id),
+    link_type
+);
+-- a ticket has at most one parent
+CREATE UNIQUE INDEX idx_ticket_links_single_parent ON ticket_links(target_ticket_id) WHERE link_type = 'PARENT_OF';
+CREATE INDEX idx_ticket_links_source ON ticket_links(source_ticket_id);
+CREATE INDEX idx_ticket_links_target ON ticket_links(target_ticket_id);
+
+ALTER TYPE ticket_change_type ADD VALUE IF NOT EXISTS 'LINK';
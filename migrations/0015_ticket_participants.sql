@@ -0,0 +1,21 @@
+-- +migrate Up
+CREATE TYPE ticket_participant_role AS ENUM ('WATCHER', 'CC');
+
+CREATE TABLE ticket_participants (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    ticket_id UUID NOT NULL REFERENCES tickets(id) ON DELETE CASCADE,
+    role ticket_participant_role NOT NULL,
+    staff_id UUID REFERENCES staff_members(id) ON DELETE CASCADE,
+    user_id UUID REFERENCES users(id) ON DELETE CASCADE,
+    email VARCHAR(320),
+    added_by_type message_author_type NOT NULL,
+    added_by_id UUID,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CHECK (num_nonnulls(staff_id, user_id, email) = 1),
+    CHECK ((role = 'WATCHER') = (staff_id IS NOT NULL))
+);
+
+CREATE UNIQUE INDEX idx_ticket_participants_staff ON ticket_participants(ticket_id, staff_id) WHERE staff_id IS NOT NULL;
+CREATE UNIQUE INDEX idx_ticket_participants_user ON ticket_participants(ticket_id, user_id) WHERE user_id IS NOT NULL;
+CREATE UNIQUE INDEX idx_ticket_participants_email ON ticket_participants(ticket_id, LOWER(email)) WHERE email IS NOT NULL;
+CREATE INDEX idx_ticket_participants_user_lookup ON ticket_participants(user_id) WHERE user_id IS NOT NULL;
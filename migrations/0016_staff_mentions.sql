@@ -0,0 +1,17 @@
+-- +migrate Up
+CREATE TABLE staff_mentions (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    ticket_id UUID NOT NULL REFERENCES tickets(id) ON DELETE CASCADE,
+    message_id UUID NOT NULL REFERENCES ticket_messages(id) ON DELETE CASCADE,
+    mentioned_staff_id UUID NOT NULL REFERENCES staff_members(id) ON DELETE CASCADE,
+    mentioned_by_staff_id UUID REFERENCES staff_members(id) ON DELETE SET NULL,
+    read_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (message_id, mentioned_staff_id)
+);
+
+CREATE INDEX idx_staff_mentions_inbox ON staff_mentions(mentioned_staff_id, created_at DESC, id DESC);
+CREATE INDEX idx_staff_mentions_unread ON staff_mentions(mentioned_staff_id) WHERE read_at IS NULL;
+
+-- mention handles compare names with everything but letters and digits stripped
+CREATE INDEX idx_staff_members_handle ON staff_members ((regexp_replace(lower(name), '[^a-z0-9]', '', 'g')));
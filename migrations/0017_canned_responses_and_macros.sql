@@ -0,0 +1,29 @@
+-- +migrate Up
+CREATE TABLE canned_responses (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    owner_staff_id UUID NOT NULL REFERENCES staff_members(id) ON DELETE CASCADE,
+    team_id UUID REFERENCES teams(id) ON DELETE SET NULL,
+    name VARCHAR(120) NOT NULL,
+    body TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_canned_responses_owner ON canned_responses(owner_staff_id);
+CREATE INDEX idx_canned_responses_team ON canned_responses(team_id) WHERE team_id IS NOT NULL;
+
+CREATE TABLE macros (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    owner_staff_id UUID NOT NULL REFERENCES staff_members(id) ON DELETE CASCADE,
+    team_id UUID REFERENCES teams(id) ON DELETE SET NULL,
+    name VARCHAR(120) NOT NULL,
+    reply_body TEXT NOT NULL DEFAULT '',
+    reply_type ticket_message_type NOT NULL DEFAULT 'PUBLIC_REPLY',
+    actions JSONB NOT NULL DEFAULT '{}',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CHECK (reply_type <> 'SYSTEM_EVENT')
+);
+
+CREATE INDEX idx_macros_owner ON macros(owner_staff_id);
+CREATE INDEX idx_macros_team ON macros(team_id) WHERE team_id IS NOT NULL;
@@ -0,0 +1,21 @@
+-- +migrate Up
+ALTER TYPE ticket_change_type ADD VALUE IF NOT EXISTS 'MESSAGE_EDIT';
+ALTER TYPE ticket_change_type ADD VALUE IF NOT EXISTS 'MESSAGE_REDACT';
+
+ALTER TABLE ticket_messages
+    ADD COLUMN edited_at TIMESTAMPTZ,
+    ADD COLUMN redacted_at TIMESTAMPTZ;
+
+CREATE TABLE ticket_message_revisions (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    message_id UUID NOT NULL REFERENCES ticket_messages(id) ON DELETE CASCADE,
+    kind VARCHAR(16) NOT NULL CHECK (kind IN ('EDIT', 'REDACT')),
+    editor_staff_id UUID REFERENCES staff_members(id) ON DELETE SET NULL,
+    previous_body TEXT,
+    redacted_segments INT NOT NULL DEFAULT 0,
+    removed_attachments JSONB NOT NULL DEFAULT '[]',
+    reason TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_ticket_message_revisions_message ON ticket_message_revisions(message_id, created_at);
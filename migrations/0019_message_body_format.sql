@@ -0,0 +1,4 @@
+-- +migrate Up
+ALTER TABLE ticket_messages
+    ADD COLUMN body_format VARCHAR(16) NOT NULL DEFAULT 'text'
+        CHECK (body_format IN ('text', 'markdown', 'html'));
@@ -0,0 +1,16 @@
+-- +migrate Up
+CREATE TABLE attachment_uploads (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    ticket_id UUID NOT NULL REFERENCES tickets(id) ON DELETE CASCADE,
+    storage_key VARCHAR(255) NOT NULL UNIQUE,
+    file_name VARCHAR(255) NOT NULL,
+    mime_type VARCHAR(128) NOT NULL,
+    size_bytes BIGINT NOT NULL,
+    uploader_type message_author_type NOT NULL,
+    uploader_id UUID NOT NULL,
+    message_id UUID REFERENCES ticket_messages(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_attachment_uploads_ticket ON attachment_uploads(ticket_id) WHERE message_id IS NULL;
+CREATE INDEX idx_attachment_references_storage_key ON attachment_references(storage_key);
@@ -0,0 +1,3 @@
+-- +migrate Up
+ALTER TABLE departments
+    ADD COLUMN allowed_attachment_types TEXT[] NOT NULL DEFAULT '{}';
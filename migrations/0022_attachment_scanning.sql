@@ -0,0 +1,16 @@
+-- +migrate Up
+CREATE TYPE attachment_scan_status AS ENUM ('PENDING', 'CLEAN', 'INFECTED', 'FAILED');
+
+-- files stored before scanning existed are treated as clean
+ALTER TABLE attachment_uploads
+    ADD COLUMN scan_status attachment_scan_status NOT NULL DEFAULT 'CLEAN',
+    ADD COLUMN scan_signature TEXT,
+    ADD COLUMN scan_attempts INT NOT NULL DEFAULT 0,
+    ADD COLUMN scanned_at TIMESTAMPTZ;
+ALTER TABLE attachment_uploads ALTER COLUMN scan_status SET DEFAULT 'PENDING';
+
+ALTER TABLE attachment_references
+    ADD COLUMN scan_status attachment_scan_status NOT NULL DEFAULT 'CLEAN';
+ALTER TABLE attachment_references ALTER COLUMN scan_status SET DEFAULT 'PENDING';
+
+CREATE INDEX idx_attachment_uploads_scan_pending ON attachment_uploads(created_at) WHERE scan_status = 'PENDING';
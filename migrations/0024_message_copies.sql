@@ -0,0 +1,5 @@
+-- +migrate Up
+-- Replies propagated from a parent ticket point at the reply they copy so redaction reaches them.
+ALTER TABLE ticket_messages ADD COLUMN copied_from_message_id UUID REFERENCES ticket_messages(id) ON DELETE SET NULL;
+
+CREATE INDEX idx_ticket_messages_copied_from ON ticket_messages (copied_from_message_id) WHERE copied_from_message_id IS NOT NULL;
@@ -0,0 +1,26 @@
+-- +migrate Up
+-- Index the text of HTML message bodies rather than their markup, matching the search snippets.
+CREATE OR REPLACE FUNCTION ticket_search_document(p_ticket_id UUID, p_title TEXT, p_description TEXT, p_include_internal BOOLEAN)
+RETURNS TSVECTOR AS $$
+    SELECT setweight(to_tsvector('english', COALESCE(p_title, '')), 'A')
+        || setweight(to_tsvector('english', COALESCE(p_description, '')), 'B')
+        || setweight(to_tsvector('english', COALESCE((
+            SELECT string_agg(CASE WHEN m.body_format = 'html' THEN regexp_replace(m.body, '<[^>]*>', ' ', 'g') ELSE m.body END, ' ')
+            FROM ticket_messages m
+            WHERE m.ticket_id = p_ticket_id AND m.message_type = 'PUBLIC_REPLY'), '')), 'C')
+        || CASE WHEN p_include_internal THEN setweight(to_tsvector('english', COALESCE((
+            SELECT string_agg(CASE WHEN m.body_format = 'html' THEN regexp_replace(m.body, '<[^>]*>', ' ', 'g') ELSE m.body END, ' ')
+            FROM ticket_messages m
+            WHERE m.ticket_id = p_ticket_id AND m.message_type = 'INTERNAL_NOTE'), '')), 'D')
+           ELSE ''::tsvector END
+$$ LANGUAGE sql STABLE;
+
+DROP TRIGGER trg_ticket_messages_search_vector ON ticket_messages;
+CREATE TRIGGER trg_ticket_messages_search_vector
+    AFTER INSERT OR UPDATE OF body, body_format, message_type, ticket_id OR DELETE ON ticket_messages
+    FOR EACH ROW EXECUTE FUNCTION ticket_messages_search_vector_trigger();
+
+UPDATE tickets SET
+    search_vector = ticket_search_document(id, title, description, FALSE),
+    staff_search_vector = ticket_search_document(id, title, description, TRUE)
+WHERE EXISTS (SELECT 1 FROM ticket_messages m WHERE m.ticket_id = tickets.id AND m.body_format = 'html');
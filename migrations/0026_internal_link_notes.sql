@@ -0,0 +1,5 @@
+-- +migrate Up
+-- Link change messages name the other ticket, which may belong to another requester; keep them internal.
+UPDATE ticket_messages SET message_type = 'INTERNAL_NOTE'
+WHERE author_type = 'SYSTEM' AND message_type = 'SYSTEM_EVENT'
+  AND (body LIKE 'Link added: this ticket %' OR body LIKE 'Link removed: this ticket %');